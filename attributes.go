@@ -0,0 +1,78 @@
+package main
+
+import (
+	"log/slog"
+
+	"github.com/NVIDIA/go-nvml/pkg/nvml"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+func init() {
+	registerCollector("attributes", defaultEnabled, newAttributesCollector)
+}
+
+// attributesCollector exports the static device attributes reported by
+// nvmlDeviceGetAttributes along with the maximum number of MIG devices.
+type attributesCollector struct {
+	logger *slog.Logger
+
+	maxMigDevices         *prometheus.Desc
+	multiprocessorCount   *prometheus.Desc
+	gpuInstanceSlices     *prometheus.Desc
+	computeInstanceSlices *prometheus.Desc
+	engineCount           *prometheus.Desc
+}
+
+func newAttributesCollector(logger *slog.Logger) collector {
+	return &attributesCollector{
+		logger: logger,
+		maxMigDevices: deviceDesc("", "max_mig_devices",
+			"Maximum number of MIG devices that can exist on the GPU device."),
+		multiprocessorCount: deviceDesc("", "multiprocessors",
+			"Number of streaming multiprocessors of the GPU device."),
+		gpuInstanceSlices: deviceDesc("", "gpu_instance_slices",
+			"Number of GPU instance slices of the GPU device."),
+		computeInstanceSlices: deviceDesc("", "compute_instance_slices",
+			"Number of compute instance slices of the GPU device."),
+		engineCount: deviceDesc("", "engines",
+			"Number of shared engines of the given type (copy, decoder, encoder, jpeg or ofa) of the GPU device.",
+			"engine"),
+	}
+}
+
+func (c *attributesCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.maxMigDevices
+	ch <- c.multiprocessorCount
+	ch <- c.gpuInstanceSlices
+	ch <- c.computeInstanceSlices
+	ch <- c.engineCount
+}
+
+func (c *attributesCollector) Collect(ch chan<- prometheus.Metric, devices []*device) {
+	for _, d := range devices {
+		if count, ret := d.GetMaxMigDeviceCount(); ret == nvml.SUCCESS {
+			ch <- prometheus.MustNewConstMetric(c.maxMigDevices, prometheus.GaugeValue, float64(count), d.labels...)
+		} else {
+			c.logger.Debug("failed to get max MIG device count", "uuid", d.uuid, "err", ret)
+		}
+
+		attributes, ret := d.GetAttributes()
+		if ret != nvml.SUCCESS {
+			c.logger.Debug("failed to get device attributes", "uuid", d.uuid, "err", ret)
+			continue
+		}
+		ch <- prometheus.MustNewConstMetric(c.multiprocessorCount, prometheus.GaugeValue, float64(attributes.MultiprocessorCount), d.labels...)
+		ch <- prometheus.MustNewConstMetric(c.gpuInstanceSlices, prometheus.GaugeValue, float64(attributes.GpuInstanceSliceCount), d.labels...)
+		ch <- prometheus.MustNewConstMetric(c.computeInstanceSlices, prometheus.GaugeValue, float64(attributes.ComputeInstanceSliceCount), d.labels...)
+
+		for engine, count := range map[string]uint32{
+			"copy":    attributes.SharedCopyEngineCount,
+			"decoder": attributes.SharedDecoderCount,
+			"encoder": attributes.SharedEncoderCount,
+			"jpeg":    attributes.SharedJpegCount,
+			"ofa":     attributes.SharedOfaCount,
+		} {
+			ch <- prometheus.MustNewConstMetric(c.engineCount, prometheus.GaugeValue, float64(count), d.labelsWith(engine)...)
+		}
+	}
+}
@@ -0,0 +1,155 @@
+package main
+
+import (
+	"cmp"
+	"flag"
+	"fmt"
+	"log/slog"
+	"os"
+	"runtime"
+	"slices"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/NVIDIA/go-nvml/pkg/nvml"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// runBench runs the bench subcommand, which measures the cost of collecting
+// each collector and of the NVML calls they make, and returns the exit code.
+func runBench(args []string) int {
+	fs := flag.NewFlagSet("bench", flag.ExitOnError)
+	iterations := fs.Int("iterations", 100, "Number of collections of each collector.")
+	names := fs.String("collectors", "", "Comma-separated collectors to benchmark. Defaults to the collectors enabled by default.")
+	fs.Usage = func() {
+		fmt.Fprintf(fs.Output(), "Usage: %s bench [flags]\n\nMeasures the cost of each collector and the NVML calls it makes.\n\n", os.Args[0])
+		fs.PrintDefaults()
+	}
+	fs.Parse(args)
+
+	var selected []string
+	for _, name := range collectorNames() {
+		if *names == "" && collectorDefaults[name] {
+			selected = append(selected, name)
+		}
+	}
+	for _, name := range strings.Split(*names, ",") {
+		if name = strings.TrimSpace(name); name == "" {
+			continue
+		}
+		if collectorFactories[name] == nil {
+			fmt.Fprintf(os.Stderr, "unknown collector %q\n", name)
+			return 2
+		}
+		selected = append(selected, name)
+	}
+	if *iterations < 1 {
+		fmt.Fprintln(os.Stderr, "-iterations must be at least 1")
+		return 2
+	}
+
+	logger := slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelWarn}))
+	if ret := nvml.Init(); ret != nvml.SUCCESS {
+		logger.Error("failed to initialize NVML", "err", ret)
+		return 1
+	}
+	defer nvml.Shutdown()
+
+	// The devices returned by NVML are wrapped in timedDevice from here on.
+	calls := make(map[string]*benchStats)
+	timeLibraryCalls(calls)
+	devices, _, err := enumerateDevices(logger)
+	if err != nil {
+		logger.Error("failed to enumerate devices", "err", err)
+		return 1
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', tabwriter.AlignRight)
+	fmt.Fprintf(w, "%d devices, %d iterations\n\n", len(devices), *iterations)
+	fmt.Fprintln(w, "COLLECTOR\tMEAN\tMAX\tMETRICS\tALLOCS/OP\tBYTES/OP\t")
+	for _, name := range selected {
+		c := collectorFactories[name](logger.With("collector", name))
+		var stats benchStats
+		var metrics int
+		var before, after runtime.MemStats
+		runtime.ReadMemStats(&before)
+		for range *iterations {
+			start := time.Now()
+			metrics = benchCollect(c, devices)
+			stats.add(time.Since(start))
+		}
+		runtime.ReadMemStats(&after)
+		n := uint64(*iterations)
+		fmt.Fprintf(w, "%s\t%v\t%v\t%d\t%d\t%d\t\n", name, stats.mean(), stats.max, metrics,
+			(after.Mallocs-before.Mallocs)/n, (after.TotalAlloc-before.TotalAlloc)/n)
+	}
+
+	fmt.Fprintln(w, "\nNVML CALL\tCALLS\tMEAN\tMAX\tTOTAL\t")
+	callNames := make([]string, 0, len(calls))
+	for name := range calls {
+		callNames = append(callNames, name)
+	}
+	slices.SortFunc(callNames, func(a, b string) int { return cmp.Compare(calls[b].total, calls[a].total) })
+	for _, name := range callNames {
+		s := calls[name]
+		fmt.Fprintf(w, "%s\t%d\t%v\t%v\t%v\t\n", name, s.count, s.mean(), s.max, s.total)
+	}
+	w.Flush()
+	return 0
+}
+
+// benchCollect collects c once and returns the number of metrics.
+func benchCollect(c collector, devices []*device) int {
+	ch := make(chan prometheus.Metric)
+	go func() {
+		c.Collect(ch, devices)
+		close(ch)
+	}()
+	n := 0
+	for range ch {
+		n++
+	}
+	return n
+}
+
+// benchStats accumulates durations.
+type benchStats struct {
+	count int
+	total time.Duration
+	max   time.Duration
+}
+
+func (s *benchStats) add(d time.Duration) {
+	s.count++
+	s.total += d
+	s.max = max(s.max, d)
+}
+
+func (s *benchStats) mean() time.Duration {
+	if s.count == 0 {
+		return 0
+	}
+	return s.total / time.Duration(s.count)
+}
+
+// timedDevice records the duration of the NVML device calls made by the
+// collectors. Its methods and timeLibraryCalls in bench_nvml.go are generated
+// from nvml.Device and nvml.Interface, so that every call is timed.
+//
+//go:generate go run gen_bench_nvml.go
+type timedDevice struct {
+	nvml.Device
+	calls map[string]*benchStats
+}
+
+// recordCall adds the duration of the NVML call name started at start to
+// calls.
+func recordCall(calls map[string]*benchStats, name string, start time.Time) {
+	s := calls[name]
+	if s == nil {
+		s = &benchStats{}
+		calls[name] = s
+	}
+	s.add(time.Since(start))
+}
@@ -0,0 +1,4309 @@
+// Code generated by go run gen_bench_nvml.go; DO NOT EDIT.
+
+package main
+
+import (
+	"time"
+
+	"github.com/NVIDIA/go-nvml/pkg/nvml"
+)
+
+func (d timedDevice) ClearAccountingPids() nvml.Return {
+	defer recordCall(d.calls, "ClearAccountingPids", time.Now())
+	return d.Device.ClearAccountingPids()
+}
+
+func (d timedDevice) ClearCpuAffinity() nvml.Return {
+	defer recordCall(d.calls, "ClearCpuAffinity", time.Now())
+	return d.Device.ClearCpuAffinity()
+}
+
+func (d timedDevice) ClearEccErrorCounts(a0 nvml.EccCounterType) nvml.Return {
+	defer recordCall(d.calls, "ClearEccErrorCounts", time.Now())
+	return d.Device.ClearEccErrorCounts(a0)
+}
+
+func (d timedDevice) ClearFieldValues(a0 []nvml.FieldValue) nvml.Return {
+	defer recordCall(d.calls, "ClearFieldValues", time.Now())
+	return d.Device.ClearFieldValues(a0)
+}
+
+func (d timedDevice) CreateGpuInstance(a0 *nvml.GpuInstanceProfileInfo) (nvml.GpuInstance, nvml.Return) {
+	defer recordCall(d.calls, "CreateGpuInstance", time.Now())
+	return d.Device.CreateGpuInstance(a0)
+}
+
+func (d timedDevice) CreateGpuInstanceWithPlacement(a0 *nvml.GpuInstanceProfileInfo, a1 *nvml.GpuInstancePlacement) (nvml.GpuInstance, nvml.Return) {
+	defer recordCall(d.calls, "CreateGpuInstanceWithPlacement", time.Now())
+	return d.Device.CreateGpuInstanceWithPlacement(a0, a1)
+}
+
+func (d timedDevice) FreezeNvLinkUtilizationCounter(a0 int, a1 int, a2 nvml.EnableState) nvml.Return {
+	defer recordCall(d.calls, "FreezeNvLinkUtilizationCounter", time.Now())
+	return d.Device.FreezeNvLinkUtilizationCounter(a0, a1, a2)
+}
+
+func (d timedDevice) GetAPIRestriction(a0 nvml.RestrictedAPI) (nvml.EnableState, nvml.Return) {
+	defer recordCall(d.calls, "GetAPIRestriction", time.Now())
+	return d.Device.GetAPIRestriction(a0)
+}
+
+func (d timedDevice) GetAccountingBufferSize() (int, nvml.Return) {
+	defer recordCall(d.calls, "GetAccountingBufferSize", time.Now())
+	return d.Device.GetAccountingBufferSize()
+}
+
+func (d timedDevice) GetAccountingMode() (nvml.EnableState, nvml.Return) {
+	defer recordCall(d.calls, "GetAccountingMode", time.Now())
+	return d.Device.GetAccountingMode()
+}
+
+func (d timedDevice) GetAccountingPids() ([]int, nvml.Return) {
+	defer recordCall(d.calls, "GetAccountingPids", time.Now())
+	return d.Device.GetAccountingPids()
+}
+
+func (d timedDevice) GetAccountingStats(a0 uint32) (nvml.AccountingStats, nvml.Return) {
+	defer recordCall(d.calls, "GetAccountingStats", time.Now())
+	return d.Device.GetAccountingStats(a0)
+}
+
+func (d timedDevice) GetAccountingStats_v2(a0 uint32) (nvml.AccountingStats_v2, nvml.Return) {
+	defer recordCall(d.calls, "GetAccountingStats_v2", time.Now())
+	return d.Device.GetAccountingStats_v2(a0)
+}
+
+func (d timedDevice) GetActiveVgpus() ([]nvml.VgpuInstance, nvml.Return) {
+	defer recordCall(d.calls, "GetActiveVgpus", time.Now())
+	return d.Device.GetActiveVgpus()
+}
+
+func (d timedDevice) GetAdaptiveClockInfoStatus() (uint32, nvml.Return) {
+	defer recordCall(d.calls, "GetAdaptiveClockInfoStatus", time.Now())
+	return d.Device.GetAdaptiveClockInfoStatus()
+}
+
+func (d timedDevice) GetAdaptiveTgpModeInfo_v1() (nvml.AdaptiveTgpModeInfo_v1, nvml.Return) {
+	defer recordCall(d.calls, "GetAdaptiveTgpModeInfo_v1", time.Now())
+	return d.Device.GetAdaptiveTgpModeInfo_v1()
+}
+
+func (d timedDevice) GetAddressingMode() (nvml.DeviceAddressingMode, nvml.Return) {
+	defer recordCall(d.calls, "GetAddressingMode", time.Now())
+	return d.Device.GetAddressingMode()
+}
+
+func (d timedDevice) GetApplicationsClock(a0 nvml.ClockType) (uint32, nvml.Return) {
+	defer recordCall(d.calls, "GetApplicationsClock", time.Now())
+	return d.Device.GetApplicationsClock(a0)
+}
+
+func (d timedDevice) GetArchitecture() (nvml.DeviceArchitecture, nvml.Return) {
+	defer recordCall(d.calls, "GetArchitecture", time.Now())
+	return d.Device.GetArchitecture()
+}
+
+func (d timedDevice) GetAttributes() (nvml.DeviceAttributes, nvml.Return) {
+	defer recordCall(d.calls, "GetAttributes", time.Now())
+	return d.Device.GetAttributes()
+}
+
+func (d timedDevice) GetAutoBoostedClocksEnabled() (nvml.EnableState, nvml.EnableState, nvml.Return) {
+	defer recordCall(d.calls, "GetAutoBoostedClocksEnabled", time.Now())
+	return d.Device.GetAutoBoostedClocksEnabled()
+}
+
+func (d timedDevice) GetBAR1MemoryInfo() (nvml.BAR1Memory, nvml.Return) {
+	defer recordCall(d.calls, "GetBAR1MemoryInfo", time.Now())
+	return d.Device.GetBAR1MemoryInfo()
+}
+
+func (d timedDevice) GetBBXTimeData_v1() (nvml.BBXTimeData_v1, nvml.Return) {
+	defer recordCall(d.calls, "GetBBXTimeData_v1", time.Now())
+	return d.Device.GetBBXTimeData_v1()
+}
+
+func (d timedDevice) GetBankRemapperStatus_v1() (nvml.EccBankRemapperStatus_v1, nvml.Return) {
+	defer recordCall(d.calls, "GetBankRemapperStatus_v1", time.Now())
+	return d.Device.GetBankRemapperStatus_v1()
+}
+
+func (d timedDevice) GetBoardId() (uint32, nvml.Return) {
+	defer recordCall(d.calls, "GetBoardId", time.Now())
+	return d.Device.GetBoardId()
+}
+
+func (d timedDevice) GetBoardPartNumber() (string, nvml.Return) {
+	defer recordCall(d.calls, "GetBoardPartNumber", time.Now())
+	return d.Device.GetBoardPartNumber()
+}
+
+func (d timedDevice) GetBrand() (nvml.BrandType, nvml.Return) {
+	defer recordCall(d.calls, "GetBrand", time.Now())
+	return d.Device.GetBrand()
+}
+
+func (d timedDevice) GetBridgeChipInfo() (nvml.BridgeChipHierarchy, nvml.Return) {
+	defer recordCall(d.calls, "GetBridgeChipInfo", time.Now())
+	return d.Device.GetBridgeChipInfo()
+}
+
+func (d timedDevice) GetBusType() (nvml.BusType, nvml.Return) {
+	defer recordCall(d.calls, "GetBusType", time.Now())
+	return d.Device.GetBusType()
+}
+
+func (d timedDevice) GetC2cModeInfoV() nvml.C2cModeInfoHandler {
+	defer recordCall(d.calls, "GetC2cModeInfoV", time.Now())
+	return d.Device.GetC2cModeInfoV()
+}
+
+func (d timedDevice) GetCapabilities() (nvml.DeviceCapabilities, nvml.Return) {
+	defer recordCall(d.calls, "GetCapabilities", time.Now())
+	return d.Device.GetCapabilities()
+}
+
+func (d timedDevice) GetClkMonStatus() (nvml.ClkMonStatus, nvml.Return) {
+	defer recordCall(d.calls, "GetClkMonStatus", time.Now())
+	return d.Device.GetClkMonStatus()
+}
+
+func (d timedDevice) GetClock(a0 nvml.ClockType, a1 nvml.ClockId) (uint32, nvml.Return) {
+	defer recordCall(d.calls, "GetClock", time.Now())
+	return d.Device.GetClock(a0, a1)
+}
+
+func (d timedDevice) GetClockInfo(a0 nvml.ClockType) (uint32, nvml.Return) {
+	defer recordCall(d.calls, "GetClockInfo", time.Now())
+	return d.Device.GetClockInfo(a0)
+}
+
+func (d timedDevice) GetClockOffsets() (nvml.ClockOffset, nvml.Return) {
+	defer recordCall(d.calls, "GetClockOffsets", time.Now())
+	return d.Device.GetClockOffsets()
+}
+
+func (d timedDevice) GetComputeInstanceId() (int, nvml.Return) {
+	defer recordCall(d.calls, "GetComputeInstanceId", time.Now())
+	return d.Device.GetComputeInstanceId()
+}
+
+func (d timedDevice) GetComputeMode() (nvml.ComputeMode, nvml.Return) {
+	defer recordCall(d.calls, "GetComputeMode", time.Now())
+	return d.Device.GetComputeMode()
+}
+
+func (d timedDevice) GetComputeRunningProcesses() ([]nvml.ProcessInfo, nvml.Return) {
+	defer recordCall(d.calls, "GetComputeRunningProcesses", time.Now())
+	return d.Device.GetComputeRunningProcesses()
+}
+
+func (d timedDevice) GetConfComputeGpuAttestationReport(a0 *nvml.ConfComputeGpuAttestationReport) nvml.Return {
+	defer recordCall(d.calls, "GetConfComputeGpuAttestationReport", time.Now())
+	return d.Device.GetConfComputeGpuAttestationReport(a0)
+}
+
+func (d timedDevice) GetConfComputeGpuCertificate() (nvml.ConfComputeGpuCertificate, nvml.Return) {
+	defer recordCall(d.calls, "GetConfComputeGpuCertificate", time.Now())
+	return d.Device.GetConfComputeGpuCertificate()
+}
+
+func (d timedDevice) GetConfComputeMemSizeInfo() (nvml.ConfComputeMemSizeInfo, nvml.Return) {
+	defer recordCall(d.calls, "GetConfComputeMemSizeInfo", time.Now())
+	return d.Device.GetConfComputeMemSizeInfo()
+}
+
+func (d timedDevice) GetConfComputeProtectedMemoryUsage() (nvml.Memory, nvml.Return) {
+	defer recordCall(d.calls, "GetConfComputeProtectedMemoryUsage", time.Now())
+	return d.Device.GetConfComputeProtectedMemoryUsage()
+}
+
+func (d timedDevice) GetCoolerInfo() (nvml.CoolerInfo, nvml.Return) {
+	defer recordCall(d.calls, "GetCoolerInfo", time.Now())
+	return d.Device.GetCoolerInfo()
+}
+
+func (d timedDevice) GetCpuAffinity(a0 int) ([]uint, nvml.Return) {
+	defer recordCall(d.calls, "GetCpuAffinity", time.Now())
+	return d.Device.GetCpuAffinity(a0)
+}
+
+func (d timedDevice) GetCpuAffinityWithinScope(a0 int, a1 nvml.AffinityScope) ([]uint, nvml.Return) {
+	defer recordCall(d.calls, "GetCpuAffinityWithinScope", time.Now())
+	return d.Device.GetCpuAffinityWithinScope(a0, a1)
+}
+
+func (d timedDevice) GetCreatableVgpus() ([]nvml.VgpuTypeId, nvml.Return) {
+	defer recordCall(d.calls, "GetCreatableVgpus", time.Now())
+	return d.Device.GetCreatableVgpus()
+}
+
+func (d timedDevice) GetCudaComputeCapability() (int, int, nvml.Return) {
+	defer recordCall(d.calls, "GetCudaComputeCapability", time.Now())
+	return d.Device.GetCudaComputeCapability()
+}
+
+func (d timedDevice) GetCurrPcieLinkGeneration() (int, nvml.Return) {
+	defer recordCall(d.calls, "GetCurrPcieLinkGeneration", time.Now())
+	return d.Device.GetCurrPcieLinkGeneration()
+}
+
+func (d timedDevice) GetCurrPcieLinkWidth() (int, nvml.Return) {
+	defer recordCall(d.calls, "GetCurrPcieLinkWidth", time.Now())
+	return d.Device.GetCurrPcieLinkWidth()
+}
+
+func (d timedDevice) GetCurrentClockFreqs() (nvml.DeviceCurrentClockFreqs, nvml.Return) {
+	defer recordCall(d.calls, "GetCurrentClockFreqs", time.Now())
+	return d.Device.GetCurrentClockFreqs()
+}
+
+func (d timedDevice) GetCurrentClocksEventReasons() (uint64, nvml.Return) {
+	defer recordCall(d.calls, "GetCurrentClocksEventReasons", time.Now())
+	return d.Device.GetCurrentClocksEventReasons()
+}
+
+func (d timedDevice) GetCurrentClocksThrottleReasons() (uint64, nvml.Return) {
+	defer recordCall(d.calls, "GetCurrentClocksThrottleReasons", time.Now())
+	return d.Device.GetCurrentClocksThrottleReasons()
+}
+
+func (d timedDevice) GetDecoderUtilization() (uint32, uint32, nvml.Return) {
+	defer recordCall(d.calls, "GetDecoderUtilization", time.Now())
+	return d.Device.GetDecoderUtilization()
+}
+
+func (d timedDevice) GetDefaultApplicationsClock(a0 nvml.ClockType) (uint32, nvml.Return) {
+	defer recordCall(d.calls, "GetDefaultApplicationsClock", time.Now())
+	return d.Device.GetDefaultApplicationsClock(a0)
+}
+
+func (d timedDevice) GetDefaultEccMode() (nvml.EnableState, nvml.Return) {
+	defer recordCall(d.calls, "GetDefaultEccMode", time.Now())
+	return d.Device.GetDefaultEccMode()
+}
+
+func (d timedDevice) GetDetailedEccErrors(a0 nvml.MemoryErrorType, a1 nvml.EccCounterType) (nvml.EccErrorCounts, nvml.Return) {
+	defer recordCall(d.calls, "GetDetailedEccErrors", time.Now())
+	return d.Device.GetDetailedEccErrors(a0, a1)
+}
+
+func (d timedDevice) GetDeviceHandleFromMigDeviceHandle() (nvml.Device, nvml.Return) {
+	start := time.Now()
+	r0, r1 := d.Device.GetDeviceHandleFromMigDeviceHandle()
+	recordCall(d.calls, "GetDeviceHandleFromMigDeviceHandle", start)
+	if r0 != nil {
+		r0 = timedDevice{Device: r0, calls: d.calls}
+	}
+	return r0, r1
+}
+
+func (d timedDevice) GetDisplayActive() (nvml.EnableState, nvml.Return) {
+	defer recordCall(d.calls, "GetDisplayActive", time.Now())
+	return d.Device.GetDisplayActive()
+}
+
+func (d timedDevice) GetDisplayMode() (nvml.EnableState, nvml.Return) {
+	defer recordCall(d.calls, "GetDisplayMode", time.Now())
+	return d.Device.GetDisplayMode()
+}
+
+func (d timedDevice) GetDramEncryptionMode() (nvml.DramEncryptionInfo, nvml.DramEncryptionInfo, nvml.Return) {
+	defer recordCall(d.calls, "GetDramEncryptionMode", time.Now())
+	return d.Device.GetDramEncryptionMode()
+}
+
+func (d timedDevice) GetDriverModel() (nvml.DriverModel, nvml.DriverModel, nvml.Return) {
+	defer recordCall(d.calls, "GetDriverModel", time.Now())
+	return d.Device.GetDriverModel()
+}
+
+func (d timedDevice) GetDriverModel_v2() (nvml.DriverModel, nvml.DriverModel, nvml.Return) {
+	defer recordCall(d.calls, "GetDriverModel_v2", time.Now())
+	return d.Device.GetDriverModel_v2()
+}
+
+func (d timedDevice) GetDynamicPstatesInfo() (nvml.GpuDynamicPstatesInfo, nvml.Return) {
+	defer recordCall(d.calls, "GetDynamicPstatesInfo", time.Now())
+	return d.Device.GetDynamicPstatesInfo()
+}
+
+func (d timedDevice) GetEccMode() (nvml.EnableState, nvml.EnableState, nvml.Return) {
+	defer recordCall(d.calls, "GetEccMode", time.Now())
+	return d.Device.GetEccMode()
+}
+
+func (d timedDevice) GetEncoderCapacity(a0 nvml.EncoderType) (int, nvml.Return) {
+	defer recordCall(d.calls, "GetEncoderCapacity", time.Now())
+	return d.Device.GetEncoderCapacity(a0)
+}
+
+func (d timedDevice) GetEncoderSessions() ([]nvml.EncoderSessionInfo, nvml.Return) {
+	defer recordCall(d.calls, "GetEncoderSessions", time.Now())
+	return d.Device.GetEncoderSessions()
+}
+
+func (d timedDevice) GetEncoderStats() (int, uint32, uint32, nvml.Return) {
+	defer recordCall(d.calls, "GetEncoderStats", time.Now())
+	return d.Device.GetEncoderStats()
+}
+
+func (d timedDevice) GetEncoderUtilization() (uint32, uint32, nvml.Return) {
+	defer recordCall(d.calls, "GetEncoderUtilization", time.Now())
+	return d.Device.GetEncoderUtilization()
+}
+
+func (d timedDevice) GetEnforcedPowerLimit() (uint32, nvml.Return) {
+	defer recordCall(d.calls, "GetEnforcedPowerLimit", time.Now())
+	return d.Device.GetEnforcedPowerLimit()
+}
+
+func (d timedDevice) GetFBCSessions() ([]nvml.FBCSessionInfo, nvml.Return) {
+	defer recordCall(d.calls, "GetFBCSessions", time.Now())
+	return d.Device.GetFBCSessions()
+}
+
+func (d timedDevice) GetFBCStats() (nvml.FBCStats, nvml.Return) {
+	defer recordCall(d.calls, "GetFBCStats", time.Now())
+	return d.Device.GetFBCStats()
+}
+
+func (d timedDevice) GetFanControlPolicy_v2(a0 int) (nvml.FanControlPolicy, nvml.Return) {
+	defer recordCall(d.calls, "GetFanControlPolicy_v2", time.Now())
+	return d.Device.GetFanControlPolicy_v2(a0)
+}
+
+func (d timedDevice) GetFanSpeed() (uint32, nvml.Return) {
+	defer recordCall(d.calls, "GetFanSpeed", time.Now())
+	return d.Device.GetFanSpeed()
+}
+
+func (d timedDevice) GetFanSpeedRPM() (nvml.FanSpeedInfo, nvml.Return) {
+	defer recordCall(d.calls, "GetFanSpeedRPM", time.Now())
+	return d.Device.GetFanSpeedRPM()
+}
+
+func (d timedDevice) GetFanSpeed_v2(a0 int) (uint32, nvml.Return) {
+	defer recordCall(d.calls, "GetFanSpeed_v2", time.Now())
+	return d.Device.GetFanSpeed_v2(a0)
+}
+
+func (d timedDevice) GetFieldValues(a0 []nvml.FieldValue) nvml.Return {
+	defer recordCall(d.calls, "GetFieldValues", time.Now())
+	return d.Device.GetFieldValues(a0)
+}
+
+func (d timedDevice) GetGpcClkMinMaxVfOffset() (int, int, nvml.Return) {
+	defer recordCall(d.calls, "GetGpcClkMinMaxVfOffset", time.Now())
+	return d.Device.GetGpcClkMinMaxVfOffset()
+}
+
+func (d timedDevice) GetGpcClkVfOffset() (int, nvml.Return) {
+	defer recordCall(d.calls, "GetGpcClkVfOffset", time.Now())
+	return d.Device.GetGpcClkVfOffset()
+}
+
+func (d timedDevice) GetGpuFabricInfo() (nvml.GpuFabricInfo, nvml.Return) {
+	defer recordCall(d.calls, "GetGpuFabricInfo", time.Now())
+	return d.Device.GetGpuFabricInfo()
+}
+
+func (d timedDevice) GetGpuFabricInfoV() nvml.GpuFabricInfoHandler {
+	defer recordCall(d.calls, "GetGpuFabricInfoV", time.Now())
+	return d.Device.GetGpuFabricInfoV()
+}
+
+func (d timedDevice) GetGpuFabricInfo_v4() (nvml.GpuFabricInfo_v4, nvml.Return) {
+	defer recordCall(d.calls, "GetGpuFabricInfo_v4", time.Now())
+	return d.Device.GetGpuFabricInfo_v4()
+}
+
+func (d timedDevice) GetGpuInstanceById(a0 int) (nvml.GpuInstance, nvml.Return) {
+	defer recordCall(d.calls, "GetGpuInstanceById", time.Now())
+	return d.Device.GetGpuInstanceById(a0)
+}
+
+func (d timedDevice) GetGpuInstanceId() (int, nvml.Return) {
+	defer recordCall(d.calls, "GetGpuInstanceId", time.Now())
+	return d.Device.GetGpuInstanceId()
+}
+
+func (d timedDevice) GetGpuInstancePossiblePlacements(a0 *nvml.GpuInstanceProfileInfo) ([]nvml.GpuInstancePlacement, nvml.Return) {
+	defer recordCall(d.calls, "GetGpuInstancePossiblePlacements", time.Now())
+	return d.Device.GetGpuInstancePossiblePlacements(a0)
+}
+
+func (d timedDevice) GetGpuInstanceProfileInfo(a0 int) (nvml.GpuInstanceProfileInfo, nvml.Return) {
+	defer recordCall(d.calls, "GetGpuInstanceProfileInfo", time.Now())
+	return d.Device.GetGpuInstanceProfileInfo(a0)
+}
+
+func (d timedDevice) GetGpuInstanceProfileInfoByIdV(a0 int) nvml.GpuInstanceProfileInfoByIdHandler {
+	defer recordCall(d.calls, "GetGpuInstanceProfileInfoByIdV", time.Now())
+	return d.Device.GetGpuInstanceProfileInfoByIdV(a0)
+}
+
+func (d timedDevice) GetGpuInstanceProfileInfoV(a0 int) nvml.GpuInstanceProfileInfoHandler {
+	defer recordCall(d.calls, "GetGpuInstanceProfileInfoV", time.Now())
+	return d.Device.GetGpuInstanceProfileInfoV(a0)
+}
+
+func (d timedDevice) GetGpuInstanceRemainingCapacity(a0 *nvml.GpuInstanceProfileInfo) (int, nvml.Return) {
+	defer recordCall(d.calls, "GetGpuInstanceRemainingCapacity", time.Now())
+	return d.Device.GetGpuInstanceRemainingCapacity(a0)
+}
+
+func (d timedDevice) GetGpuInstances(a0 *nvml.GpuInstanceProfileInfo) ([]nvml.GpuInstance, nvml.Return) {
+	defer recordCall(d.calls, "GetGpuInstances", time.Now())
+	return d.Device.GetGpuInstances(a0)
+}
+
+func (d timedDevice) GetGpuMaxPcieLinkGeneration() (int, nvml.Return) {
+	defer recordCall(d.calls, "GetGpuMaxPcieLinkGeneration", time.Now())
+	return d.Device.GetGpuMaxPcieLinkGeneration()
+}
+
+func (d timedDevice) GetGpuOperationMode() (nvml.GpuOperationMode, nvml.GpuOperationMode, nvml.Return) {
+	defer recordCall(d.calls, "GetGpuOperationMode", time.Now())
+	return d.Device.GetGpuOperationMode()
+}
+
+func (d timedDevice) GetGraphicsRunningProcesses() ([]nvml.ProcessInfo, nvml.Return) {
+	defer recordCall(d.calls, "GetGraphicsRunningProcesses", time.Now())
+	return d.Device.GetGraphicsRunningProcesses()
+}
+
+func (d timedDevice) GetGridLicensableFeatures() (nvml.GridLicensableFeatures, nvml.Return) {
+	defer recordCall(d.calls, "GetGridLicensableFeatures", time.Now())
+	return d.Device.GetGridLicensableFeatures()
+}
+
+func (d timedDevice) GetGspFirmwareMode() (bool, bool, nvml.Return) {
+	defer recordCall(d.calls, "GetGspFirmwareMode", time.Now())
+	return d.Device.GetGspFirmwareMode()
+}
+
+func (d timedDevice) GetGspFirmwareVersion() (string, nvml.Return) {
+	defer recordCall(d.calls, "GetGspFirmwareVersion", time.Now())
+	return d.Device.GetGspFirmwareVersion()
+}
+
+func (d timedDevice) GetHostVgpuMode() (nvml.HostVgpuMode, nvml.Return) {
+	defer recordCall(d.calls, "GetHostVgpuMode", time.Now())
+	return d.Device.GetHostVgpuMode()
+}
+
+func (d timedDevice) GetHostname_v1() (string, nvml.Return) {
+	defer recordCall(d.calls, "GetHostname_v1", time.Now())
+	return d.Device.GetHostname_v1()
+}
+
+func (d timedDevice) GetIndex() (int, nvml.Return) {
+	defer recordCall(d.calls, "GetIndex", time.Now())
+	return d.Device.GetIndex()
+}
+
+func (d timedDevice) GetInforomConfigurationChecksum() (uint32, nvml.Return) {
+	defer recordCall(d.calls, "GetInforomConfigurationChecksum", time.Now())
+	return d.Device.GetInforomConfigurationChecksum()
+}
+
+func (d timedDevice) GetInforomImageVersion() (string, nvml.Return) {
+	defer recordCall(d.calls, "GetInforomImageVersion", time.Now())
+	return d.Device.GetInforomImageVersion()
+}
+
+func (d timedDevice) GetInforomVersion(a0 nvml.InforomObject) (string, nvml.Return) {
+	defer recordCall(d.calls, "GetInforomVersion", time.Now())
+	return d.Device.GetInforomVersion(a0)
+}
+
+func (d timedDevice) GetIrqNum() (int, nvml.Return) {
+	defer recordCall(d.calls, "GetIrqNum", time.Now())
+	return d.Device.GetIrqNum()
+}
+
+func (d timedDevice) GetJpgUtilization() (uint32, uint32, nvml.Return) {
+	defer recordCall(d.calls, "GetJpgUtilization", time.Now())
+	return d.Device.GetJpgUtilization()
+}
+
+func (d timedDevice) GetLastBBXFlushTime() (uint64, uint, nvml.Return) {
+	defer recordCall(d.calls, "GetLastBBXFlushTime", time.Now())
+	return d.Device.GetLastBBXFlushTime()
+}
+
+func (d timedDevice) GetMPSComputeRunningProcesses() ([]nvml.ProcessInfo, nvml.Return) {
+	defer recordCall(d.calls, "GetMPSComputeRunningProcesses", time.Now())
+	return d.Device.GetMPSComputeRunningProcesses()
+}
+
+func (d timedDevice) GetMarginTemperature() (nvml.MarginTemperature, nvml.Return) {
+	defer recordCall(d.calls, "GetMarginTemperature", time.Now())
+	return d.Device.GetMarginTemperature()
+}
+
+func (d timedDevice) GetMaxClockInfo(a0 nvml.ClockType) (uint32, nvml.Return) {
+	defer recordCall(d.calls, "GetMaxClockInfo", time.Now())
+	return d.Device.GetMaxClockInfo(a0)
+}
+
+func (d timedDevice) GetMaxCustomerBoostClock(a0 nvml.ClockType) (uint32, nvml.Return) {
+	defer recordCall(d.calls, "GetMaxCustomerBoostClock", time.Now())
+	return d.Device.GetMaxCustomerBoostClock(a0)
+}
+
+func (d timedDevice) GetMaxMigDeviceCount() (int, nvml.Return) {
+	defer recordCall(d.calls, "GetMaxMigDeviceCount", time.Now())
+	return d.Device.GetMaxMigDeviceCount()
+}
+
+func (d timedDevice) GetMaxPcieLinkGeneration() (int, nvml.Return) {
+	defer recordCall(d.calls, "GetMaxPcieLinkGeneration", time.Now())
+	return d.Device.GetMaxPcieLinkGeneration()
+}
+
+func (d timedDevice) GetMaxPcieLinkWidth() (int, nvml.Return) {
+	defer recordCall(d.calls, "GetMaxPcieLinkWidth", time.Now())
+	return d.Device.GetMaxPcieLinkWidth()
+}
+
+func (d timedDevice) GetMemClkMinMaxVfOffset() (int, int, nvml.Return) {
+	defer recordCall(d.calls, "GetMemClkMinMaxVfOffset", time.Now())
+	return d.Device.GetMemClkMinMaxVfOffset()
+}
+
+func (d timedDevice) GetMemClkVfOffset() (int, nvml.Return) {
+	defer recordCall(d.calls, "GetMemClkVfOffset", time.Now())
+	return d.Device.GetMemClkVfOffset()
+}
+
+func (d timedDevice) GetMemoryAffinity(a0 int, a1 nvml.AffinityScope) ([]uint, nvml.Return) {
+	defer recordCall(d.calls, "GetMemoryAffinity", time.Now())
+	return d.Device.GetMemoryAffinity(a0, a1)
+}
+
+func (d timedDevice) GetMemoryBusWidth() (uint32, nvml.Return) {
+	defer recordCall(d.calls, "GetMemoryBusWidth", time.Now())
+	return d.Device.GetMemoryBusWidth()
+}
+
+func (d timedDevice) GetMemoryErrorCounter(a0 nvml.MemoryErrorType, a1 nvml.EccCounterType, a2 nvml.MemoryLocation) (uint64, nvml.Return) {
+	defer recordCall(d.calls, "GetMemoryErrorCounter", time.Now())
+	return d.Device.GetMemoryErrorCounter(a0, a1, a2)
+}
+
+func (d timedDevice) GetMemoryInfo() (nvml.Memory, nvml.Return) {
+	defer recordCall(d.calls, "GetMemoryInfo", time.Now())
+	return d.Device.GetMemoryInfo()
+}
+
+func (d timedDevice) GetMemoryInfo_v2() (nvml.Memory_v2, nvml.Return) {
+	defer recordCall(d.calls, "GetMemoryInfo_v2", time.Now())
+	return d.Device.GetMemoryInfo_v2()
+}
+
+func (d timedDevice) GetMemoryLimits_v1(a0 string) (nvml.MemoryLimits_v1, nvml.Return) {
+	defer recordCall(d.calls, "GetMemoryLimits_v1", time.Now())
+	return d.Device.GetMemoryLimits_v1(a0)
+}
+
+func (d timedDevice) GetMigDeviceHandleByIndex(a0 int) (nvml.Device, nvml.Return) {
+	start := time.Now()
+	r0, r1 := d.Device.GetMigDeviceHandleByIndex(a0)
+	recordCall(d.calls, "GetMigDeviceHandleByIndex", start)
+	if r0 != nil {
+		r0 = timedDevice{Device: r0, calls: d.calls}
+	}
+	return r0, r1
+}
+
+func (d timedDevice) GetMigMode() (int, int, nvml.Return) {
+	defer recordCall(d.calls, "GetMigMode", time.Now())
+	return d.Device.GetMigMode()
+}
+
+func (d timedDevice) GetMinMaxClockOfPState(a0 nvml.ClockType, a1 nvml.Pstates) (uint32, uint32, nvml.Return) {
+	defer recordCall(d.calls, "GetMinMaxClockOfPState", time.Now())
+	return d.Device.GetMinMaxClockOfPState(a0, a1)
+}
+
+func (d timedDevice) GetMinMaxFanSpeed() (int, int, nvml.Return) {
+	defer recordCall(d.calls, "GetMinMaxFanSpeed", time.Now())
+	return d.Device.GetMinMaxFanSpeed()
+}
+
+func (d timedDevice) GetMinorNumber() (int, nvml.Return) {
+	defer recordCall(d.calls, "GetMinorNumber", time.Now())
+	return d.Device.GetMinorNumber()
+}
+
+func (d timedDevice) GetModuleId() (int, nvml.Return) {
+	defer recordCall(d.calls, "GetModuleId", time.Now())
+	return d.Device.GetModuleId()
+}
+
+func (d timedDevice) GetMultiGpuBoard() (int, nvml.Return) {
+	defer recordCall(d.calls, "GetMultiGpuBoard", time.Now())
+	return d.Device.GetMultiGpuBoard()
+}
+
+func (d timedDevice) GetName() (string, nvml.Return) {
+	defer recordCall(d.calls, "GetName", time.Now())
+	return d.Device.GetName()
+}
+
+func (d timedDevice) GetNumFans() (int, nvml.Return) {
+	defer recordCall(d.calls, "GetNumFans", time.Now())
+	return d.Device.GetNumFans()
+}
+
+func (d timedDevice) GetNumGpuCores() (int, nvml.Return) {
+	defer recordCall(d.calls, "GetNumGpuCores", time.Now())
+	return d.Device.GetNumGpuCores()
+}
+
+func (d timedDevice) GetNumaNodeId() (int, nvml.Return) {
+	defer recordCall(d.calls, "GetNumaNodeId", time.Now())
+	return d.Device.GetNumaNodeId()
+}
+
+func (d timedDevice) GetNvLinkCapability(a0 int, a1 nvml.NvLinkCapability) (uint32, nvml.Return) {
+	defer recordCall(d.calls, "GetNvLinkCapability", time.Now())
+	return d.Device.GetNvLinkCapability(a0, a1)
+}
+
+func (d timedDevice) GetNvLinkErrorCounter(a0 int, a1 nvml.NvLinkErrorCounter) (uint64, nvml.Return) {
+	defer recordCall(d.calls, "GetNvLinkErrorCounter", time.Now())
+	return d.Device.GetNvLinkErrorCounter(a0, a1)
+}
+
+func (d timedDevice) GetNvLinkInfo() nvml.NvLinkInfoHandler {
+	defer recordCall(d.calls, "GetNvLinkInfo", time.Now())
+	return d.Device.GetNvLinkInfo()
+}
+
+func (d timedDevice) GetNvLinkRemoteDeviceType(a0 int) (nvml.IntNvLinkDeviceType, nvml.Return) {
+	defer recordCall(d.calls, "GetNvLinkRemoteDeviceType", time.Now())
+	return d.Device.GetNvLinkRemoteDeviceType(a0)
+}
+
+func (d timedDevice) GetNvLinkRemotePciInfo(a0 int) (nvml.PciInfo, nvml.Return) {
+	defer recordCall(d.calls, "GetNvLinkRemotePciInfo", time.Now())
+	return d.Device.GetNvLinkRemotePciInfo(a0)
+}
+
+func (d timedDevice) GetNvLinkState(a0 int) (nvml.EnableState, nvml.Return) {
+	defer recordCall(d.calls, "GetNvLinkState", time.Now())
+	return d.Device.GetNvLinkState(a0)
+}
+
+func (d timedDevice) GetNvLinkTelemetrySamples_v1(a0 nvml.NvlinkTelemetrySamples_v1) (nvml.NvlinkTelemetrySamples_v1, nvml.Return) {
+	defer recordCall(d.calls, "GetNvLinkTelemetrySamples_v1", time.Now())
+	return d.Device.GetNvLinkTelemetrySamples_v1(a0)
+}
+
+func (d timedDevice) GetNvLinkUtilizationControl(a0 int, a1 int) (nvml.NvLinkUtilizationControl, nvml.Return) {
+	defer recordCall(d.calls, "GetNvLinkUtilizationControl", time.Now())
+	return d.Device.GetNvLinkUtilizationControl(a0, a1)
+}
+
+func (d timedDevice) GetNvLinkUtilizationCounter(a0 int, a1 int) (uint64, uint64, nvml.Return) {
+	defer recordCall(d.calls, "GetNvLinkUtilizationCounter", time.Now())
+	return d.Device.GetNvLinkUtilizationCounter(a0, a1)
+}
+
+func (d timedDevice) GetNvLinkVersion(a0 int) (uint32, nvml.Return) {
+	defer recordCall(d.calls, "GetNvLinkVersion", time.Now())
+	return d.Device.GetNvLinkVersion(a0)
+}
+
+func (d timedDevice) GetNvlinkBwMode() (nvml.NvlinkGetBwMode, nvml.Return) {
+	defer recordCall(d.calls, "GetNvlinkBwMode", time.Now())
+	return d.Device.GetNvlinkBwMode()
+}
+
+func (d timedDevice) GetNvlinkSupportedBwModes() (nvml.NvlinkSupportedBwModes, nvml.Return) {
+	defer recordCall(d.calls, "GetNvlinkSupportedBwModes", time.Now())
+	return d.Device.GetNvlinkSupportedBwModes()
+}
+
+func (d timedDevice) GetOfaUtilization() (uint32, uint32, nvml.Return) {
+	defer recordCall(d.calls, "GetOfaUtilization", time.Now())
+	return d.Device.GetOfaUtilization()
+}
+
+func (d timedDevice) GetP2PStatus(a0 nvml.Device, a1 nvml.GpuP2PCapsIndex) (nvml.GpuP2PStatus, nvml.Return) {
+	defer recordCall(d.calls, "GetP2PStatus", time.Now())
+	return d.Device.GetP2PStatus(a0, a1)
+}
+
+func (d timedDevice) GetPciInfo() (nvml.PciInfo, nvml.Return) {
+	defer recordCall(d.calls, "GetPciInfo", time.Now())
+	return d.Device.GetPciInfo()
+}
+
+func (d timedDevice) GetPciInfoExt() (nvml.PciInfoExt, nvml.Return) {
+	defer recordCall(d.calls, "GetPciInfoExt", time.Now())
+	return d.Device.GetPciInfoExt()
+}
+
+func (d timedDevice) GetPcieLinkMaxSpeed() (uint32, nvml.Return) {
+	defer recordCall(d.calls, "GetPcieLinkMaxSpeed", time.Now())
+	return d.Device.GetPcieLinkMaxSpeed()
+}
+
+func (d timedDevice) GetPcieReplayCounter() (int, nvml.Return) {
+	defer recordCall(d.calls, "GetPcieReplayCounter", time.Now())
+	return d.Device.GetPcieReplayCounter()
+}
+
+func (d timedDevice) GetPcieSpeed() (int, nvml.Return) {
+	defer recordCall(d.calls, "GetPcieSpeed", time.Now())
+	return d.Device.GetPcieSpeed()
+}
+
+func (d timedDevice) GetPcieThroughput(a0 nvml.PcieUtilCounter) (uint32, nvml.Return) {
+	defer recordCall(d.calls, "GetPcieThroughput", time.Now())
+	return d.Device.GetPcieThroughput(a0)
+}
+
+func (d timedDevice) GetPdi() (nvml.Pdi, nvml.Return) {
+	defer recordCall(d.calls, "GetPdi", time.Now())
+	return d.Device.GetPdi()
+}
+
+func (d timedDevice) GetPerformanceModes() (nvml.DevicePerfModes, nvml.Return) {
+	defer recordCall(d.calls, "GetPerformanceModes", time.Now())
+	return d.Device.GetPerformanceModes()
+}
+
+func (d timedDevice) GetPerformanceState() (nvml.Pstates, nvml.Return) {
+	defer recordCall(d.calls, "GetPerformanceState", time.Now())
+	return d.Device.GetPerformanceState()
+}
+
+func (d timedDevice) GetPersistenceMode() (nvml.EnableState, nvml.Return) {
+	defer recordCall(d.calls, "GetPersistenceMode", time.Now())
+	return d.Device.GetPersistenceMode()
+}
+
+func (d timedDevice) GetPgpuMetadataString() (string, nvml.Return) {
+	defer recordCall(d.calls, "GetPgpuMetadataString", time.Now())
+	return d.Device.GetPgpuMetadataString()
+}
+
+func (d timedDevice) GetPlatformInfo() (nvml.PlatformInfo, nvml.Return) {
+	defer recordCall(d.calls, "GetPlatformInfo", time.Now())
+	return d.Device.GetPlatformInfo()
+}
+
+func (d timedDevice) GetPowerManagementDefaultLimit() (uint32, nvml.Return) {
+	defer recordCall(d.calls, "GetPowerManagementDefaultLimit", time.Now())
+	return d.Device.GetPowerManagementDefaultLimit()
+}
+
+func (d timedDevice) GetPowerManagementLimit() (uint32, nvml.Return) {
+	defer recordCall(d.calls, "GetPowerManagementLimit", time.Now())
+	return d.Device.GetPowerManagementLimit()
+}
+
+func (d timedDevice) GetPowerManagementLimitConstraints() (uint32, uint32, nvml.Return) {
+	defer recordCall(d.calls, "GetPowerManagementLimitConstraints", time.Now())
+	return d.Device.GetPowerManagementLimitConstraints()
+}
+
+func (d timedDevice) GetPowerManagementMode() (nvml.EnableState, nvml.Return) {
+	defer recordCall(d.calls, "GetPowerManagementMode", time.Now())
+	return d.Device.GetPowerManagementMode()
+}
+
+func (d timedDevice) GetPowerMizerMode_v1() (nvml.DevicePowerMizerModes_v1, nvml.Return) {
+	defer recordCall(d.calls, "GetPowerMizerMode_v1", time.Now())
+	return d.Device.GetPowerMizerMode_v1()
+}
+
+func (d timedDevice) GetPowerSource() (nvml.PowerSource, nvml.Return) {
+	defer recordCall(d.calls, "GetPowerSource", time.Now())
+	return d.Device.GetPowerSource()
+}
+
+func (d timedDevice) GetPowerState() (nvml.Pstates, nvml.Return) {
+	defer recordCall(d.calls, "GetPowerState", time.Now())
+	return d.Device.GetPowerState()
+}
+
+func (d timedDevice) GetPowerUsage() (uint32, nvml.Return) {
+	defer recordCall(d.calls, "GetPowerUsage", time.Now())
+	return d.Device.GetPowerUsage()
+}
+
+func (d timedDevice) GetProcessUtilization(a0 uint64) ([]nvml.ProcessUtilizationSample, nvml.Return) {
+	defer recordCall(d.calls, "GetProcessUtilization", time.Now())
+	return d.Device.GetProcessUtilization(a0)
+}
+
+func (d timedDevice) GetProcessesUtilizationInfo() (nvml.ProcessesUtilizationInfo, nvml.Return) {
+	defer recordCall(d.calls, "GetProcessesUtilizationInfo", time.Now())
+	return d.Device.GetProcessesUtilizationInfo()
+}
+
+func (d timedDevice) GetRemappedRows() (int, int, bool, bool, nvml.Return) {
+	defer recordCall(d.calls, "GetRemappedRows", time.Now())
+	return d.Device.GetRemappedRows()
+}
+
+func (d timedDevice) GetRemappedRows_v2() (nvml.RemappedRowsInfo_v2, nvml.Return) {
+	defer recordCall(d.calls, "GetRemappedRows_v2", time.Now())
+	return d.Device.GetRemappedRows_v2()
+}
+
+func (d timedDevice) GetRepairStatus() (nvml.RepairStatus, nvml.Return) {
+	defer recordCall(d.calls, "GetRepairStatus", time.Now())
+	return d.Device.GetRepairStatus()
+}
+
+func (d timedDevice) GetRetiredPages(a0 nvml.PageRetirementCause) ([]uint64, nvml.Return) {
+	defer recordCall(d.calls, "GetRetiredPages", time.Now())
+	return d.Device.GetRetiredPages(a0)
+}
+
+func (d timedDevice) GetRetiredPagesPendingStatus() (nvml.EnableState, nvml.Return) {
+	defer recordCall(d.calls, "GetRetiredPagesPendingStatus", time.Now())
+	return d.Device.GetRetiredPagesPendingStatus()
+}
+
+func (d timedDevice) GetRetiredPages_v2(a0 nvml.PageRetirementCause) ([]uint64, []uint64, nvml.Return) {
+	defer recordCall(d.calls, "GetRetiredPages_v2", time.Now())
+	return d.Device.GetRetiredPages_v2(a0)
+}
+
+func (d timedDevice) GetRowRemapperHistogram() (nvml.RowRemapperHistogramValues, nvml.Return) {
+	defer recordCall(d.calls, "GetRowRemapperHistogram", time.Now())
+	return d.Device.GetRowRemapperHistogram()
+}
+
+func (d timedDevice) GetRunningProcessDetailList() (nvml.ProcessDetailList, nvml.Return) {
+	defer recordCall(d.calls, "GetRunningProcessDetailList", time.Now())
+	return d.Device.GetRunningProcessDetailList()
+}
+
+func (d timedDevice) GetSamples(a0 nvml.SamplingType, a1 uint64) (nvml.ValueType, []nvml.Sample, nvml.Return) {
+	defer recordCall(d.calls, "GetSamples", time.Now())
+	return d.Device.GetSamples(a0, a1)
+}
+
+func (d timedDevice) GetSerial() (string, nvml.Return) {
+	defer recordCall(d.calls, "GetSerial", time.Now())
+	return d.Device.GetSerial()
+}
+
+func (d timedDevice) GetSramEccErrorStatus() (nvml.EccSramErrorStatus, nvml.Return) {
+	defer recordCall(d.calls, "GetSramEccErrorStatus", time.Now())
+	return d.Device.GetSramEccErrorStatus()
+}
+
+func (d timedDevice) GetSramUniqueUncorrectedEccErrorCounts(a0 *nvml.EccSramUniqueUncorrectedErrorCounts) nvml.Return {
+	defer recordCall(d.calls, "GetSramUniqueUncorrectedEccErrorCounts", time.Now())
+	return d.Device.GetSramUniqueUncorrectedEccErrorCounts(a0)
+}
+
+func (d timedDevice) GetSupportedClocksEventReasons() (uint64, nvml.Return) {
+	defer recordCall(d.calls, "GetSupportedClocksEventReasons", time.Now())
+	return d.Device.GetSupportedClocksEventReasons()
+}
+
+func (d timedDevice) GetSupportedClocksThrottleReasons() (uint64, nvml.Return) {
+	defer recordCall(d.calls, "GetSupportedClocksThrottleReasons", time.Now())
+	return d.Device.GetSupportedClocksThrottleReasons()
+}
+
+func (d timedDevice) GetSupportedEventTypes() (uint64, nvml.Return) {
+	defer recordCall(d.calls, "GetSupportedEventTypes", time.Now())
+	return d.Device.GetSupportedEventTypes()
+}
+
+func (d timedDevice) GetSupportedGraphicsClocks(a0 int) (int, uint32, nvml.Return) {
+	defer recordCall(d.calls, "GetSupportedGraphicsClocks", time.Now())
+	return d.Device.GetSupportedGraphicsClocks(a0)
+}
+
+func (d timedDevice) GetSupportedMemoryClocks() (int, uint32, nvml.Return) {
+	defer recordCall(d.calls, "GetSupportedMemoryClocks", time.Now())
+	return d.Device.GetSupportedMemoryClocks()
+}
+
+func (d timedDevice) GetSupportedPerformanceStates() ([]nvml.Pstates, nvml.Return) {
+	defer recordCall(d.calls, "GetSupportedPerformanceStates", time.Now())
+	return d.Device.GetSupportedPerformanceStates()
+}
+
+func (d timedDevice) GetSupportedVgpus() ([]nvml.VgpuTypeId, nvml.Return) {
+	defer recordCall(d.calls, "GetSupportedVgpus", time.Now())
+	return d.Device.GetSupportedVgpus()
+}
+
+func (d timedDevice) GetTargetFanSpeed(a0 int) (int, nvml.Return) {
+	defer recordCall(d.calls, "GetTargetFanSpeed", time.Now())
+	return d.Device.GetTargetFanSpeed(a0)
+}
+
+func (d timedDevice) GetTemperature(a0 nvml.TemperatureSensors) (uint32, nvml.Return) {
+	defer recordCall(d.calls, "GetTemperature", time.Now())
+	return d.Device.GetTemperature(a0)
+}
+
+func (d timedDevice) GetTemperatureThreshold(a0 nvml.TemperatureThresholds) (uint32, nvml.Return) {
+	defer recordCall(d.calls, "GetTemperatureThreshold", time.Now())
+	return d.Device.GetTemperatureThreshold(a0)
+}
+
+func (d timedDevice) GetTemperatureV() nvml.TemperatureHandler {
+	defer recordCall(d.calls, "GetTemperatureV", time.Now())
+	return d.Device.GetTemperatureV()
+}
+
+func (d timedDevice) GetThermalSettings(a0 uint32) (nvml.GpuThermalSettings, nvml.Return) {
+	defer recordCall(d.calls, "GetThermalSettings", time.Now())
+	return d.Device.GetThermalSettings(a0)
+}
+
+func (d timedDevice) GetTopologyCommonAncestor(a0 nvml.Device) (nvml.GpuTopologyLevel, nvml.Return) {
+	defer recordCall(d.calls, "GetTopologyCommonAncestor", time.Now())
+	return d.Device.GetTopologyCommonAncestor(a0)
+}
+
+func (d timedDevice) GetTopologyNearestGpus(a0 nvml.GpuTopologyLevel) ([]nvml.Device, nvml.Return) {
+	defer recordCall(d.calls, "GetTopologyNearestGpus", time.Now())
+	return d.Device.GetTopologyNearestGpus(a0)
+}
+
+func (d timedDevice) GetTotalEccErrors(a0 nvml.MemoryErrorType, a1 nvml.EccCounterType) (uint64, nvml.Return) {
+	defer recordCall(d.calls, "GetTotalEccErrors", time.Now())
+	return d.Device.GetTotalEccErrors(a0, a1)
+}
+
+func (d timedDevice) GetTotalEnergyConsumption() (uint64, nvml.Return) {
+	defer recordCall(d.calls, "GetTotalEnergyConsumption", time.Now())
+	return d.Device.GetTotalEnergyConsumption()
+}
+
+func (d timedDevice) GetUUID() (string, nvml.Return) {
+	defer recordCall(d.calls, "GetUUID", time.Now())
+	return d.Device.GetUUID()
+}
+
+func (d timedDevice) GetUnrepairableMemoryFlag_v1() (nvml.UnrepairableMemoryStatus_v1, nvml.Return) {
+	defer recordCall(d.calls, "GetUnrepairableMemoryFlag_v1", time.Now())
+	return d.Device.GetUnrepairableMemoryFlag_v1()
+}
+
+func (d timedDevice) GetUtilizationRates() (nvml.Utilization, nvml.Return) {
+	defer recordCall(d.calls, "GetUtilizationRates", time.Now())
+	return d.Device.GetUtilizationRates()
+}
+
+func (d timedDevice) GetVbiosVersion() (string, nvml.Return) {
+	defer recordCall(d.calls, "GetVbiosVersion", time.Now())
+	return d.Device.GetVbiosVersion()
+}
+
+func (d timedDevice) GetVgpuCapabilities(a0 nvml.DeviceVgpuCapability) (bool, nvml.Return) {
+	defer recordCall(d.calls, "GetVgpuCapabilities", time.Now())
+	return d.Device.GetVgpuCapabilities(a0)
+}
+
+func (d timedDevice) GetVgpuHeterogeneousMode() (nvml.VgpuHeterogeneousMode, nvml.Return) {
+	defer recordCall(d.calls, "GetVgpuHeterogeneousMode", time.Now())
+	return d.Device.GetVgpuHeterogeneousMode()
+}
+
+func (d timedDevice) GetVgpuInstancesUtilizationInfo() (nvml.VgpuInstancesUtilizationInfo, nvml.Return) {
+	defer recordCall(d.calls, "GetVgpuInstancesUtilizationInfo", time.Now())
+	return d.Device.GetVgpuInstancesUtilizationInfo()
+}
+
+func (d timedDevice) GetVgpuMetadata() (nvml.VgpuPgpuMetadata, nvml.Return) {
+	defer recordCall(d.calls, "GetVgpuMetadata", time.Now())
+	return d.Device.GetVgpuMetadata()
+}
+
+func (d timedDevice) GetVgpuProcessUtilization(a0 uint64) ([]nvml.VgpuProcessUtilizationSample, nvml.Return) {
+	defer recordCall(d.calls, "GetVgpuProcessUtilization", time.Now())
+	return d.Device.GetVgpuProcessUtilization(a0)
+}
+
+func (d timedDevice) GetVgpuProcessesUtilizationInfo() (nvml.VgpuProcessesUtilizationInfo, nvml.Return) {
+	defer recordCall(d.calls, "GetVgpuProcessesUtilizationInfo", time.Now())
+	return d.Device.GetVgpuProcessesUtilizationInfo()
+}
+
+func (d timedDevice) GetVgpuSchedulerCapabilities() (nvml.VgpuSchedulerCapabilities, nvml.Return) {
+	defer recordCall(d.calls, "GetVgpuSchedulerCapabilities", time.Now())
+	return d.Device.GetVgpuSchedulerCapabilities()
+}
+
+func (d timedDevice) GetVgpuSchedulerLog() (nvml.VgpuSchedulerLog, nvml.Return) {
+	defer recordCall(d.calls, "GetVgpuSchedulerLog", time.Now())
+	return d.Device.GetVgpuSchedulerLog()
+}
+
+func (d timedDevice) GetVgpuSchedulerLog_v2(a0 nvml.VgpuSchedulerLogInfo_v2) (nvml.VgpuSchedulerLogInfo_v2, nvml.Return) {
+	defer recordCall(d.calls, "GetVgpuSchedulerLog_v2", time.Now())
+	return d.Device.GetVgpuSchedulerLog_v2(a0)
+}
+
+func (d timedDevice) GetVgpuSchedulerState() (nvml.VgpuSchedulerGetState, nvml.Return) {
+	defer recordCall(d.calls, "GetVgpuSchedulerState", time.Now())
+	return d.Device.GetVgpuSchedulerState()
+}
+
+func (d timedDevice) GetVgpuSchedulerState_v2(a0 nvml.VgpuSchedulerStateInfo_v2) (nvml.VgpuSchedulerStateInfo_v2, nvml.Return) {
+	defer recordCall(d.calls, "GetVgpuSchedulerState_v2", time.Now())
+	return d.Device.GetVgpuSchedulerState_v2(a0)
+}
+
+func (d timedDevice) GetVgpuTypeCreatablePlacements(a0 nvml.VgpuTypeId) (nvml.VgpuPlacementList, nvml.Return) {
+	defer recordCall(d.calls, "GetVgpuTypeCreatablePlacements", time.Now())
+	return d.Device.GetVgpuTypeCreatablePlacements(a0)
+}
+
+func (d timedDevice) GetVgpuTypeSupportedPlacements(a0 nvml.VgpuTypeId) (nvml.VgpuPlacementList, nvml.Return) {
+	defer recordCall(d.calls, "GetVgpuTypeSupportedPlacements", time.Now())
+	return d.Device.GetVgpuTypeSupportedPlacements(a0)
+}
+
+func (d timedDevice) GetVgpuUtilization(a0 uint64) (nvml.ValueType, []nvml.VgpuInstanceUtilizationSample, nvml.Return) {
+	defer recordCall(d.calls, "GetVgpuUtilization", time.Now())
+	return d.Device.GetVgpuUtilization(a0)
+}
+
+func (d timedDevice) GetViolationStatus(a0 nvml.PerfPolicyType) (nvml.ViolationTime, nvml.Return) {
+	defer recordCall(d.calls, "GetViolationStatus", time.Now())
+	return d.Device.GetViolationStatus(a0)
+}
+
+func (d timedDevice) GetVirtualizationMode() (nvml.GpuVirtualizationMode, nvml.Return) {
+	defer recordCall(d.calls, "GetVirtualizationMode", time.Now())
+	return d.Device.GetVirtualizationMode()
+}
+
+func (d timedDevice) GpmMigSampleGet(a0 int, a1 nvml.GpmSample) nvml.Return {
+	defer recordCall(d.calls, "GpmMigSampleGet", time.Now())
+	return d.Device.GpmMigSampleGet(a0, a1)
+}
+
+func (d timedDevice) GpmQueryDeviceSupport() (nvml.GpmSupport, nvml.Return) {
+	defer recordCall(d.calls, "GpmQueryDeviceSupport", time.Now())
+	return d.Device.GpmQueryDeviceSupport()
+}
+
+func (d timedDevice) GpmQueryDeviceSupportV() nvml.GpmSupportV {
+	defer recordCall(d.calls, "GpmQueryDeviceSupportV", time.Now())
+	return d.Device.GpmQueryDeviceSupportV()
+}
+
+func (d timedDevice) GpmQueryIfStreamingEnabled() (uint32, nvml.Return) {
+	defer recordCall(d.calls, "GpmQueryIfStreamingEnabled", time.Now())
+	return d.Device.GpmQueryIfStreamingEnabled()
+}
+
+func (d timedDevice) GpmSampleGet(a0 nvml.GpmSample) nvml.Return {
+	defer recordCall(d.calls, "GpmSampleGet", time.Now())
+	return d.Device.GpmSampleGet(a0)
+}
+
+func (d timedDevice) GpmSetStreamingEnabled(a0 uint32) nvml.Return {
+	defer recordCall(d.calls, "GpmSetStreamingEnabled", time.Now())
+	return d.Device.GpmSetStreamingEnabled(a0)
+}
+
+func (d timedDevice) IsMigDeviceHandle() (bool, nvml.Return) {
+	defer recordCall(d.calls, "IsMigDeviceHandle", time.Now())
+	return d.Device.IsMigDeviceHandle()
+}
+
+func (d timedDevice) OnSameBoard(a0 nvml.Device) (int, nvml.Return) {
+	defer recordCall(d.calls, "OnSameBoard", time.Now())
+	return d.Device.OnSameBoard(a0)
+}
+
+func (d timedDevice) PerfMetricsGetSamples_v1() (nvml.PerfMetricsSamples_v1, nvml.Return) {
+	defer recordCall(d.calls, "PerfMetricsGetSamples_v1", time.Now())
+	return d.Device.PerfMetricsGetSamples_v1()
+}
+
+func (d timedDevice) PowerSmoothingActivatePresetProfile(a0 *nvml.PowerSmoothingProfile) nvml.Return {
+	defer recordCall(d.calls, "PowerSmoothingActivatePresetProfile", time.Now())
+	return d.Device.PowerSmoothingActivatePresetProfile(a0)
+}
+
+func (d timedDevice) PowerSmoothingSetState(a0 *nvml.PowerSmoothingState) nvml.Return {
+	defer recordCall(d.calls, "PowerSmoothingSetState", time.Now())
+	return d.Device.PowerSmoothingSetState(a0)
+}
+
+func (d timedDevice) PowerSmoothingUpdatePresetProfileParam(a0 *nvml.PowerSmoothingProfile) nvml.Return {
+	defer recordCall(d.calls, "PowerSmoothingUpdatePresetProfileParam", time.Now())
+	return d.Device.PowerSmoothingUpdatePresetProfileParam(a0)
+}
+
+func (d timedDevice) ReadPRMCounters_v1(a0 []nvml.PRMCounterId, a1 int) ([]nvml.PRMCounter_v1, nvml.Return) {
+	defer recordCall(d.calls, "ReadPRMCounters_v1", time.Now())
+	return d.Device.ReadPRMCounters_v1(a0, a1)
+}
+
+func (d timedDevice) ReadWritePRM_v1(a0 *nvml.PRMTLV_v1) nvml.Return {
+	defer recordCall(d.calls, "ReadWritePRM_v1", time.Now())
+	return d.Device.ReadWritePRM_v1(a0)
+}
+
+func (d timedDevice) RegisterEvents(a0 uint64, a1 nvml.EventSet) nvml.Return {
+	defer recordCall(d.calls, "RegisterEvents", time.Now())
+	return d.Device.RegisterEvents(a0, a1)
+}
+
+func (d timedDevice) ResetApplicationsClocks() nvml.Return {
+	defer recordCall(d.calls, "ResetApplicationsClocks", time.Now())
+	return d.Device.ResetApplicationsClocks()
+}
+
+func (d timedDevice) ResetGpuLockedClocks() nvml.Return {
+	defer recordCall(d.calls, "ResetGpuLockedClocks", time.Now())
+	return d.Device.ResetGpuLockedClocks()
+}
+
+func (d timedDevice) ResetMemoryLockedClocks() nvml.Return {
+	defer recordCall(d.calls, "ResetMemoryLockedClocks", time.Now())
+	return d.Device.ResetMemoryLockedClocks()
+}
+
+func (d timedDevice) ResetNvLinkErrorCounters(a0 int) nvml.Return {
+	defer recordCall(d.calls, "ResetNvLinkErrorCounters", time.Now())
+	return d.Device.ResetNvLinkErrorCounters(a0)
+}
+
+func (d timedDevice) ResetNvLinkUtilizationCounter(a0 int, a1 int) nvml.Return {
+	defer recordCall(d.calls, "ResetNvLinkUtilizationCounter", time.Now())
+	return d.Device.ResetNvLinkUtilizationCounter(a0, a1)
+}
+
+func (d timedDevice) SetAPIRestriction(a0 nvml.RestrictedAPI, a1 nvml.EnableState) nvml.Return {
+	defer recordCall(d.calls, "SetAPIRestriction", time.Now())
+	return d.Device.SetAPIRestriction(a0, a1)
+}
+
+func (d timedDevice) SetAccountingMode(a0 nvml.EnableState) nvml.Return {
+	defer recordCall(d.calls, "SetAccountingMode", time.Now())
+	return d.Device.SetAccountingMode(a0)
+}
+
+func (d timedDevice) SetAdaptiveTgpMode_v1(a0 nvml.EnableState) nvml.Return {
+	defer recordCall(d.calls, "SetAdaptiveTgpMode_v1", time.Now())
+	return d.Device.SetAdaptiveTgpMode_v1(a0)
+}
+
+func (d timedDevice) SetApplicationsClocks(a0 uint32, a1 uint32) nvml.Return {
+	defer recordCall(d.calls, "SetApplicationsClocks", time.Now())
+	return d.Device.SetApplicationsClocks(a0, a1)
+}
+
+func (d timedDevice) SetAutoBoostedClocksEnabled(a0 nvml.EnableState) nvml.Return {
+	defer recordCall(d.calls, "SetAutoBoostedClocksEnabled", time.Now())
+	return d.Device.SetAutoBoostedClocksEnabled(a0)
+}
+
+func (d timedDevice) SetClockOffsets(a0 nvml.ClockOffset) nvml.Return {
+	defer recordCall(d.calls, "SetClockOffsets", time.Now())
+	return d.Device.SetClockOffsets(a0)
+}
+
+func (d timedDevice) SetComputeMode(a0 nvml.ComputeMode) nvml.Return {
+	defer recordCall(d.calls, "SetComputeMode", time.Now())
+	return d.Device.SetComputeMode(a0)
+}
+
+func (d timedDevice) SetConfComputeUnprotectedMemSize(a0 uint64) nvml.Return {
+	defer recordCall(d.calls, "SetConfComputeUnprotectedMemSize", time.Now())
+	return d.Device.SetConfComputeUnprotectedMemSize(a0)
+}
+
+func (d timedDevice) SetCpuAffinity() nvml.Return {
+	defer recordCall(d.calls, "SetCpuAffinity", time.Now())
+	return d.Device.SetCpuAffinity()
+}
+
+func (d timedDevice) SetDefaultAutoBoostedClocksEnabled(a0 nvml.EnableState, a1 uint32) nvml.Return {
+	defer recordCall(d.calls, "SetDefaultAutoBoostedClocksEnabled", time.Now())
+	return d.Device.SetDefaultAutoBoostedClocksEnabled(a0, a1)
+}
+
+func (d timedDevice) SetDefaultFanSpeed_v2(a0 int) nvml.Return {
+	defer recordCall(d.calls, "SetDefaultFanSpeed_v2", time.Now())
+	return d.Device.SetDefaultFanSpeed_v2(a0)
+}
+
+func (d timedDevice) SetDramEncryptionMode(a0 *nvml.DramEncryptionInfo) nvml.Return {
+	defer recordCall(d.calls, "SetDramEncryptionMode", time.Now())
+	return d.Device.SetDramEncryptionMode(a0)
+}
+
+func (d timedDevice) SetDriverModel(a0 nvml.DriverModel, a1 uint32) nvml.Return {
+	defer recordCall(d.calls, "SetDriverModel", time.Now())
+	return d.Device.SetDriverModel(a0, a1)
+}
+
+func (d timedDevice) SetEccMode(a0 nvml.EnableState) nvml.Return {
+	defer recordCall(d.calls, "SetEccMode", time.Now())
+	return d.Device.SetEccMode(a0)
+}
+
+func (d timedDevice) SetFanControlPolicy(a0 int, a1 nvml.FanControlPolicy) nvml.Return {
+	defer recordCall(d.calls, "SetFanControlPolicy", time.Now())
+	return d.Device.SetFanControlPolicy(a0, a1)
+}
+
+func (d timedDevice) SetFanSpeed_v2(a0 int, a1 int) nvml.Return {
+	defer recordCall(d.calls, "SetFanSpeed_v2", time.Now())
+	return d.Device.SetFanSpeed_v2(a0, a1)
+}
+
+func (d timedDevice) SetGpcClkVfOffset(a0 int) nvml.Return {
+	defer recordCall(d.calls, "SetGpcClkVfOffset", time.Now())
+	return d.Device.SetGpcClkVfOffset(a0)
+}
+
+func (d timedDevice) SetGpuLockedClocks(a0 uint32, a1 uint32) nvml.Return {
+	defer recordCall(d.calls, "SetGpuLockedClocks", time.Now())
+	return d.Device.SetGpuLockedClocks(a0, a1)
+}
+
+func (d timedDevice) SetGpuOperationMode(a0 nvml.GpuOperationMode) nvml.Return {
+	defer recordCall(d.calls, "SetGpuOperationMode", time.Now())
+	return d.Device.SetGpuOperationMode(a0)
+}
+
+func (d timedDevice) SetHostname_v1(a0 string) nvml.Return {
+	defer recordCall(d.calls, "SetHostname_v1", time.Now())
+	return d.Device.SetHostname_v1(a0)
+}
+
+func (d timedDevice) SetMemClkVfOffset(a0 int) nvml.Return {
+	defer recordCall(d.calls, "SetMemClkVfOffset", time.Now())
+	return d.Device.SetMemClkVfOffset(a0)
+}
+
+func (d timedDevice) SetMemoryLimits_v1(a0 string, a1 uint64, a2 uint64) nvml.Return {
+	defer recordCall(d.calls, "SetMemoryLimits_v1", time.Now())
+	return d.Device.SetMemoryLimits_v1(a0, a1, a2)
+}
+
+func (d timedDevice) SetMemoryLockedClocks(a0 uint32, a1 uint32) nvml.Return {
+	defer recordCall(d.calls, "SetMemoryLockedClocks", time.Now())
+	return d.Device.SetMemoryLockedClocks(a0, a1)
+}
+
+func (d timedDevice) SetMigMode(a0 int) (nvml.Return, nvml.Return) {
+	defer recordCall(d.calls, "SetMigMode", time.Now())
+	return d.Device.SetMigMode(a0)
+}
+
+func (d timedDevice) SetNvLinkDeviceLowPowerThreshold(a0 *nvml.NvLinkPowerThres) nvml.Return {
+	defer recordCall(d.calls, "SetNvLinkDeviceLowPowerThreshold", time.Now())
+	return d.Device.SetNvLinkDeviceLowPowerThreshold(a0)
+}
+
+func (d timedDevice) SetNvLinkUtilizationControl(a0 int, a1 int, a2 *nvml.NvLinkUtilizationControl, a3 bool) nvml.Return {
+	defer recordCall(d.calls, "SetNvLinkUtilizationControl", time.Now())
+	return d.Device.SetNvLinkUtilizationControl(a0, a1, a2, a3)
+}
+
+func (d timedDevice) SetNvlinkBwMode(a0 *nvml.NvlinkSetBwMode) nvml.Return {
+	defer recordCall(d.calls, "SetNvlinkBwMode", time.Now())
+	return d.Device.SetNvlinkBwMode(a0)
+}
+
+func (d timedDevice) SetNvlinkBwModeAsync_v1(a0 *nvml.NvlinkSetBwModeAsync_v1) nvml.Return {
+	defer recordCall(d.calls, "SetNvlinkBwModeAsync_v1", time.Now())
+	return d.Device.SetNvlinkBwModeAsync_v1(a0)
+}
+
+func (d timedDevice) SetPersistenceMode(a0 nvml.EnableState) nvml.Return {
+	defer recordCall(d.calls, "SetPersistenceMode", time.Now())
+	return d.Device.SetPersistenceMode(a0)
+}
+
+func (d timedDevice) SetPowerManagementLimit(a0 uint32) nvml.Return {
+	defer recordCall(d.calls, "SetPowerManagementLimit", time.Now())
+	return d.Device.SetPowerManagementLimit(a0)
+}
+
+func (d timedDevice) SetPowerManagementLimit_v2(a0 *nvml.PowerValue_v2) nvml.Return {
+	defer recordCall(d.calls, "SetPowerManagementLimit_v2", time.Now())
+	return d.Device.SetPowerManagementLimit_v2(a0)
+}
+
+func (d timedDevice) SetRusdSettings_v1(a0 nvml.RusdSettings_v1) nvml.Return {
+	defer recordCall(d.calls, "SetRusdSettings_v1", time.Now())
+	return d.Device.SetRusdSettings_v1(a0)
+}
+
+func (d timedDevice) SetTemperatureThreshold(a0 nvml.TemperatureThresholds, a1 int) nvml.Return {
+	defer recordCall(d.calls, "SetTemperatureThreshold", time.Now())
+	return d.Device.SetTemperatureThreshold(a0, a1)
+}
+
+func (d timedDevice) SetVgpuCapabilities(a0 nvml.DeviceVgpuCapability, a1 nvml.EnableState) nvml.Return {
+	defer recordCall(d.calls, "SetVgpuCapabilities", time.Now())
+	return d.Device.SetVgpuCapabilities(a0, a1)
+}
+
+func (d timedDevice) SetVgpuHeterogeneousMode(a0 nvml.VgpuHeterogeneousMode) nvml.Return {
+	defer recordCall(d.calls, "SetVgpuHeterogeneousMode", time.Now())
+	return d.Device.SetVgpuHeterogeneousMode(a0)
+}
+
+func (d timedDevice) SetVgpuSchedulerState(a0 *nvml.VgpuSchedulerSetState) nvml.Return {
+	defer recordCall(d.calls, "SetVgpuSchedulerState", time.Now())
+	return d.Device.SetVgpuSchedulerState(a0)
+}
+
+func (d timedDevice) SetVgpuSchedulerState_v2(a0 *nvml.VgpuSchedulerState_v2) nvml.Return {
+	defer recordCall(d.calls, "SetVgpuSchedulerState_v2", time.Now())
+	return d.Device.SetVgpuSchedulerState_v2(a0)
+}
+
+func (d timedDevice) SetVirtualizationMode(a0 nvml.GpuVirtualizationMode) nvml.Return {
+	defer recordCall(d.calls, "SetVirtualizationMode", time.Now())
+	return d.Device.SetVirtualizationMode(a0)
+}
+
+func (d timedDevice) ValidateInforom() nvml.Return {
+	defer recordCall(d.calls, "ValidateInforom", time.Now())
+	return d.Device.ValidateInforom()
+}
+
+func (d timedDevice) VgpuForceGspUnload() nvml.Return {
+	defer recordCall(d.calls, "VgpuForceGspUnload", time.Now())
+	return d.Device.VgpuForceGspUnload()
+}
+
+func (d timedDevice) VgpuTypeGetMaxInstances(a0 nvml.VgpuTypeId) (int, nvml.Return) {
+	defer recordCall(d.calls, "VgpuTypeGetMaxInstances", time.Now())
+	return d.Device.VgpuTypeGetMaxInstances(a0)
+}
+
+func (d timedDevice) WorkloadPowerProfileClearRequestedProfiles(a0 *nvml.WorkloadPowerProfileRequestedProfiles) nvml.Return {
+	defer recordCall(d.calls, "WorkloadPowerProfileClearRequestedProfiles", time.Now())
+	return d.Device.WorkloadPowerProfileClearRequestedProfiles(a0)
+}
+
+func (d timedDevice) WorkloadPowerProfileGetCurrentProfiles() (nvml.WorkloadPowerProfileCurrentProfiles, nvml.Return) {
+	defer recordCall(d.calls, "WorkloadPowerProfileGetCurrentProfiles", time.Now())
+	return d.Device.WorkloadPowerProfileGetCurrentProfiles()
+}
+
+func (d timedDevice) WorkloadPowerProfileGetProfilesInfo() (nvml.WorkloadPowerProfileProfilesInfo, nvml.Return) {
+	defer recordCall(d.calls, "WorkloadPowerProfileGetProfilesInfo", time.Now())
+	return d.Device.WorkloadPowerProfileGetProfilesInfo()
+}
+
+func (d timedDevice) WorkloadPowerProfileSetRequestedProfiles(a0 *nvml.WorkloadPowerProfileRequestedProfiles) nvml.Return {
+	defer recordCall(d.calls, "WorkloadPowerProfileSetRequestedProfiles", time.Now())
+	return d.Device.WorkloadPowerProfileSetRequestedProfiles(a0)
+}
+
+func (d timedDevice) WorkloadPowerProfileUpdateProfiles_v1(a0 nvml.PowerProfileOperation, a1 []nvml.PowerProfileType) nvml.Return {
+	defer recordCall(d.calls, "WorkloadPowerProfileUpdateProfiles_v1", time.Now())
+	return d.Device.WorkloadPowerProfileUpdateProfiles_v1(a0, a1)
+}
+
+// timeLibraryCalls replaces the package-level functions of nvml with ones
+// that record their duration in calls. Devices they return are wrapped in
+// timedDevice.
+func timeLibraryCalls(calls map[string]*benchStats) {
+	{
+		f := nvml.ComputeInstanceDestroy
+		nvml.ComputeInstanceDestroy = func(a0 nvml.ComputeInstance) nvml.Return {
+			defer recordCall(calls, "ComputeInstanceDestroy", time.Now())
+			return f(a0)
+		}
+	}
+	{
+		f := nvml.ComputeInstanceGetInfo
+		nvml.ComputeInstanceGetInfo = func(a0 nvml.ComputeInstance) (nvml.ComputeInstanceInfo, nvml.Return) {
+			defer recordCall(calls, "ComputeInstanceGetInfo", time.Now())
+			return f(a0)
+		}
+	}
+	{
+		f := nvml.DeviceClearAccountingPids
+		nvml.DeviceClearAccountingPids = func(a0 nvml.Device) nvml.Return {
+			defer recordCall(calls, "DeviceClearAccountingPids", time.Now())
+			return f(a0)
+		}
+	}
+	{
+		f := nvml.DeviceClearCpuAffinity
+		nvml.DeviceClearCpuAffinity = func(a0 nvml.Device) nvml.Return {
+			defer recordCall(calls, "DeviceClearCpuAffinity", time.Now())
+			return f(a0)
+		}
+	}
+	{
+		f := nvml.DeviceClearEccErrorCounts
+		nvml.DeviceClearEccErrorCounts = func(a0 nvml.Device, a1 nvml.EccCounterType) nvml.Return {
+			defer recordCall(calls, "DeviceClearEccErrorCounts", time.Now())
+			return f(a0, a1)
+		}
+	}
+	{
+		f := nvml.DeviceClearFieldValues
+		nvml.DeviceClearFieldValues = func(a0 nvml.Device, a1 []nvml.FieldValue) nvml.Return {
+			defer recordCall(calls, "DeviceClearFieldValues", time.Now())
+			return f(a0, a1)
+		}
+	}
+	{
+		f := nvml.DeviceCreateGpuInstance
+		nvml.DeviceCreateGpuInstance = func(a0 nvml.Device, a1 *nvml.GpuInstanceProfileInfo) (nvml.GpuInstance, nvml.Return) {
+			defer recordCall(calls, "DeviceCreateGpuInstance", time.Now())
+			return f(a0, a1)
+		}
+	}
+	{
+		f := nvml.DeviceCreateGpuInstanceWithPlacement
+		nvml.DeviceCreateGpuInstanceWithPlacement = func(a0 nvml.Device, a1 *nvml.GpuInstanceProfileInfo, a2 *nvml.GpuInstancePlacement) (nvml.GpuInstance, nvml.Return) {
+			defer recordCall(calls, "DeviceCreateGpuInstanceWithPlacement", time.Now())
+			return f(a0, a1, a2)
+		}
+	}
+	{
+		f := nvml.DeviceDiscoverGpus
+		nvml.DeviceDiscoverGpus = func() (nvml.PciInfo, nvml.Return) {
+			defer recordCall(calls, "DeviceDiscoverGpus", time.Now())
+			return f()
+		}
+	}
+	{
+		f := nvml.DeviceFreezeNvLinkUtilizationCounter
+		nvml.DeviceFreezeNvLinkUtilizationCounter = func(a0 nvml.Device, a1 int, a2 int, a3 nvml.EnableState) nvml.Return {
+			defer recordCall(calls, "DeviceFreezeNvLinkUtilizationCounter", time.Now())
+			return f(a0, a1, a2, a3)
+		}
+	}
+	{
+		f := nvml.DeviceGetAPIRestriction
+		nvml.DeviceGetAPIRestriction = func(a0 nvml.Device, a1 nvml.RestrictedAPI) (nvml.EnableState, nvml.Return) {
+			defer recordCall(calls, "DeviceGetAPIRestriction", time.Now())
+			return f(a0, a1)
+		}
+	}
+	{
+		f := nvml.DeviceGetAccountingBufferSize
+		nvml.DeviceGetAccountingBufferSize = func(a0 nvml.Device) (int, nvml.Return) {
+			defer recordCall(calls, "DeviceGetAccountingBufferSize", time.Now())
+			return f(a0)
+		}
+	}
+	{
+		f := nvml.DeviceGetAccountingMode
+		nvml.DeviceGetAccountingMode = func(a0 nvml.Device) (nvml.EnableState, nvml.Return) {
+			defer recordCall(calls, "DeviceGetAccountingMode", time.Now())
+			return f(a0)
+		}
+	}
+	{
+		f := nvml.DeviceGetAccountingPids
+		nvml.DeviceGetAccountingPids = func(a0 nvml.Device) ([]int, nvml.Return) {
+			defer recordCall(calls, "DeviceGetAccountingPids", time.Now())
+			return f(a0)
+		}
+	}
+	{
+		f := nvml.DeviceGetAccountingStats
+		nvml.DeviceGetAccountingStats = func(a0 nvml.Device, a1 uint32) (nvml.AccountingStats, nvml.Return) {
+			defer recordCall(calls, "DeviceGetAccountingStats", time.Now())
+			return f(a0, a1)
+		}
+	}
+	{
+		f := nvml.DeviceGetAccountingStats_v2
+		nvml.DeviceGetAccountingStats_v2 = func(a0 nvml.Device, a1 uint32) (nvml.AccountingStats_v2, nvml.Return) {
+			defer recordCall(calls, "DeviceGetAccountingStats_v2", time.Now())
+			return f(a0, a1)
+		}
+	}
+	{
+		f := nvml.DeviceGetActiveVgpus
+		nvml.DeviceGetActiveVgpus = func(a0 nvml.Device) ([]nvml.VgpuInstance, nvml.Return) {
+			defer recordCall(calls, "DeviceGetActiveVgpus", time.Now())
+			return f(a0)
+		}
+	}
+	{
+		f := nvml.DeviceGetAdaptiveClockInfoStatus
+		nvml.DeviceGetAdaptiveClockInfoStatus = func(a0 nvml.Device) (uint32, nvml.Return) {
+			defer recordCall(calls, "DeviceGetAdaptiveClockInfoStatus", time.Now())
+			return f(a0)
+		}
+	}
+	{
+		f := nvml.DeviceGetAdaptiveTgpModeInfo_v1
+		nvml.DeviceGetAdaptiveTgpModeInfo_v1 = func(a0 nvml.Device) (nvml.AdaptiveTgpModeInfo_v1, nvml.Return) {
+			defer recordCall(calls, "DeviceGetAdaptiveTgpModeInfo_v1", time.Now())
+			return f(a0)
+		}
+	}
+	{
+		f := nvml.DeviceGetAddressingMode
+		nvml.DeviceGetAddressingMode = func(a0 nvml.Device) (nvml.DeviceAddressingMode, nvml.Return) {
+			defer recordCall(calls, "DeviceGetAddressingMode", time.Now())
+			return f(a0)
+		}
+	}
+	{
+		f := nvml.DeviceGetApplicationsClock
+		nvml.DeviceGetApplicationsClock = func(a0 nvml.Device, a1 nvml.ClockType) (uint32, nvml.Return) {
+			defer recordCall(calls, "DeviceGetApplicationsClock", time.Now())
+			return f(a0, a1)
+		}
+	}
+	{
+		f := nvml.DeviceGetArchitecture
+		nvml.DeviceGetArchitecture = func(a0 nvml.Device) (nvml.DeviceArchitecture, nvml.Return) {
+			defer recordCall(calls, "DeviceGetArchitecture", time.Now())
+			return f(a0)
+		}
+	}
+	{
+		f := nvml.DeviceGetAttributes
+		nvml.DeviceGetAttributes = func(a0 nvml.Device) (nvml.DeviceAttributes, nvml.Return) {
+			defer recordCall(calls, "DeviceGetAttributes", time.Now())
+			return f(a0)
+		}
+	}
+	{
+		f := nvml.DeviceGetAutoBoostedClocksEnabled
+		nvml.DeviceGetAutoBoostedClocksEnabled = func(a0 nvml.Device) (nvml.EnableState, nvml.EnableState, nvml.Return) {
+			defer recordCall(calls, "DeviceGetAutoBoostedClocksEnabled", time.Now())
+			return f(a0)
+		}
+	}
+	{
+		f := nvml.DeviceGetBAR1MemoryInfo
+		nvml.DeviceGetBAR1MemoryInfo = func(a0 nvml.Device) (nvml.BAR1Memory, nvml.Return) {
+			defer recordCall(calls, "DeviceGetBAR1MemoryInfo", time.Now())
+			return f(a0)
+		}
+	}
+	{
+		f := nvml.DeviceGetBBXTimeData_v1
+		nvml.DeviceGetBBXTimeData_v1 = func(a0 nvml.Device) (nvml.BBXTimeData_v1, nvml.Return) {
+			defer recordCall(calls, "DeviceGetBBXTimeData_v1", time.Now())
+			return f(a0)
+		}
+	}
+	{
+		f := nvml.DeviceGetBankRemapperStatus_v1
+		nvml.DeviceGetBankRemapperStatus_v1 = func(a0 nvml.Device) (nvml.EccBankRemapperStatus_v1, nvml.Return) {
+			defer recordCall(calls, "DeviceGetBankRemapperStatus_v1", time.Now())
+			return f(a0)
+		}
+	}
+	{
+		f := nvml.DeviceGetBoardId
+		nvml.DeviceGetBoardId = func(a0 nvml.Device) (uint32, nvml.Return) {
+			defer recordCall(calls, "DeviceGetBoardId", time.Now())
+			return f(a0)
+		}
+	}
+	{
+		f := nvml.DeviceGetBoardPartNumber
+		nvml.DeviceGetBoardPartNumber = func(a0 nvml.Device) (string, nvml.Return) {
+			defer recordCall(calls, "DeviceGetBoardPartNumber", time.Now())
+			return f(a0)
+		}
+	}
+	{
+		f := nvml.DeviceGetBrand
+		nvml.DeviceGetBrand = func(a0 nvml.Device) (nvml.BrandType, nvml.Return) {
+			defer recordCall(calls, "DeviceGetBrand", time.Now())
+			return f(a0)
+		}
+	}
+	{
+		f := nvml.DeviceGetBridgeChipInfo
+		nvml.DeviceGetBridgeChipInfo = func(a0 nvml.Device) (nvml.BridgeChipHierarchy, nvml.Return) {
+			defer recordCall(calls, "DeviceGetBridgeChipInfo", time.Now())
+			return f(a0)
+		}
+	}
+	{
+		f := nvml.DeviceGetBusType
+		nvml.DeviceGetBusType = func(a0 nvml.Device) (nvml.BusType, nvml.Return) {
+			defer recordCall(calls, "DeviceGetBusType", time.Now())
+			return f(a0)
+		}
+	}
+	{
+		f := nvml.DeviceGetC2cModeInfoV
+		nvml.DeviceGetC2cModeInfoV = func(a0 nvml.Device) nvml.C2cModeInfoHandler {
+			defer recordCall(calls, "DeviceGetC2cModeInfoV", time.Now())
+			return f(a0)
+		}
+	}
+	{
+		f := nvml.DeviceGetCapabilities
+		nvml.DeviceGetCapabilities = func(a0 nvml.Device) (nvml.DeviceCapabilities, nvml.Return) {
+			defer recordCall(calls, "DeviceGetCapabilities", time.Now())
+			return f(a0)
+		}
+	}
+	{
+		f := nvml.DeviceGetClkMonStatus
+		nvml.DeviceGetClkMonStatus = func(a0 nvml.Device) (nvml.ClkMonStatus, nvml.Return) {
+			defer recordCall(calls, "DeviceGetClkMonStatus", time.Now())
+			return f(a0)
+		}
+	}
+	{
+		f := nvml.DeviceGetClock
+		nvml.DeviceGetClock = func(a0 nvml.Device, a1 nvml.ClockType, a2 nvml.ClockId) (uint32, nvml.Return) {
+			defer recordCall(calls, "DeviceGetClock", time.Now())
+			return f(a0, a1, a2)
+		}
+	}
+	{
+		f := nvml.DeviceGetClockInfo
+		nvml.DeviceGetClockInfo = func(a0 nvml.Device, a1 nvml.ClockType) (uint32, nvml.Return) {
+			defer recordCall(calls, "DeviceGetClockInfo", time.Now())
+			return f(a0, a1)
+		}
+	}
+	{
+		f := nvml.DeviceGetClockOffsets
+		nvml.DeviceGetClockOffsets = func(a0 nvml.Device) (nvml.ClockOffset, nvml.Return) {
+			defer recordCall(calls, "DeviceGetClockOffsets", time.Now())
+			return f(a0)
+		}
+	}
+	{
+		f := nvml.DeviceGetComputeInstanceId
+		nvml.DeviceGetComputeInstanceId = func(a0 nvml.Device) (int, nvml.Return) {
+			defer recordCall(calls, "DeviceGetComputeInstanceId", time.Now())
+			return f(a0)
+		}
+	}
+	{
+		f := nvml.DeviceGetComputeMode
+		nvml.DeviceGetComputeMode = func(a0 nvml.Device) (nvml.ComputeMode, nvml.Return) {
+			defer recordCall(calls, "DeviceGetComputeMode", time.Now())
+			return f(a0)
+		}
+	}
+	{
+		f := nvml.DeviceGetComputeRunningProcesses
+		nvml.DeviceGetComputeRunningProcesses = func(a0 nvml.Device) ([]nvml.ProcessInfo, nvml.Return) {
+			defer recordCall(calls, "DeviceGetComputeRunningProcesses", time.Now())
+			return f(a0)
+		}
+	}
+	{
+		f := nvml.DeviceGetConfComputeGpuAttestationReport
+		nvml.DeviceGetConfComputeGpuAttestationReport = func(a0 nvml.Device, a1 *nvml.ConfComputeGpuAttestationReport) nvml.Return {
+			defer recordCall(calls, "DeviceGetConfComputeGpuAttestationReport", time.Now())
+			return f(a0, a1)
+		}
+	}
+	{
+		f := nvml.DeviceGetConfComputeGpuCertificate
+		nvml.DeviceGetConfComputeGpuCertificate = func(a0 nvml.Device) (nvml.ConfComputeGpuCertificate, nvml.Return) {
+			defer recordCall(calls, "DeviceGetConfComputeGpuCertificate", time.Now())
+			return f(a0)
+		}
+	}
+	{
+		f := nvml.DeviceGetConfComputeMemSizeInfo
+		nvml.DeviceGetConfComputeMemSizeInfo = func(a0 nvml.Device) (nvml.ConfComputeMemSizeInfo, nvml.Return) {
+			defer recordCall(calls, "DeviceGetConfComputeMemSizeInfo", time.Now())
+			return f(a0)
+		}
+	}
+	{
+		f := nvml.DeviceGetConfComputeProtectedMemoryUsage
+		nvml.DeviceGetConfComputeProtectedMemoryUsage = func(a0 nvml.Device) (nvml.Memory, nvml.Return) {
+			defer recordCall(calls, "DeviceGetConfComputeProtectedMemoryUsage", time.Now())
+			return f(a0)
+		}
+	}
+	{
+		f := nvml.DeviceGetCoolerInfo
+		nvml.DeviceGetCoolerInfo = func(a0 nvml.Device) (nvml.CoolerInfo, nvml.Return) {
+			defer recordCall(calls, "DeviceGetCoolerInfo", time.Now())
+			return f(a0)
+		}
+	}
+	{
+		f := nvml.DeviceGetCount
+		nvml.DeviceGetCount = func() (int, nvml.Return) {
+			defer recordCall(calls, "DeviceGetCount", time.Now())
+			return f()
+		}
+	}
+	{
+		f := nvml.DeviceGetCpuAffinity
+		nvml.DeviceGetCpuAffinity = func(a0 nvml.Device, a1 int) ([]uint, nvml.Return) {
+			defer recordCall(calls, "DeviceGetCpuAffinity", time.Now())
+			return f(a0, a1)
+		}
+	}
+	{
+		f := nvml.DeviceGetCpuAffinityWithinScope
+		nvml.DeviceGetCpuAffinityWithinScope = func(a0 nvml.Device, a1 int, a2 nvml.AffinityScope) ([]uint, nvml.Return) {
+			defer recordCall(calls, "DeviceGetCpuAffinityWithinScope", time.Now())
+			return f(a0, a1, a2)
+		}
+	}
+	{
+		f := nvml.DeviceGetCreatableVgpus
+		nvml.DeviceGetCreatableVgpus = func(a0 nvml.Device) ([]nvml.VgpuTypeId, nvml.Return) {
+			defer recordCall(calls, "DeviceGetCreatableVgpus", time.Now())
+			return f(a0)
+		}
+	}
+	{
+		f := nvml.DeviceGetCudaComputeCapability
+		nvml.DeviceGetCudaComputeCapability = func(a0 nvml.Device) (int, int, nvml.Return) {
+			defer recordCall(calls, "DeviceGetCudaComputeCapability", time.Now())
+			return f(a0)
+		}
+	}
+	{
+		f := nvml.DeviceGetCurrPcieLinkGeneration
+		nvml.DeviceGetCurrPcieLinkGeneration = func(a0 nvml.Device) (int, nvml.Return) {
+			defer recordCall(calls, "DeviceGetCurrPcieLinkGeneration", time.Now())
+			return f(a0)
+		}
+	}
+	{
+		f := nvml.DeviceGetCurrPcieLinkWidth
+		nvml.DeviceGetCurrPcieLinkWidth = func(a0 nvml.Device) (int, nvml.Return) {
+			defer recordCall(calls, "DeviceGetCurrPcieLinkWidth", time.Now())
+			return f(a0)
+		}
+	}
+	{
+		f := nvml.DeviceGetCurrentClockFreqs
+		nvml.DeviceGetCurrentClockFreqs = func(a0 nvml.Device) (nvml.DeviceCurrentClockFreqs, nvml.Return) {
+			defer recordCall(calls, "DeviceGetCurrentClockFreqs", time.Now())
+			return f(a0)
+		}
+	}
+	{
+		f := nvml.DeviceGetCurrentClocksEventReasons
+		nvml.DeviceGetCurrentClocksEventReasons = func(a0 nvml.Device) (uint64, nvml.Return) {
+			defer recordCall(calls, "DeviceGetCurrentClocksEventReasons", time.Now())
+			return f(a0)
+		}
+	}
+	{
+		f := nvml.DeviceGetCurrentClocksThrottleReasons
+		nvml.DeviceGetCurrentClocksThrottleReasons = func(a0 nvml.Device) (uint64, nvml.Return) {
+			defer recordCall(calls, "DeviceGetCurrentClocksThrottleReasons", time.Now())
+			return f(a0)
+		}
+	}
+	{
+		f := nvml.DeviceGetDecoderUtilization
+		nvml.DeviceGetDecoderUtilization = func(a0 nvml.Device) (uint32, uint32, nvml.Return) {
+			defer recordCall(calls, "DeviceGetDecoderUtilization", time.Now())
+			return f(a0)
+		}
+	}
+	{
+		f := nvml.DeviceGetDefaultApplicationsClock
+		nvml.DeviceGetDefaultApplicationsClock = func(a0 nvml.Device, a1 nvml.ClockType) (uint32, nvml.Return) {
+			defer recordCall(calls, "DeviceGetDefaultApplicationsClock", time.Now())
+			return f(a0, a1)
+		}
+	}
+	{
+		f := nvml.DeviceGetDefaultEccMode
+		nvml.DeviceGetDefaultEccMode = func(a0 nvml.Device) (nvml.EnableState, nvml.Return) {
+			defer recordCall(calls, "DeviceGetDefaultEccMode", time.Now())
+			return f(a0)
+		}
+	}
+	{
+		f := nvml.DeviceGetDetailedEccErrors
+		nvml.DeviceGetDetailedEccErrors = func(a0 nvml.Device, a1 nvml.MemoryErrorType, a2 nvml.EccCounterType) (nvml.EccErrorCounts, nvml.Return) {
+			defer recordCall(calls, "DeviceGetDetailedEccErrors", time.Now())
+			return f(a0, a1, a2)
+		}
+	}
+	{
+		f := nvml.DeviceGetDeviceHandleFromMigDeviceHandle
+		nvml.DeviceGetDeviceHandleFromMigDeviceHandle = func(a0 nvml.Device) (nvml.Device, nvml.Return) {
+			start := time.Now()
+			r0, r1 := f(a0)
+			recordCall(calls, "DeviceGetDeviceHandleFromMigDeviceHandle", start)
+			if r0 != nil {
+				r0 = timedDevice{Device: r0, calls: calls}
+			}
+			return r0, r1
+		}
+	}
+	{
+		f := nvml.DeviceGetDisplayActive
+		nvml.DeviceGetDisplayActive = func(a0 nvml.Device) (nvml.EnableState, nvml.Return) {
+			defer recordCall(calls, "DeviceGetDisplayActive", time.Now())
+			return f(a0)
+		}
+	}
+	{
+		f := nvml.DeviceGetDisplayMode
+		nvml.DeviceGetDisplayMode = func(a0 nvml.Device) (nvml.EnableState, nvml.Return) {
+			defer recordCall(calls, "DeviceGetDisplayMode", time.Now())
+			return f(a0)
+		}
+	}
+	{
+		f := nvml.DeviceGetDramEncryptionMode
+		nvml.DeviceGetDramEncryptionMode = func(a0 nvml.Device) (nvml.DramEncryptionInfo, nvml.DramEncryptionInfo, nvml.Return) {
+			defer recordCall(calls, "DeviceGetDramEncryptionMode", time.Now())
+			return f(a0)
+		}
+	}
+	{
+		f := nvml.DeviceGetDriverModel
+		nvml.DeviceGetDriverModel = func(a0 nvml.Device) (nvml.DriverModel, nvml.DriverModel, nvml.Return) {
+			defer recordCall(calls, "DeviceGetDriverModel", time.Now())
+			return f(a0)
+		}
+	}
+	{
+		f := nvml.DeviceGetDriverModel_v2
+		nvml.DeviceGetDriverModel_v2 = func(a0 nvml.Device) (nvml.DriverModel, nvml.DriverModel, nvml.Return) {
+			defer recordCall(calls, "DeviceGetDriverModel_v2", time.Now())
+			return f(a0)
+		}
+	}
+	{
+		f := nvml.DeviceGetDynamicPstatesInfo
+		nvml.DeviceGetDynamicPstatesInfo = func(a0 nvml.Device) (nvml.GpuDynamicPstatesInfo, nvml.Return) {
+			defer recordCall(calls, "DeviceGetDynamicPstatesInfo", time.Now())
+			return f(a0)
+		}
+	}
+	{
+		f := nvml.DeviceGetEccMode
+		nvml.DeviceGetEccMode = func(a0 nvml.Device) (nvml.EnableState, nvml.EnableState, nvml.Return) {
+			defer recordCall(calls, "DeviceGetEccMode", time.Now())
+			return f(a0)
+		}
+	}
+	{
+		f := nvml.DeviceGetEncoderCapacity
+		nvml.DeviceGetEncoderCapacity = func(a0 nvml.Device, a1 nvml.EncoderType) (int, nvml.Return) {
+			defer recordCall(calls, "DeviceGetEncoderCapacity", time.Now())
+			return f(a0, a1)
+		}
+	}
+	{
+		f := nvml.DeviceGetEncoderSessions
+		nvml.DeviceGetEncoderSessions = func(a0 nvml.Device) ([]nvml.EncoderSessionInfo, nvml.Return) {
+			defer recordCall(calls, "DeviceGetEncoderSessions", time.Now())
+			return f(a0)
+		}
+	}
+	{
+		f := nvml.DeviceGetEncoderStats
+		nvml.DeviceGetEncoderStats = func(a0 nvml.Device) (int, uint32, uint32, nvml.Return) {
+			defer recordCall(calls, "DeviceGetEncoderStats", time.Now())
+			return f(a0)
+		}
+	}
+	{
+		f := nvml.DeviceGetEncoderUtilization
+		nvml.DeviceGetEncoderUtilization = func(a0 nvml.Device) (uint32, uint32, nvml.Return) {
+			defer recordCall(calls, "DeviceGetEncoderUtilization", time.Now())
+			return f(a0)
+		}
+	}
+	{
+		f := nvml.DeviceGetEnforcedPowerLimit
+		nvml.DeviceGetEnforcedPowerLimit = func(a0 nvml.Device) (uint32, nvml.Return) {
+			defer recordCall(calls, "DeviceGetEnforcedPowerLimit", time.Now())
+			return f(a0)
+		}
+	}
+	{
+		f := nvml.DeviceGetFBCSessions
+		nvml.DeviceGetFBCSessions = func(a0 nvml.Device) ([]nvml.FBCSessionInfo, nvml.Return) {
+			defer recordCall(calls, "DeviceGetFBCSessions", time.Now())
+			return f(a0)
+		}
+	}
+	{
+		f := nvml.DeviceGetFBCStats
+		nvml.DeviceGetFBCStats = func(a0 nvml.Device) (nvml.FBCStats, nvml.Return) {
+			defer recordCall(calls, "DeviceGetFBCStats", time.Now())
+			return f(a0)
+		}
+	}
+	{
+		f := nvml.DeviceGetFanControlPolicy_v2
+		nvml.DeviceGetFanControlPolicy_v2 = func(a0 nvml.Device, a1 int) (nvml.FanControlPolicy, nvml.Return) {
+			defer recordCall(calls, "DeviceGetFanControlPolicy_v2", time.Now())
+			return f(a0, a1)
+		}
+	}
+	{
+		f := nvml.DeviceGetFanSpeed
+		nvml.DeviceGetFanSpeed = func(a0 nvml.Device) (uint32, nvml.Return) {
+			defer recordCall(calls, "DeviceGetFanSpeed", time.Now())
+			return f(a0)
+		}
+	}
+	{
+		f := nvml.DeviceGetFanSpeedRPM
+		nvml.DeviceGetFanSpeedRPM = func(a0 nvml.Device) (nvml.FanSpeedInfo, nvml.Return) {
+			defer recordCall(calls, "DeviceGetFanSpeedRPM", time.Now())
+			return f(a0)
+		}
+	}
+	{
+		f := nvml.DeviceGetFanSpeed_v2
+		nvml.DeviceGetFanSpeed_v2 = func(a0 nvml.Device, a1 int) (uint32, nvml.Return) {
+			defer recordCall(calls, "DeviceGetFanSpeed_v2", time.Now())
+			return f(a0, a1)
+		}
+	}
+	{
+		f := nvml.DeviceGetFieldValues
+		nvml.DeviceGetFieldValues = func(a0 nvml.Device, a1 []nvml.FieldValue) nvml.Return {
+			defer recordCall(calls, "DeviceGetFieldValues", time.Now())
+			return f(a0, a1)
+		}
+	}
+	{
+		f := nvml.DeviceGetGpcClkMinMaxVfOffset
+		nvml.DeviceGetGpcClkMinMaxVfOffset = func(a0 nvml.Device) (int, int, nvml.Return) {
+			defer recordCall(calls, "DeviceGetGpcClkMinMaxVfOffset", time.Now())
+			return f(a0)
+		}
+	}
+	{
+		f := nvml.DeviceGetGpcClkVfOffset
+		nvml.DeviceGetGpcClkVfOffset = func(a0 nvml.Device) (int, nvml.Return) {
+			defer recordCall(calls, "DeviceGetGpcClkVfOffset", time.Now())
+			return f(a0)
+		}
+	}
+	{
+		f := nvml.DeviceGetGpuFabricInfo
+		nvml.DeviceGetGpuFabricInfo = func(a0 nvml.Device) (nvml.GpuFabricInfo, nvml.Return) {
+			defer recordCall(calls, "DeviceGetGpuFabricInfo", time.Now())
+			return f(a0)
+		}
+	}
+	{
+		f := nvml.DeviceGetGpuFabricInfoV
+		nvml.DeviceGetGpuFabricInfoV = func(a0 nvml.Device) nvml.GpuFabricInfoHandler {
+			defer recordCall(calls, "DeviceGetGpuFabricInfoV", time.Now())
+			return f(a0)
+		}
+	}
+	{
+		f := nvml.DeviceGetGpuFabricInfo_v4
+		nvml.DeviceGetGpuFabricInfo_v4 = func(a0 nvml.Device) (nvml.GpuFabricInfo_v4, nvml.Return) {
+			defer recordCall(calls, "DeviceGetGpuFabricInfo_v4", time.Now())
+			return f(a0)
+		}
+	}
+	{
+		f := nvml.DeviceGetGpuInstanceById
+		nvml.DeviceGetGpuInstanceById = func(a0 nvml.Device, a1 int) (nvml.GpuInstance, nvml.Return) {
+			defer recordCall(calls, "DeviceGetGpuInstanceById", time.Now())
+			return f(a0, a1)
+		}
+	}
+	{
+		f := nvml.DeviceGetGpuInstanceId
+		nvml.DeviceGetGpuInstanceId = func(a0 nvml.Device) (int, nvml.Return) {
+			defer recordCall(calls, "DeviceGetGpuInstanceId", time.Now())
+			return f(a0)
+		}
+	}
+	{
+		f := nvml.DeviceGetGpuInstancePossiblePlacements
+		nvml.DeviceGetGpuInstancePossiblePlacements = func(a0 nvml.Device, a1 *nvml.GpuInstanceProfileInfo) ([]nvml.GpuInstancePlacement, nvml.Return) {
+			defer recordCall(calls, "DeviceGetGpuInstancePossiblePlacements", time.Now())
+			return f(a0, a1)
+		}
+	}
+	{
+		f := nvml.DeviceGetGpuInstanceProfileInfo
+		nvml.DeviceGetGpuInstanceProfileInfo = func(a0 nvml.Device, a1 int) (nvml.GpuInstanceProfileInfo, nvml.Return) {
+			defer recordCall(calls, "DeviceGetGpuInstanceProfileInfo", time.Now())
+			return f(a0, a1)
+		}
+	}
+	{
+		f := nvml.DeviceGetGpuInstanceProfileInfoByIdV
+		nvml.DeviceGetGpuInstanceProfileInfoByIdV = func(a0 nvml.Device, a1 int) nvml.GpuInstanceProfileInfoByIdHandler {
+			defer recordCall(calls, "DeviceGetGpuInstanceProfileInfoByIdV", time.Now())
+			return f(a0, a1)
+		}
+	}
+	{
+		f := nvml.DeviceGetGpuInstanceProfileInfoV
+		nvml.DeviceGetGpuInstanceProfileInfoV = func(a0 nvml.Device, a1 int) nvml.GpuInstanceProfileInfoHandler {
+			defer recordCall(calls, "DeviceGetGpuInstanceProfileInfoV", time.Now())
+			return f(a0, a1)
+		}
+	}
+	{
+		f := nvml.DeviceGetGpuInstanceRemainingCapacity
+		nvml.DeviceGetGpuInstanceRemainingCapacity = func(a0 nvml.Device, a1 *nvml.GpuInstanceProfileInfo) (int, nvml.Return) {
+			defer recordCall(calls, "DeviceGetGpuInstanceRemainingCapacity", time.Now())
+			return f(a0, a1)
+		}
+	}
+	{
+		f := nvml.DeviceGetGpuInstances
+		nvml.DeviceGetGpuInstances = func(a0 nvml.Device, a1 *nvml.GpuInstanceProfileInfo) ([]nvml.GpuInstance, nvml.Return) {
+			defer recordCall(calls, "DeviceGetGpuInstances", time.Now())
+			return f(a0, a1)
+		}
+	}
+	{
+		f := nvml.DeviceGetGpuMaxPcieLinkGeneration
+		nvml.DeviceGetGpuMaxPcieLinkGeneration = func(a0 nvml.Device) (int, nvml.Return) {
+			defer recordCall(calls, "DeviceGetGpuMaxPcieLinkGeneration", time.Now())
+			return f(a0)
+		}
+	}
+	{
+		f := nvml.DeviceGetGpuOperationMode
+		nvml.DeviceGetGpuOperationMode = func(a0 nvml.Device) (nvml.GpuOperationMode, nvml.GpuOperationMode, nvml.Return) {
+			defer recordCall(calls, "DeviceGetGpuOperationMode", time.Now())
+			return f(a0)
+		}
+	}
+	{
+		f := nvml.DeviceGetGraphicsRunningProcesses
+		nvml.DeviceGetGraphicsRunningProcesses = func(a0 nvml.Device) ([]nvml.ProcessInfo, nvml.Return) {
+			defer recordCall(calls, "DeviceGetGraphicsRunningProcesses", time.Now())
+			return f(a0)
+		}
+	}
+	{
+		f := nvml.DeviceGetGridLicensableFeatures
+		nvml.DeviceGetGridLicensableFeatures = func(a0 nvml.Device) (nvml.GridLicensableFeatures, nvml.Return) {
+			defer recordCall(calls, "DeviceGetGridLicensableFeatures", time.Now())
+			return f(a0)
+		}
+	}
+	{
+		f := nvml.DeviceGetGspFirmwareMode
+		nvml.DeviceGetGspFirmwareMode = func(a0 nvml.Device) (bool, bool, nvml.Return) {
+			defer recordCall(calls, "DeviceGetGspFirmwareMode", time.Now())
+			return f(a0)
+		}
+	}
+	{
+		f := nvml.DeviceGetGspFirmwareVersion
+		nvml.DeviceGetGspFirmwareVersion = func(a0 nvml.Device) (string, nvml.Return) {
+			defer recordCall(calls, "DeviceGetGspFirmwareVersion", time.Now())
+			return f(a0)
+		}
+	}
+	{
+		f := nvml.DeviceGetHandleByIndex
+		nvml.DeviceGetHandleByIndex = func(a0 int) (nvml.Device, nvml.Return) {
+			start := time.Now()
+			r0, r1 := f(a0)
+			recordCall(calls, "DeviceGetHandleByIndex", start)
+			if r0 != nil {
+				r0 = timedDevice{Device: r0, calls: calls}
+			}
+			return r0, r1
+		}
+	}
+	{
+		f := nvml.DeviceGetHandleByPciBusId
+		nvml.DeviceGetHandleByPciBusId = func(a0 string) (nvml.Device, nvml.Return) {
+			start := time.Now()
+			r0, r1 := f(a0)
+			recordCall(calls, "DeviceGetHandleByPciBusId", start)
+			if r0 != nil {
+				r0 = timedDevice{Device: r0, calls: calls}
+			}
+			return r0, r1
+		}
+	}
+	{
+		f := nvml.DeviceGetHandleBySerial
+		nvml.DeviceGetHandleBySerial = func(a0 string) (nvml.Device, nvml.Return) {
+			start := time.Now()
+			r0, r1 := f(a0)
+			recordCall(calls, "DeviceGetHandleBySerial", start)
+			if r0 != nil {
+				r0 = timedDevice{Device: r0, calls: calls}
+			}
+			return r0, r1
+		}
+	}
+	{
+		f := nvml.DeviceGetHandleByUUID
+		nvml.DeviceGetHandleByUUID = func(a0 string) (nvml.Device, nvml.Return) {
+			start := time.Now()
+			r0, r1 := f(a0)
+			recordCall(calls, "DeviceGetHandleByUUID", start)
+			if r0 != nil {
+				r0 = timedDevice{Device: r0, calls: calls}
+			}
+			return r0, r1
+		}
+	}
+	{
+		f := nvml.DeviceGetHandleByUUIDV
+		nvml.DeviceGetHandleByUUIDV = func(a0 *nvml.UUID) (nvml.Device, nvml.Return) {
+			start := time.Now()
+			r0, r1 := f(a0)
+			recordCall(calls, "DeviceGetHandleByUUIDV", start)
+			if r0 != nil {
+				r0 = timedDevice{Device: r0, calls: calls}
+			}
+			return r0, r1
+		}
+	}
+	{
+		f := nvml.DeviceGetHostVgpuMode
+		nvml.DeviceGetHostVgpuMode = func(a0 nvml.Device) (nvml.HostVgpuMode, nvml.Return) {
+			defer recordCall(calls, "DeviceGetHostVgpuMode", time.Now())
+			return f(a0)
+		}
+	}
+	{
+		f := nvml.DeviceGetHostname_v1
+		nvml.DeviceGetHostname_v1 = func(a0 nvml.Device) (string, nvml.Return) {
+			defer recordCall(calls, "DeviceGetHostname_v1", time.Now())
+			return f(a0)
+		}
+	}
+	{
+		f := nvml.DeviceGetIndex
+		nvml.DeviceGetIndex = func(a0 nvml.Device) (int, nvml.Return) {
+			defer recordCall(calls, "DeviceGetIndex", time.Now())
+			return f(a0)
+		}
+	}
+	{
+		f := nvml.DeviceGetInforomConfigurationChecksum
+		nvml.DeviceGetInforomConfigurationChecksum = func(a0 nvml.Device) (uint32, nvml.Return) {
+			defer recordCall(calls, "DeviceGetInforomConfigurationChecksum", time.Now())
+			return f(a0)
+		}
+	}
+	{
+		f := nvml.DeviceGetInforomImageVersion
+		nvml.DeviceGetInforomImageVersion = func(a0 nvml.Device) (string, nvml.Return) {
+			defer recordCall(calls, "DeviceGetInforomImageVersion", time.Now())
+			return f(a0)
+		}
+	}
+	{
+		f := nvml.DeviceGetInforomVersion
+		nvml.DeviceGetInforomVersion = func(a0 nvml.Device, a1 nvml.InforomObject) (string, nvml.Return) {
+			defer recordCall(calls, "DeviceGetInforomVersion", time.Now())
+			return f(a0, a1)
+		}
+	}
+	{
+		f := nvml.DeviceGetIrqNum
+		nvml.DeviceGetIrqNum = func(a0 nvml.Device) (int, nvml.Return) {
+			defer recordCall(calls, "DeviceGetIrqNum", time.Now())
+			return f(a0)
+		}
+	}
+	{
+		f := nvml.DeviceGetJpgUtilization
+		nvml.DeviceGetJpgUtilization = func(a0 nvml.Device) (uint32, uint32, nvml.Return) {
+			defer recordCall(calls, "DeviceGetJpgUtilization", time.Now())
+			return f(a0)
+		}
+	}
+	{
+		f := nvml.DeviceGetLastBBXFlushTime
+		nvml.DeviceGetLastBBXFlushTime = func(a0 nvml.Device) (uint64, uint, nvml.Return) {
+			defer recordCall(calls, "DeviceGetLastBBXFlushTime", time.Now())
+			return f(a0)
+		}
+	}
+	{
+		f := nvml.DeviceGetMPSComputeRunningProcesses
+		nvml.DeviceGetMPSComputeRunningProcesses = func(a0 nvml.Device) ([]nvml.ProcessInfo, nvml.Return) {
+			defer recordCall(calls, "DeviceGetMPSComputeRunningProcesses", time.Now())
+			return f(a0)
+		}
+	}
+	{
+		f := nvml.DeviceGetMarginTemperature
+		nvml.DeviceGetMarginTemperature = func(a0 nvml.Device) (nvml.MarginTemperature, nvml.Return) {
+			defer recordCall(calls, "DeviceGetMarginTemperature", time.Now())
+			return f(a0)
+		}
+	}
+	{
+		f := nvml.DeviceGetMaxClockInfo
+		nvml.DeviceGetMaxClockInfo = func(a0 nvml.Device, a1 nvml.ClockType) (uint32, nvml.Return) {
+			defer recordCall(calls, "DeviceGetMaxClockInfo", time.Now())
+			return f(a0, a1)
+		}
+	}
+	{
+		f := nvml.DeviceGetMaxCustomerBoostClock
+		nvml.DeviceGetMaxCustomerBoostClock = func(a0 nvml.Device, a1 nvml.ClockType) (uint32, nvml.Return) {
+			defer recordCall(calls, "DeviceGetMaxCustomerBoostClock", time.Now())
+			return f(a0, a1)
+		}
+	}
+	{
+		f := nvml.DeviceGetMaxMigDeviceCount
+		nvml.DeviceGetMaxMigDeviceCount = func(a0 nvml.Device) (int, nvml.Return) {
+			defer recordCall(calls, "DeviceGetMaxMigDeviceCount", time.Now())
+			return f(a0)
+		}
+	}
+	{
+		f := nvml.DeviceGetMaxPcieLinkGeneration
+		nvml.DeviceGetMaxPcieLinkGeneration = func(a0 nvml.Device) (int, nvml.Return) {
+			defer recordCall(calls, "DeviceGetMaxPcieLinkGeneration", time.Now())
+			return f(a0)
+		}
+	}
+	{
+		f := nvml.DeviceGetMaxPcieLinkWidth
+		nvml.DeviceGetMaxPcieLinkWidth = func(a0 nvml.Device) (int, nvml.Return) {
+			defer recordCall(calls, "DeviceGetMaxPcieLinkWidth", time.Now())
+			return f(a0)
+		}
+	}
+	{
+		f := nvml.DeviceGetMemClkMinMaxVfOffset
+		nvml.DeviceGetMemClkMinMaxVfOffset = func(a0 nvml.Device) (int, int, nvml.Return) {
+			defer recordCall(calls, "DeviceGetMemClkMinMaxVfOffset", time.Now())
+			return f(a0)
+		}
+	}
+	{
+		f := nvml.DeviceGetMemClkVfOffset
+		nvml.DeviceGetMemClkVfOffset = func(a0 nvml.Device) (int, nvml.Return) {
+			defer recordCall(calls, "DeviceGetMemClkVfOffset", time.Now())
+			return f(a0)
+		}
+	}
+	{
+		f := nvml.DeviceGetMemoryAffinity
+		nvml.DeviceGetMemoryAffinity = func(a0 nvml.Device, a1 int, a2 nvml.AffinityScope) ([]uint, nvml.Return) {
+			defer recordCall(calls, "DeviceGetMemoryAffinity", time.Now())
+			return f(a0, a1, a2)
+		}
+	}
+	{
+		f := nvml.DeviceGetMemoryBusWidth
+		nvml.DeviceGetMemoryBusWidth = func(a0 nvml.Device) (uint32, nvml.Return) {
+			defer recordCall(calls, "DeviceGetMemoryBusWidth", time.Now())
+			return f(a0)
+		}
+	}
+	{
+		f := nvml.DeviceGetMemoryErrorCounter
+		nvml.DeviceGetMemoryErrorCounter = func(a0 nvml.Device, a1 nvml.MemoryErrorType, a2 nvml.EccCounterType, a3 nvml.MemoryLocation) (uint64, nvml.Return) {
+			defer recordCall(calls, "DeviceGetMemoryErrorCounter", time.Now())
+			return f(a0, a1, a2, a3)
+		}
+	}
+	{
+		f := nvml.DeviceGetMemoryInfo
+		nvml.DeviceGetMemoryInfo = func(a0 nvml.Device) (nvml.Memory, nvml.Return) {
+			defer recordCall(calls, "DeviceGetMemoryInfo", time.Now())
+			return f(a0)
+		}
+	}
+	{
+		f := nvml.DeviceGetMemoryInfo_v2
+		nvml.DeviceGetMemoryInfo_v2 = func(a0 nvml.Device) (nvml.Memory_v2, nvml.Return) {
+			defer recordCall(calls, "DeviceGetMemoryInfo_v2", time.Now())
+			return f(a0)
+		}
+	}
+	{
+		f := nvml.DeviceGetMemoryLimits_v1
+		nvml.DeviceGetMemoryLimits_v1 = func(a0 nvml.Device, a1 string) (nvml.MemoryLimits_v1, nvml.Return) {
+			defer recordCall(calls, "DeviceGetMemoryLimits_v1", time.Now())
+			return f(a0, a1)
+		}
+	}
+	{
+		f := nvml.DeviceGetMigDeviceHandleByIndex
+		nvml.DeviceGetMigDeviceHandleByIndex = func(a0 nvml.Device, a1 int) (nvml.Device, nvml.Return) {
+			start := time.Now()
+			r0, r1 := f(a0, a1)
+			recordCall(calls, "DeviceGetMigDeviceHandleByIndex", start)
+			if r0 != nil {
+				r0 = timedDevice{Device: r0, calls: calls}
+			}
+			return r0, r1
+		}
+	}
+	{
+		f := nvml.DeviceGetMigMode
+		nvml.DeviceGetMigMode = func(a0 nvml.Device) (int, int, nvml.Return) {
+			defer recordCall(calls, "DeviceGetMigMode", time.Now())
+			return f(a0)
+		}
+	}
+	{
+		f := nvml.DeviceGetMinMaxClockOfPState
+		nvml.DeviceGetMinMaxClockOfPState = func(a0 nvml.Device, a1 nvml.ClockType, a2 nvml.Pstates) (uint32, uint32, nvml.Return) {
+			defer recordCall(calls, "DeviceGetMinMaxClockOfPState", time.Now())
+			return f(a0, a1, a2)
+		}
+	}
+	{
+		f := nvml.DeviceGetMinMaxFanSpeed
+		nvml.DeviceGetMinMaxFanSpeed = func(a0 nvml.Device) (int, int, nvml.Return) {
+			defer recordCall(calls, "DeviceGetMinMaxFanSpeed", time.Now())
+			return f(a0)
+		}
+	}
+	{
+		f := nvml.DeviceGetMinorNumber
+		nvml.DeviceGetMinorNumber = func(a0 nvml.Device) (int, nvml.Return) {
+			defer recordCall(calls, "DeviceGetMinorNumber", time.Now())
+			return f(a0)
+		}
+	}
+	{
+		f := nvml.DeviceGetModuleId
+		nvml.DeviceGetModuleId = func(a0 nvml.Device) (int, nvml.Return) {
+			defer recordCall(calls, "DeviceGetModuleId", time.Now())
+			return f(a0)
+		}
+	}
+	{
+		f := nvml.DeviceGetMultiGpuBoard
+		nvml.DeviceGetMultiGpuBoard = func(a0 nvml.Device) (int, nvml.Return) {
+			defer recordCall(calls, "DeviceGetMultiGpuBoard", time.Now())
+			return f(a0)
+		}
+	}
+	{
+		f := nvml.DeviceGetName
+		nvml.DeviceGetName = func(a0 nvml.Device) (string, nvml.Return) {
+			defer recordCall(calls, "DeviceGetName", time.Now())
+			return f(a0)
+		}
+	}
+	{
+		f := nvml.DeviceGetNumFans
+		nvml.DeviceGetNumFans = func(a0 nvml.Device) (int, nvml.Return) {
+			defer recordCall(calls, "DeviceGetNumFans", time.Now())
+			return f(a0)
+		}
+	}
+	{
+		f := nvml.DeviceGetNumGpuCores
+		nvml.DeviceGetNumGpuCores = func(a0 nvml.Device) (int, nvml.Return) {
+			defer recordCall(calls, "DeviceGetNumGpuCores", time.Now())
+			return f(a0)
+		}
+	}
+	{
+		f := nvml.DeviceGetNumaNodeId
+		nvml.DeviceGetNumaNodeId = func(a0 nvml.Device) (int, nvml.Return) {
+			defer recordCall(calls, "DeviceGetNumaNodeId", time.Now())
+			return f(a0)
+		}
+	}
+	{
+		f := nvml.DeviceGetNvLinkCapability
+		nvml.DeviceGetNvLinkCapability = func(a0 nvml.Device, a1 int, a2 nvml.NvLinkCapability) (uint32, nvml.Return) {
+			defer recordCall(calls, "DeviceGetNvLinkCapability", time.Now())
+			return f(a0, a1, a2)
+		}
+	}
+	{
+		f := nvml.DeviceGetNvLinkErrorCounter
+		nvml.DeviceGetNvLinkErrorCounter = func(a0 nvml.Device, a1 int, a2 nvml.NvLinkErrorCounter) (uint64, nvml.Return) {
+			defer recordCall(calls, "DeviceGetNvLinkErrorCounter", time.Now())
+			return f(a0, a1, a2)
+		}
+	}
+	{
+		f := nvml.DeviceGetNvLinkInfo
+		nvml.DeviceGetNvLinkInfo = func(a0 nvml.Device) nvml.NvLinkInfoHandler {
+			defer recordCall(calls, "DeviceGetNvLinkInfo", time.Now())
+			return f(a0)
+		}
+	}
+	{
+		f := nvml.DeviceGetNvLinkRemoteDeviceType
+		nvml.DeviceGetNvLinkRemoteDeviceType = func(a0 nvml.Device, a1 int) (nvml.IntNvLinkDeviceType, nvml.Return) {
+			defer recordCall(calls, "DeviceGetNvLinkRemoteDeviceType", time.Now())
+			return f(a0, a1)
+		}
+	}
+	{
+		f := nvml.DeviceGetNvLinkRemotePciInfo
+		nvml.DeviceGetNvLinkRemotePciInfo = func(a0 nvml.Device, a1 int) (nvml.PciInfo, nvml.Return) {
+			defer recordCall(calls, "DeviceGetNvLinkRemotePciInfo", time.Now())
+			return f(a0, a1)
+		}
+	}
+	{
+		f := nvml.DeviceGetNvLinkState
+		nvml.DeviceGetNvLinkState = func(a0 nvml.Device, a1 int) (nvml.EnableState, nvml.Return) {
+			defer recordCall(calls, "DeviceGetNvLinkState", time.Now())
+			return f(a0, a1)
+		}
+	}
+	{
+		f := nvml.DeviceGetNvLinkTelemetrySamples_v1
+		nvml.DeviceGetNvLinkTelemetrySamples_v1 = func(a0 nvml.Device, a1 nvml.NvlinkTelemetrySamples_v1) (nvml.NvlinkTelemetrySamples_v1, nvml.Return) {
+			defer recordCall(calls, "DeviceGetNvLinkTelemetrySamples_v1", time.Now())
+			return f(a0, a1)
+		}
+	}
+	{
+		f := nvml.DeviceGetNvLinkUtilizationControl
+		nvml.DeviceGetNvLinkUtilizationControl = func(a0 nvml.Device, a1 int, a2 int) (nvml.NvLinkUtilizationControl, nvml.Return) {
+			defer recordCall(calls, "DeviceGetNvLinkUtilizationControl", time.Now())
+			return f(a0, a1, a2)
+		}
+	}
+	{
+		f := nvml.DeviceGetNvLinkUtilizationCounter
+		nvml.DeviceGetNvLinkUtilizationCounter = func(a0 nvml.Device, a1 int, a2 int) (uint64, uint64, nvml.Return) {
+			defer recordCall(calls, "DeviceGetNvLinkUtilizationCounter", time.Now())
+			return f(a0, a1, a2)
+		}
+	}
+	{
+		f := nvml.DeviceGetNvLinkVersion
+		nvml.DeviceGetNvLinkVersion = func(a0 nvml.Device, a1 int) (uint32, nvml.Return) {
+			defer recordCall(calls, "DeviceGetNvLinkVersion", time.Now())
+			return f(a0, a1)
+		}
+	}
+	{
+		f := nvml.DeviceGetNvlinkBwMode
+		nvml.DeviceGetNvlinkBwMode = func(a0 nvml.Device) (nvml.NvlinkGetBwMode, nvml.Return) {
+			defer recordCall(calls, "DeviceGetNvlinkBwMode", time.Now())
+			return f(a0)
+		}
+	}
+	{
+		f := nvml.DeviceGetNvlinkSupportedBwModes
+		nvml.DeviceGetNvlinkSupportedBwModes = func(a0 nvml.Device) (nvml.NvlinkSupportedBwModes, nvml.Return) {
+			defer recordCall(calls, "DeviceGetNvlinkSupportedBwModes", time.Now())
+			return f(a0)
+		}
+	}
+	{
+		f := nvml.DeviceGetOfaUtilization
+		nvml.DeviceGetOfaUtilization = func(a0 nvml.Device) (uint32, uint32, nvml.Return) {
+			defer recordCall(calls, "DeviceGetOfaUtilization", time.Now())
+			return f(a0)
+		}
+	}
+	{
+		f := nvml.DeviceGetP2PStatus
+		nvml.DeviceGetP2PStatus = func(a0 nvml.Device, a1 nvml.Device, a2 nvml.GpuP2PCapsIndex) (nvml.GpuP2PStatus, nvml.Return) {
+			defer recordCall(calls, "DeviceGetP2PStatus", time.Now())
+			return f(a0, a1, a2)
+		}
+	}
+	{
+		f := nvml.DeviceGetPciInfo
+		nvml.DeviceGetPciInfo = func(a0 nvml.Device) (nvml.PciInfo, nvml.Return) {
+			defer recordCall(calls, "DeviceGetPciInfo", time.Now())
+			return f(a0)
+		}
+	}
+	{
+		f := nvml.DeviceGetPciInfoExt
+		nvml.DeviceGetPciInfoExt = func(a0 nvml.Device) (nvml.PciInfoExt, nvml.Return) {
+			defer recordCall(calls, "DeviceGetPciInfoExt", time.Now())
+			return f(a0)
+		}
+	}
+	{
+		f := nvml.DeviceGetPcieLinkMaxSpeed
+		nvml.DeviceGetPcieLinkMaxSpeed = func(a0 nvml.Device) (uint32, nvml.Return) {
+			defer recordCall(calls, "DeviceGetPcieLinkMaxSpeed", time.Now())
+			return f(a0)
+		}
+	}
+	{
+		f := nvml.DeviceGetPcieReplayCounter
+		nvml.DeviceGetPcieReplayCounter = func(a0 nvml.Device) (int, nvml.Return) {
+			defer recordCall(calls, "DeviceGetPcieReplayCounter", time.Now())
+			return f(a0)
+		}
+	}
+	{
+		f := nvml.DeviceGetPcieSpeed
+		nvml.DeviceGetPcieSpeed = func(a0 nvml.Device) (int, nvml.Return) {
+			defer recordCall(calls, "DeviceGetPcieSpeed", time.Now())
+			return f(a0)
+		}
+	}
+	{
+		f := nvml.DeviceGetPcieThroughput
+		nvml.DeviceGetPcieThroughput = func(a0 nvml.Device, a1 nvml.PcieUtilCounter) (uint32, nvml.Return) {
+			defer recordCall(calls, "DeviceGetPcieThroughput", time.Now())
+			return f(a0, a1)
+		}
+	}
+	{
+		f := nvml.DeviceGetPdi
+		nvml.DeviceGetPdi = func(a0 nvml.Device) (nvml.Pdi, nvml.Return) {
+			defer recordCall(calls, "DeviceGetPdi", time.Now())
+			return f(a0)
+		}
+	}
+	{
+		f := nvml.DeviceGetPerformanceModes
+		nvml.DeviceGetPerformanceModes = func(a0 nvml.Device) (nvml.DevicePerfModes, nvml.Return) {
+			defer recordCall(calls, "DeviceGetPerformanceModes", time.Now())
+			return f(a0)
+		}
+	}
+	{
+		f := nvml.DeviceGetPerformanceState
+		nvml.DeviceGetPerformanceState = func(a0 nvml.Device) (nvml.Pstates, nvml.Return) {
+			defer recordCall(calls, "DeviceGetPerformanceState", time.Now())
+			return f(a0)
+		}
+	}
+	{
+		f := nvml.DeviceGetPersistenceMode
+		nvml.DeviceGetPersistenceMode = func(a0 nvml.Device) (nvml.EnableState, nvml.Return) {
+			defer recordCall(calls, "DeviceGetPersistenceMode", time.Now())
+			return f(a0)
+		}
+	}
+	{
+		f := nvml.DeviceGetPgpuMetadataString
+		nvml.DeviceGetPgpuMetadataString = func(a0 nvml.Device) (string, nvml.Return) {
+			defer recordCall(calls, "DeviceGetPgpuMetadataString", time.Now())
+			return f(a0)
+		}
+	}
+	{
+		f := nvml.DeviceGetPlatformInfo
+		nvml.DeviceGetPlatformInfo = func(a0 nvml.Device) (nvml.PlatformInfo, nvml.Return) {
+			defer recordCall(calls, "DeviceGetPlatformInfo", time.Now())
+			return f(a0)
+		}
+	}
+	{
+		f := nvml.DeviceGetPowerManagementDefaultLimit
+		nvml.DeviceGetPowerManagementDefaultLimit = func(a0 nvml.Device) (uint32, nvml.Return) {
+			defer recordCall(calls, "DeviceGetPowerManagementDefaultLimit", time.Now())
+			return f(a0)
+		}
+	}
+	{
+		f := nvml.DeviceGetPowerManagementLimit
+		nvml.DeviceGetPowerManagementLimit = func(a0 nvml.Device) (uint32, nvml.Return) {
+			defer recordCall(calls, "DeviceGetPowerManagementLimit", time.Now())
+			return f(a0)
+		}
+	}
+	{
+		f := nvml.DeviceGetPowerManagementLimitConstraints
+		nvml.DeviceGetPowerManagementLimitConstraints = func(a0 nvml.Device) (uint32, uint32, nvml.Return) {
+			defer recordCall(calls, "DeviceGetPowerManagementLimitConstraints", time.Now())
+			return f(a0)
+		}
+	}
+	{
+		f := nvml.DeviceGetPowerManagementMode
+		nvml.DeviceGetPowerManagementMode = func(a0 nvml.Device) (nvml.EnableState, nvml.Return) {
+			defer recordCall(calls, "DeviceGetPowerManagementMode", time.Now())
+			return f(a0)
+		}
+	}
+	{
+		f := nvml.DeviceGetPowerMizerMode_v1
+		nvml.DeviceGetPowerMizerMode_v1 = func(a0 nvml.Device) (nvml.DevicePowerMizerModes_v1, nvml.Return) {
+			defer recordCall(calls, "DeviceGetPowerMizerMode_v1", time.Now())
+			return f(a0)
+		}
+	}
+	{
+		f := nvml.DeviceGetPowerSource
+		nvml.DeviceGetPowerSource = func(a0 nvml.Device) (nvml.PowerSource, nvml.Return) {
+			defer recordCall(calls, "DeviceGetPowerSource", time.Now())
+			return f(a0)
+		}
+	}
+	{
+		f := nvml.DeviceGetPowerState
+		nvml.DeviceGetPowerState = func(a0 nvml.Device) (nvml.Pstates, nvml.Return) {
+			defer recordCall(calls, "DeviceGetPowerState", time.Now())
+			return f(a0)
+		}
+	}
+	{
+		f := nvml.DeviceGetPowerUsage
+		nvml.DeviceGetPowerUsage = func(a0 nvml.Device) (uint32, nvml.Return) {
+			defer recordCall(calls, "DeviceGetPowerUsage", time.Now())
+			return f(a0)
+		}
+	}
+	{
+		f := nvml.DeviceGetProcessUtilization
+		nvml.DeviceGetProcessUtilization = func(a0 nvml.Device, a1 uint64) ([]nvml.ProcessUtilizationSample, nvml.Return) {
+			defer recordCall(calls, "DeviceGetProcessUtilization", time.Now())
+			return f(a0, a1)
+		}
+	}
+	{
+		f := nvml.DeviceGetProcessesUtilizationInfo
+		nvml.DeviceGetProcessesUtilizationInfo = func(a0 nvml.Device) (nvml.ProcessesUtilizationInfo, nvml.Return) {
+			defer recordCall(calls, "DeviceGetProcessesUtilizationInfo", time.Now())
+			return f(a0)
+		}
+	}
+	{
+		f := nvml.DeviceGetRemappedRows
+		nvml.DeviceGetRemappedRows = func(a0 nvml.Device) (int, int, bool, bool, nvml.Return) {
+			defer recordCall(calls, "DeviceGetRemappedRows", time.Now())
+			return f(a0)
+		}
+	}
+	{
+		f := nvml.DeviceGetRemappedRows_v2
+		nvml.DeviceGetRemappedRows_v2 = func(a0 nvml.Device) (nvml.RemappedRowsInfo_v2, nvml.Return) {
+			defer recordCall(calls, "DeviceGetRemappedRows_v2", time.Now())
+			return f(a0)
+		}
+	}
+	{
+		f := nvml.DeviceGetRepairStatus
+		nvml.DeviceGetRepairStatus = func(a0 nvml.Device) (nvml.RepairStatus, nvml.Return) {
+			defer recordCall(calls, "DeviceGetRepairStatus", time.Now())
+			return f(a0)
+		}
+	}
+	{
+		f := nvml.DeviceGetRetiredPages
+		nvml.DeviceGetRetiredPages = func(a0 nvml.Device, a1 nvml.PageRetirementCause) ([]uint64, nvml.Return) {
+			defer recordCall(calls, "DeviceGetRetiredPages", time.Now())
+			return f(a0, a1)
+		}
+	}
+	{
+		f := nvml.DeviceGetRetiredPagesPendingStatus
+		nvml.DeviceGetRetiredPagesPendingStatus = func(a0 nvml.Device) (nvml.EnableState, nvml.Return) {
+			defer recordCall(calls, "DeviceGetRetiredPagesPendingStatus", time.Now())
+			return f(a0)
+		}
+	}
+	{
+		f := nvml.DeviceGetRetiredPages_v2
+		nvml.DeviceGetRetiredPages_v2 = func(a0 nvml.Device, a1 nvml.PageRetirementCause) ([]uint64, []uint64, nvml.Return) {
+			defer recordCall(calls, "DeviceGetRetiredPages_v2", time.Now())
+			return f(a0, a1)
+		}
+	}
+	{
+		f := nvml.DeviceGetRowRemapperHistogram
+		nvml.DeviceGetRowRemapperHistogram = func(a0 nvml.Device) (nvml.RowRemapperHistogramValues, nvml.Return) {
+			defer recordCall(calls, "DeviceGetRowRemapperHistogram", time.Now())
+			return f(a0)
+		}
+	}
+	{
+		f := nvml.DeviceGetRunningProcessDetailList
+		nvml.DeviceGetRunningProcessDetailList = func(a0 nvml.Device) (nvml.ProcessDetailList, nvml.Return) {
+			defer recordCall(calls, "DeviceGetRunningProcessDetailList", time.Now())
+			return f(a0)
+		}
+	}
+	{
+		f := nvml.DeviceGetSamples
+		nvml.DeviceGetSamples = func(a0 nvml.Device, a1 nvml.SamplingType, a2 uint64) (nvml.ValueType, []nvml.Sample, nvml.Return) {
+			defer recordCall(calls, "DeviceGetSamples", time.Now())
+			return f(a0, a1, a2)
+		}
+	}
+	{
+		f := nvml.DeviceGetSerial
+		nvml.DeviceGetSerial = func(a0 nvml.Device) (string, nvml.Return) {
+			defer recordCall(calls, "DeviceGetSerial", time.Now())
+			return f(a0)
+		}
+	}
+	{
+		f := nvml.DeviceGetSramEccErrorStatus
+		nvml.DeviceGetSramEccErrorStatus = func(a0 nvml.Device) (nvml.EccSramErrorStatus, nvml.Return) {
+			defer recordCall(calls, "DeviceGetSramEccErrorStatus", time.Now())
+			return f(a0)
+		}
+	}
+	{
+		f := nvml.DeviceGetSramUniqueUncorrectedEccErrorCounts
+		nvml.DeviceGetSramUniqueUncorrectedEccErrorCounts = func(a0 nvml.Device, a1 *nvml.EccSramUniqueUncorrectedErrorCounts) nvml.Return {
+			defer recordCall(calls, "DeviceGetSramUniqueUncorrectedEccErrorCounts", time.Now())
+			return f(a0, a1)
+		}
+	}
+	{
+		f := nvml.DeviceGetSupportedClocksEventReasons
+		nvml.DeviceGetSupportedClocksEventReasons = func(a0 nvml.Device) (uint64, nvml.Return) {
+			defer recordCall(calls, "DeviceGetSupportedClocksEventReasons", time.Now())
+			return f(a0)
+		}
+	}
+	{
+		f := nvml.DeviceGetSupportedClocksThrottleReasons
+		nvml.DeviceGetSupportedClocksThrottleReasons = func(a0 nvml.Device) (uint64, nvml.Return) {
+			defer recordCall(calls, "DeviceGetSupportedClocksThrottleReasons", time.Now())
+			return f(a0)
+		}
+	}
+	{
+		f := nvml.DeviceGetSupportedEventTypes
+		nvml.DeviceGetSupportedEventTypes = func(a0 nvml.Device) (uint64, nvml.Return) {
+			defer recordCall(calls, "DeviceGetSupportedEventTypes", time.Now())
+			return f(a0)
+		}
+	}
+	{
+		f := nvml.DeviceGetSupportedGraphicsClocks
+		nvml.DeviceGetSupportedGraphicsClocks = func(a0 nvml.Device, a1 int) (int, uint32, nvml.Return) {
+			defer recordCall(calls, "DeviceGetSupportedGraphicsClocks", time.Now())
+			return f(a0, a1)
+		}
+	}
+	{
+		f := nvml.DeviceGetSupportedMemoryClocks
+		nvml.DeviceGetSupportedMemoryClocks = func(a0 nvml.Device) (int, uint32, nvml.Return) {
+			defer recordCall(calls, "DeviceGetSupportedMemoryClocks", time.Now())
+			return f(a0)
+		}
+	}
+	{
+		f := nvml.DeviceGetSupportedPerformanceStates
+		nvml.DeviceGetSupportedPerformanceStates = func(a0 nvml.Device) ([]nvml.Pstates, nvml.Return) {
+			defer recordCall(calls, "DeviceGetSupportedPerformanceStates", time.Now())
+			return f(a0)
+		}
+	}
+	{
+		f := nvml.DeviceGetSupportedVgpus
+		nvml.DeviceGetSupportedVgpus = func(a0 nvml.Device) ([]nvml.VgpuTypeId, nvml.Return) {
+			defer recordCall(calls, "DeviceGetSupportedVgpus", time.Now())
+			return f(a0)
+		}
+	}
+	{
+		f := nvml.DeviceGetTargetFanSpeed
+		nvml.DeviceGetTargetFanSpeed = func(a0 nvml.Device, a1 int) (int, nvml.Return) {
+			defer recordCall(calls, "DeviceGetTargetFanSpeed", time.Now())
+			return f(a0, a1)
+		}
+	}
+	{
+		f := nvml.DeviceGetTemperature
+		nvml.DeviceGetTemperature = func(a0 nvml.Device, a1 nvml.TemperatureSensors) (uint32, nvml.Return) {
+			defer recordCall(calls, "DeviceGetTemperature", time.Now())
+			return f(a0, a1)
+		}
+	}
+	{
+		f := nvml.DeviceGetTemperatureThreshold
+		nvml.DeviceGetTemperatureThreshold = func(a0 nvml.Device, a1 nvml.TemperatureThresholds) (uint32, nvml.Return) {
+			defer recordCall(calls, "DeviceGetTemperatureThreshold", time.Now())
+			return f(a0, a1)
+		}
+	}
+	{
+		f := nvml.DeviceGetTemperatureV
+		nvml.DeviceGetTemperatureV = func(a0 nvml.Device) nvml.TemperatureHandler {
+			defer recordCall(calls, "DeviceGetTemperatureV", time.Now())
+			return f(a0)
+		}
+	}
+	{
+		f := nvml.DeviceGetThermalSettings
+		nvml.DeviceGetThermalSettings = func(a0 nvml.Device, a1 uint32) (nvml.GpuThermalSettings, nvml.Return) {
+			defer recordCall(calls, "DeviceGetThermalSettings", time.Now())
+			return f(a0, a1)
+		}
+	}
+	{
+		f := nvml.DeviceGetTopologyCommonAncestor
+		nvml.DeviceGetTopologyCommonAncestor = func(a0 nvml.Device, a1 nvml.Device) (nvml.GpuTopologyLevel, nvml.Return) {
+			defer recordCall(calls, "DeviceGetTopologyCommonAncestor", time.Now())
+			return f(a0, a1)
+		}
+	}
+	{
+		f := nvml.DeviceGetTopologyNearestGpus
+		nvml.DeviceGetTopologyNearestGpus = func(a0 nvml.Device, a1 nvml.GpuTopologyLevel) ([]nvml.Device, nvml.Return) {
+			defer recordCall(calls, "DeviceGetTopologyNearestGpus", time.Now())
+			return f(a0, a1)
+		}
+	}
+	{
+		f := nvml.DeviceGetTotalEccErrors
+		nvml.DeviceGetTotalEccErrors = func(a0 nvml.Device, a1 nvml.MemoryErrorType, a2 nvml.EccCounterType) (uint64, nvml.Return) {
+			defer recordCall(calls, "DeviceGetTotalEccErrors", time.Now())
+			return f(a0, a1, a2)
+		}
+	}
+	{
+		f := nvml.DeviceGetTotalEnergyConsumption
+		nvml.DeviceGetTotalEnergyConsumption = func(a0 nvml.Device) (uint64, nvml.Return) {
+			defer recordCall(calls, "DeviceGetTotalEnergyConsumption", time.Now())
+			return f(a0)
+		}
+	}
+	{
+		f := nvml.DeviceGetUUID
+		nvml.DeviceGetUUID = func(a0 nvml.Device) (string, nvml.Return) {
+			defer recordCall(calls, "DeviceGetUUID", time.Now())
+			return f(a0)
+		}
+	}
+	{
+		f := nvml.DeviceGetUnrepairableMemoryFlag_v1
+		nvml.DeviceGetUnrepairableMemoryFlag_v1 = func(a0 nvml.Device) (nvml.UnrepairableMemoryStatus_v1, nvml.Return) {
+			defer recordCall(calls, "DeviceGetUnrepairableMemoryFlag_v1", time.Now())
+			return f(a0)
+		}
+	}
+	{
+		f := nvml.DeviceGetUtilizationRates
+		nvml.DeviceGetUtilizationRates = func(a0 nvml.Device) (nvml.Utilization, nvml.Return) {
+			defer recordCall(calls, "DeviceGetUtilizationRates", time.Now())
+			return f(a0)
+		}
+	}
+	{
+		f := nvml.DeviceGetVbiosVersion
+		nvml.DeviceGetVbiosVersion = func(a0 nvml.Device) (string, nvml.Return) {
+			defer recordCall(calls, "DeviceGetVbiosVersion", time.Now())
+			return f(a0)
+		}
+	}
+	{
+		f := nvml.DeviceGetVgpuCapabilities
+		nvml.DeviceGetVgpuCapabilities = func(a0 nvml.Device, a1 nvml.DeviceVgpuCapability) (bool, nvml.Return) {
+			defer recordCall(calls, "DeviceGetVgpuCapabilities", time.Now())
+			return f(a0, a1)
+		}
+	}
+	{
+		f := nvml.DeviceGetVgpuHeterogeneousMode
+		nvml.DeviceGetVgpuHeterogeneousMode = func(a0 nvml.Device) (nvml.VgpuHeterogeneousMode, nvml.Return) {
+			defer recordCall(calls, "DeviceGetVgpuHeterogeneousMode", time.Now())
+			return f(a0)
+		}
+	}
+	{
+		f := nvml.DeviceGetVgpuInstancesUtilizationInfo
+		nvml.DeviceGetVgpuInstancesUtilizationInfo = func(a0 nvml.Device) (nvml.VgpuInstancesUtilizationInfo, nvml.Return) {
+			defer recordCall(calls, "DeviceGetVgpuInstancesUtilizationInfo", time.Now())
+			return f(a0)
+		}
+	}
+	{
+		f := nvml.DeviceGetVgpuMetadata
+		nvml.DeviceGetVgpuMetadata = func(a0 nvml.Device) (nvml.VgpuPgpuMetadata, nvml.Return) {
+			defer recordCall(calls, "DeviceGetVgpuMetadata", time.Now())
+			return f(a0)
+		}
+	}
+	{
+		f := nvml.DeviceGetVgpuProcessUtilization
+		nvml.DeviceGetVgpuProcessUtilization = func(a0 nvml.Device, a1 uint64) ([]nvml.VgpuProcessUtilizationSample, nvml.Return) {
+			defer recordCall(calls, "DeviceGetVgpuProcessUtilization", time.Now())
+			return f(a0, a1)
+		}
+	}
+	{
+		f := nvml.DeviceGetVgpuProcessesUtilizationInfo
+		nvml.DeviceGetVgpuProcessesUtilizationInfo = func(a0 nvml.Device) (nvml.VgpuProcessesUtilizationInfo, nvml.Return) {
+			defer recordCall(calls, "DeviceGetVgpuProcessesUtilizationInfo", time.Now())
+			return f(a0)
+		}
+	}
+	{
+		f := nvml.DeviceGetVgpuSchedulerCapabilities
+		nvml.DeviceGetVgpuSchedulerCapabilities = func(a0 nvml.Device) (nvml.VgpuSchedulerCapabilities, nvml.Return) {
+			defer recordCall(calls, "DeviceGetVgpuSchedulerCapabilities", time.Now())
+			return f(a0)
+		}
+	}
+	{
+		f := nvml.DeviceGetVgpuSchedulerLog
+		nvml.DeviceGetVgpuSchedulerLog = func(a0 nvml.Device) (nvml.VgpuSchedulerLog, nvml.Return) {
+			defer recordCall(calls, "DeviceGetVgpuSchedulerLog", time.Now())
+			return f(a0)
+		}
+	}
+	{
+		f := nvml.DeviceGetVgpuSchedulerLog_v2
+		nvml.DeviceGetVgpuSchedulerLog_v2 = func(a0 nvml.Device, a1 nvml.VgpuSchedulerLogInfo_v2) (nvml.VgpuSchedulerLogInfo_v2, nvml.Return) {
+			defer recordCall(calls, "DeviceGetVgpuSchedulerLog_v2", time.Now())
+			return f(a0, a1)
+		}
+	}
+	{
+		f := nvml.DeviceGetVgpuSchedulerState
+		nvml.DeviceGetVgpuSchedulerState = func(a0 nvml.Device) (nvml.VgpuSchedulerGetState, nvml.Return) {
+			defer recordCall(calls, "DeviceGetVgpuSchedulerState", time.Now())
+			return f(a0)
+		}
+	}
+	{
+		f := nvml.DeviceGetVgpuSchedulerState_v2
+		nvml.DeviceGetVgpuSchedulerState_v2 = func(a0 nvml.Device, a1 nvml.VgpuSchedulerStateInfo_v2) (nvml.VgpuSchedulerStateInfo_v2, nvml.Return) {
+			defer recordCall(calls, "DeviceGetVgpuSchedulerState_v2", time.Now())
+			return f(a0, a1)
+		}
+	}
+	{
+		f := nvml.DeviceGetVgpuTypeCreatablePlacements
+		nvml.DeviceGetVgpuTypeCreatablePlacements = func(a0 nvml.Device, a1 nvml.VgpuTypeId) (nvml.VgpuPlacementList, nvml.Return) {
+			defer recordCall(calls, "DeviceGetVgpuTypeCreatablePlacements", time.Now())
+			return f(a0, a1)
+		}
+	}
+	{
+		f := nvml.DeviceGetVgpuTypeSupportedPlacements
+		nvml.DeviceGetVgpuTypeSupportedPlacements = func(a0 nvml.Device, a1 nvml.VgpuTypeId) (nvml.VgpuPlacementList, nvml.Return) {
+			defer recordCall(calls, "DeviceGetVgpuTypeSupportedPlacements", time.Now())
+			return f(a0, a1)
+		}
+	}
+	{
+		f := nvml.DeviceGetVgpuUtilization
+		nvml.DeviceGetVgpuUtilization = func(a0 nvml.Device, a1 uint64) (nvml.ValueType, []nvml.VgpuInstanceUtilizationSample, nvml.Return) {
+			defer recordCall(calls, "DeviceGetVgpuUtilization", time.Now())
+			return f(a0, a1)
+		}
+	}
+	{
+		f := nvml.DeviceGetViolationStatus
+		nvml.DeviceGetViolationStatus = func(a0 nvml.Device, a1 nvml.PerfPolicyType) (nvml.ViolationTime, nvml.Return) {
+			defer recordCall(calls, "DeviceGetViolationStatus", time.Now())
+			return f(a0, a1)
+		}
+	}
+	{
+		f := nvml.DeviceGetVirtualizationMode
+		nvml.DeviceGetVirtualizationMode = func(a0 nvml.Device) (nvml.GpuVirtualizationMode, nvml.Return) {
+			defer recordCall(calls, "DeviceGetVirtualizationMode", time.Now())
+			return f(a0)
+		}
+	}
+	{
+		f := nvml.DeviceIsMigDeviceHandle
+		nvml.DeviceIsMigDeviceHandle = func(a0 nvml.Device) (bool, nvml.Return) {
+			defer recordCall(calls, "DeviceIsMigDeviceHandle", time.Now())
+			return f(a0)
+		}
+	}
+	{
+		f := nvml.DeviceModifyDrainState
+		nvml.DeviceModifyDrainState = func(a0 *nvml.PciInfo, a1 nvml.EnableState) nvml.Return {
+			defer recordCall(calls, "DeviceModifyDrainState", time.Now())
+			return f(a0, a1)
+		}
+	}
+	{
+		f := nvml.DeviceOnSameBoard
+		nvml.DeviceOnSameBoard = func(a0 nvml.Device, a1 nvml.Device) (int, nvml.Return) {
+			defer recordCall(calls, "DeviceOnSameBoard", time.Now())
+			return f(a0, a1)
+		}
+	}
+	{
+		f := nvml.DevicePerfMetricsGetSamples_v1
+		nvml.DevicePerfMetricsGetSamples_v1 = func(a0 nvml.Device) (nvml.PerfMetricsSamples_v1, nvml.Return) {
+			defer recordCall(calls, "DevicePerfMetricsGetSamples_v1", time.Now())
+			return f(a0)
+		}
+	}
+	{
+		f := nvml.DevicePowerSmoothingActivatePresetProfile
+		nvml.DevicePowerSmoothingActivatePresetProfile = func(a0 nvml.Device, a1 *nvml.PowerSmoothingProfile) nvml.Return {
+			defer recordCall(calls, "DevicePowerSmoothingActivatePresetProfile", time.Now())
+			return f(a0, a1)
+		}
+	}
+	{
+		f := nvml.DevicePowerSmoothingSetState
+		nvml.DevicePowerSmoothingSetState = func(a0 nvml.Device, a1 *nvml.PowerSmoothingState) nvml.Return {
+			defer recordCall(calls, "DevicePowerSmoothingSetState", time.Now())
+			return f(a0, a1)
+		}
+	}
+	{
+		f := nvml.DevicePowerSmoothingUpdatePresetProfileParam
+		nvml.DevicePowerSmoothingUpdatePresetProfileParam = func(a0 nvml.Device, a1 *nvml.PowerSmoothingProfile) nvml.Return {
+			defer recordCall(calls, "DevicePowerSmoothingUpdatePresetProfileParam", time.Now())
+			return f(a0, a1)
+		}
+	}
+	{
+		f := nvml.DeviceQueryDrainState
+		nvml.DeviceQueryDrainState = func(a0 *nvml.PciInfo) (nvml.EnableState, nvml.Return) {
+			defer recordCall(calls, "DeviceQueryDrainState", time.Now())
+			return f(a0)
+		}
+	}
+	{
+		f := nvml.DeviceReadPRMCounters_v1
+		nvml.DeviceReadPRMCounters_v1 = func(a0 nvml.Device, a1 []nvml.PRMCounterId, a2 int) ([]nvml.PRMCounter_v1, nvml.Return) {
+			defer recordCall(calls, "DeviceReadPRMCounters_v1", time.Now())
+			return f(a0, a1, a2)
+		}
+	}
+	{
+		f := nvml.DeviceReadWritePRM_v1
+		nvml.DeviceReadWritePRM_v1 = func(a0 nvml.Device, a1 *nvml.PRMTLV_v1) nvml.Return {
+			defer recordCall(calls, "DeviceReadWritePRM_v1", time.Now())
+			return f(a0, a1)
+		}
+	}
+	{
+		f := nvml.DeviceRegisterEvents
+		nvml.DeviceRegisterEvents = func(a0 nvml.Device, a1 uint64, a2 nvml.EventSet) nvml.Return {
+			defer recordCall(calls, "DeviceRegisterEvents", time.Now())
+			return f(a0, a1, a2)
+		}
+	}
+	{
+		f := nvml.DeviceRemoveGpu
+		nvml.DeviceRemoveGpu = func(a0 *nvml.PciInfo) nvml.Return {
+			defer recordCall(calls, "DeviceRemoveGpu", time.Now())
+			return f(a0)
+		}
+	}
+	{
+		f := nvml.DeviceRemoveGpu_v2
+		nvml.DeviceRemoveGpu_v2 = func(a0 *nvml.PciInfo, a1 nvml.DetachGpuState, a2 nvml.PcieLinkState) nvml.Return {
+			defer recordCall(calls, "DeviceRemoveGpu_v2", time.Now())
+			return f(a0, a1, a2)
+		}
+	}
+	{
+		f := nvml.DeviceResetApplicationsClocks
+		nvml.DeviceResetApplicationsClocks = func(a0 nvml.Device) nvml.Return {
+			defer recordCall(calls, "DeviceResetApplicationsClocks", time.Now())
+			return f(a0)
+		}
+	}
+	{
+		f := nvml.DeviceResetGpuLockedClocks
+		nvml.DeviceResetGpuLockedClocks = func(a0 nvml.Device) nvml.Return {
+			defer recordCall(calls, "DeviceResetGpuLockedClocks", time.Now())
+			return f(a0)
+		}
+	}
+	{
+		f := nvml.DeviceResetMemoryLockedClocks
+		nvml.DeviceResetMemoryLockedClocks = func(a0 nvml.Device) nvml.Return {
+			defer recordCall(calls, "DeviceResetMemoryLockedClocks", time.Now())
+			return f(a0)
+		}
+	}
+	{
+		f := nvml.DeviceResetNvLinkErrorCounters
+		nvml.DeviceResetNvLinkErrorCounters = func(a0 nvml.Device, a1 int) nvml.Return {
+			defer recordCall(calls, "DeviceResetNvLinkErrorCounters", time.Now())
+			return f(a0, a1)
+		}
+	}
+	{
+		f := nvml.DeviceResetNvLinkUtilizationCounter
+		nvml.DeviceResetNvLinkUtilizationCounter = func(a0 nvml.Device, a1 int, a2 int) nvml.Return {
+			defer recordCall(calls, "DeviceResetNvLinkUtilizationCounter", time.Now())
+			return f(a0, a1, a2)
+		}
+	}
+	{
+		f := nvml.DeviceSetAPIRestriction
+		nvml.DeviceSetAPIRestriction = func(a0 nvml.Device, a1 nvml.RestrictedAPI, a2 nvml.EnableState) nvml.Return {
+			defer recordCall(calls, "DeviceSetAPIRestriction", time.Now())
+			return f(a0, a1, a2)
+		}
+	}
+	{
+		f := nvml.DeviceSetAccountingMode
+		nvml.DeviceSetAccountingMode = func(a0 nvml.Device, a1 nvml.EnableState) nvml.Return {
+			defer recordCall(calls, "DeviceSetAccountingMode", time.Now())
+			return f(a0, a1)
+		}
+	}
+	{
+		f := nvml.DeviceSetAdaptiveTgpMode_v1
+		nvml.DeviceSetAdaptiveTgpMode_v1 = func(a0 nvml.Device, a1 nvml.EnableState) nvml.Return {
+			defer recordCall(calls, "DeviceSetAdaptiveTgpMode_v1", time.Now())
+			return f(a0, a1)
+		}
+	}
+	{
+		f := nvml.DeviceSetApplicationsClocks
+		nvml.DeviceSetApplicationsClocks = func(a0 nvml.Device, a1 uint32, a2 uint32) nvml.Return {
+			defer recordCall(calls, "DeviceSetApplicationsClocks", time.Now())
+			return f(a0, a1, a2)
+		}
+	}
+	{
+		f := nvml.DeviceSetAutoBoostedClocksEnabled
+		nvml.DeviceSetAutoBoostedClocksEnabled = func(a0 nvml.Device, a1 nvml.EnableState) nvml.Return {
+			defer recordCall(calls, "DeviceSetAutoBoostedClocksEnabled", time.Now())
+			return f(a0, a1)
+		}
+	}
+	{
+		f := nvml.DeviceSetClockOffsets
+		nvml.DeviceSetClockOffsets = func(a0 nvml.Device, a1 nvml.ClockOffset) nvml.Return {
+			defer recordCall(calls, "DeviceSetClockOffsets", time.Now())
+			return f(a0, a1)
+		}
+	}
+	{
+		f := nvml.DeviceSetComputeMode
+		nvml.DeviceSetComputeMode = func(a0 nvml.Device, a1 nvml.ComputeMode) nvml.Return {
+			defer recordCall(calls, "DeviceSetComputeMode", time.Now())
+			return f(a0, a1)
+		}
+	}
+	{
+		f := nvml.DeviceSetConfComputeUnprotectedMemSize
+		nvml.DeviceSetConfComputeUnprotectedMemSize = func(a0 nvml.Device, a1 uint64) nvml.Return {
+			defer recordCall(calls, "DeviceSetConfComputeUnprotectedMemSize", time.Now())
+			return f(a0, a1)
+		}
+	}
+	{
+		f := nvml.DeviceSetCpuAffinity
+		nvml.DeviceSetCpuAffinity = func(a0 nvml.Device) nvml.Return {
+			defer recordCall(calls, "DeviceSetCpuAffinity", time.Now())
+			return f(a0)
+		}
+	}
+	{
+		f := nvml.DeviceSetDefaultAutoBoostedClocksEnabled
+		nvml.DeviceSetDefaultAutoBoostedClocksEnabled = func(a0 nvml.Device, a1 nvml.EnableState, a2 uint32) nvml.Return {
+			defer recordCall(calls, "DeviceSetDefaultAutoBoostedClocksEnabled", time.Now())
+			return f(a0, a1, a2)
+		}
+	}
+	{
+		f := nvml.DeviceSetDefaultFanSpeed_v2
+		nvml.DeviceSetDefaultFanSpeed_v2 = func(a0 nvml.Device, a1 int) nvml.Return {
+			defer recordCall(calls, "DeviceSetDefaultFanSpeed_v2", time.Now())
+			return f(a0, a1)
+		}
+	}
+	{
+		f := nvml.DeviceSetDramEncryptionMode
+		nvml.DeviceSetDramEncryptionMode = func(a0 nvml.Device, a1 *nvml.DramEncryptionInfo) nvml.Return {
+			defer recordCall(calls, "DeviceSetDramEncryptionMode", time.Now())
+			return f(a0, a1)
+		}
+	}
+	{
+		f := nvml.DeviceSetDriverModel
+		nvml.DeviceSetDriverModel = func(a0 nvml.Device, a1 nvml.DriverModel, a2 uint32) nvml.Return {
+			defer recordCall(calls, "DeviceSetDriverModel", time.Now())
+			return f(a0, a1, a2)
+		}
+	}
+	{
+		f := nvml.DeviceSetEccMode
+		nvml.DeviceSetEccMode = func(a0 nvml.Device, a1 nvml.EnableState) nvml.Return {
+			defer recordCall(calls, "DeviceSetEccMode", time.Now())
+			return f(a0, a1)
+		}
+	}
+	{
+		f := nvml.DeviceSetFanControlPolicy
+		nvml.DeviceSetFanControlPolicy = func(a0 nvml.Device, a1 int, a2 nvml.FanControlPolicy) nvml.Return {
+			defer recordCall(calls, "DeviceSetFanControlPolicy", time.Now())
+			return f(a0, a1, a2)
+		}
+	}
+	{
+		f := nvml.DeviceSetFanSpeed_v2
+		nvml.DeviceSetFanSpeed_v2 = func(a0 nvml.Device, a1 int, a2 int) nvml.Return {
+			defer recordCall(calls, "DeviceSetFanSpeed_v2", time.Now())
+			return f(a0, a1, a2)
+		}
+	}
+	{
+		f := nvml.DeviceSetGpcClkVfOffset
+		nvml.DeviceSetGpcClkVfOffset = func(a0 nvml.Device, a1 int) nvml.Return {
+			defer recordCall(calls, "DeviceSetGpcClkVfOffset", time.Now())
+			return f(a0, a1)
+		}
+	}
+	{
+		f := nvml.DeviceSetGpuLockedClocks
+		nvml.DeviceSetGpuLockedClocks = func(a0 nvml.Device, a1 uint32, a2 uint32) nvml.Return {
+			defer recordCall(calls, "DeviceSetGpuLockedClocks", time.Now())
+			return f(a0, a1, a2)
+		}
+	}
+	{
+		f := nvml.DeviceSetGpuOperationMode
+		nvml.DeviceSetGpuOperationMode = func(a0 nvml.Device, a1 nvml.GpuOperationMode) nvml.Return {
+			defer recordCall(calls, "DeviceSetGpuOperationMode", time.Now())
+			return f(a0, a1)
+		}
+	}
+	{
+		f := nvml.DeviceSetHostname_v1
+		nvml.DeviceSetHostname_v1 = func(a0 nvml.Device, a1 string) nvml.Return {
+			defer recordCall(calls, "DeviceSetHostname_v1", time.Now())
+			return f(a0, a1)
+		}
+	}
+	{
+		f := nvml.DeviceSetMemClkVfOffset
+		nvml.DeviceSetMemClkVfOffset = func(a0 nvml.Device, a1 int) nvml.Return {
+			defer recordCall(calls, "DeviceSetMemClkVfOffset", time.Now())
+			return f(a0, a1)
+		}
+	}
+	{
+		f := nvml.DeviceSetMemoryLimits_v1
+		nvml.DeviceSetMemoryLimits_v1 = func(a0 nvml.Device, a1 string, a2 uint64, a3 uint64) nvml.Return {
+			defer recordCall(calls, "DeviceSetMemoryLimits_v1", time.Now())
+			return f(a0, a1, a2, a3)
+		}
+	}
+	{
+		f := nvml.DeviceSetMemoryLockedClocks
+		nvml.DeviceSetMemoryLockedClocks = func(a0 nvml.Device, a1 uint32, a2 uint32) nvml.Return {
+			defer recordCall(calls, "DeviceSetMemoryLockedClocks", time.Now())
+			return f(a0, a1, a2)
+		}
+	}
+	{
+		f := nvml.DeviceSetMigMode
+		nvml.DeviceSetMigMode = func(a0 nvml.Device, a1 int) (nvml.Return, nvml.Return) {
+			defer recordCall(calls, "DeviceSetMigMode", time.Now())
+			return f(a0, a1)
+		}
+	}
+	{
+		f := nvml.DeviceSetNvLinkDeviceLowPowerThreshold
+		nvml.DeviceSetNvLinkDeviceLowPowerThreshold = func(a0 nvml.Device, a1 *nvml.NvLinkPowerThres) nvml.Return {
+			defer recordCall(calls, "DeviceSetNvLinkDeviceLowPowerThreshold", time.Now())
+			return f(a0, a1)
+		}
+	}
+	{
+		f := nvml.DeviceSetNvLinkUtilizationControl
+		nvml.DeviceSetNvLinkUtilizationControl = func(a0 nvml.Device, a1 int, a2 int, a3 *nvml.NvLinkUtilizationControl, a4 bool) nvml.Return {
+			defer recordCall(calls, "DeviceSetNvLinkUtilizationControl", time.Now())
+			return f(a0, a1, a2, a3, a4)
+		}
+	}
+	{
+		f := nvml.DeviceSetNvlinkBwMode
+		nvml.DeviceSetNvlinkBwMode = func(a0 nvml.Device, a1 *nvml.NvlinkSetBwMode) nvml.Return {
+			defer recordCall(calls, "DeviceSetNvlinkBwMode", time.Now())
+			return f(a0, a1)
+		}
+	}
+	{
+		f := nvml.DeviceSetNvlinkBwModeAsync_v1
+		nvml.DeviceSetNvlinkBwModeAsync_v1 = func(a0 nvml.Device, a1 *nvml.NvlinkSetBwModeAsync_v1) nvml.Return {
+			defer recordCall(calls, "DeviceSetNvlinkBwModeAsync_v1", time.Now())
+			return f(a0, a1)
+		}
+	}
+	{
+		f := nvml.DeviceSetPersistenceMode
+		nvml.DeviceSetPersistenceMode = func(a0 nvml.Device, a1 nvml.EnableState) nvml.Return {
+			defer recordCall(calls, "DeviceSetPersistenceMode", time.Now())
+			return f(a0, a1)
+		}
+	}
+	{
+		f := nvml.DeviceSetPowerManagementLimit
+		nvml.DeviceSetPowerManagementLimit = func(a0 nvml.Device, a1 uint32) nvml.Return {
+			defer recordCall(calls, "DeviceSetPowerManagementLimit", time.Now())
+			return f(a0, a1)
+		}
+	}
+	{
+		f := nvml.DeviceSetPowerManagementLimit_v2
+		nvml.DeviceSetPowerManagementLimit_v2 = func(a0 nvml.Device, a1 *nvml.PowerValue_v2) nvml.Return {
+			defer recordCall(calls, "DeviceSetPowerManagementLimit_v2", time.Now())
+			return f(a0, a1)
+		}
+	}
+	{
+		f := nvml.DeviceSetRusdSettings_v1
+		nvml.DeviceSetRusdSettings_v1 = func(a0 nvml.Device, a1 nvml.RusdSettings_v1) nvml.Return {
+			defer recordCall(calls, "DeviceSetRusdSettings_v1", time.Now())
+			return f(a0, a1)
+		}
+	}
+	{
+		f := nvml.DeviceSetTemperatureThreshold
+		nvml.DeviceSetTemperatureThreshold = func(a0 nvml.Device, a1 nvml.TemperatureThresholds, a2 int) nvml.Return {
+			defer recordCall(calls, "DeviceSetTemperatureThreshold", time.Now())
+			return f(a0, a1, a2)
+		}
+	}
+	{
+		f := nvml.DeviceSetVgpuCapabilities
+		nvml.DeviceSetVgpuCapabilities = func(a0 nvml.Device, a1 nvml.DeviceVgpuCapability, a2 nvml.EnableState) nvml.Return {
+			defer recordCall(calls, "DeviceSetVgpuCapabilities", time.Now())
+			return f(a0, a1, a2)
+		}
+	}
+	{
+		f := nvml.DeviceSetVgpuHeterogeneousMode
+		nvml.DeviceSetVgpuHeterogeneousMode = func(a0 nvml.Device, a1 nvml.VgpuHeterogeneousMode) nvml.Return {
+			defer recordCall(calls, "DeviceSetVgpuHeterogeneousMode", time.Now())
+			return f(a0, a1)
+		}
+	}
+	{
+		f := nvml.DeviceSetVgpuSchedulerState
+		nvml.DeviceSetVgpuSchedulerState = func(a0 nvml.Device, a1 *nvml.VgpuSchedulerSetState) nvml.Return {
+			defer recordCall(calls, "DeviceSetVgpuSchedulerState", time.Now())
+			return f(a0, a1)
+		}
+	}
+	{
+		f := nvml.DeviceSetVgpuSchedulerState_v2
+		nvml.DeviceSetVgpuSchedulerState_v2 = func(a0 nvml.Device, a1 *nvml.VgpuSchedulerState_v2) nvml.Return {
+			defer recordCall(calls, "DeviceSetVgpuSchedulerState_v2", time.Now())
+			return f(a0, a1)
+		}
+	}
+	{
+		f := nvml.DeviceSetVirtualizationMode
+		nvml.DeviceSetVirtualizationMode = func(a0 nvml.Device, a1 nvml.GpuVirtualizationMode) nvml.Return {
+			defer recordCall(calls, "DeviceSetVirtualizationMode", time.Now())
+			return f(a0, a1)
+		}
+	}
+	{
+		f := nvml.DeviceValidateInforom
+		nvml.DeviceValidateInforom = func(a0 nvml.Device) nvml.Return {
+			defer recordCall(calls, "DeviceValidateInforom", time.Now())
+			return f(a0)
+		}
+	}
+	{
+		f := nvml.DeviceVgpuForceGspUnload
+		nvml.DeviceVgpuForceGspUnload = func(a0 nvml.Device) nvml.Return {
+			defer recordCall(calls, "DeviceVgpuForceGspUnload", time.Now())
+			return f(a0)
+		}
+	}
+	{
+		f := nvml.DeviceWorkloadPowerProfileClearRequestedProfiles
+		nvml.DeviceWorkloadPowerProfileClearRequestedProfiles = func(a0 nvml.Device, a1 *nvml.WorkloadPowerProfileRequestedProfiles) nvml.Return {
+			defer recordCall(calls, "DeviceWorkloadPowerProfileClearRequestedProfiles", time.Now())
+			return f(a0, a1)
+		}
+	}
+	{
+		f := nvml.DeviceWorkloadPowerProfileGetCurrentProfiles
+		nvml.DeviceWorkloadPowerProfileGetCurrentProfiles = func(a0 nvml.Device) (nvml.WorkloadPowerProfileCurrentProfiles, nvml.Return) {
+			defer recordCall(calls, "DeviceWorkloadPowerProfileGetCurrentProfiles", time.Now())
+			return f(a0)
+		}
+	}
+	{
+		f := nvml.DeviceWorkloadPowerProfileGetProfilesInfo
+		nvml.DeviceWorkloadPowerProfileGetProfilesInfo = func(a0 nvml.Device) (nvml.WorkloadPowerProfileProfilesInfo, nvml.Return) {
+			defer recordCall(calls, "DeviceWorkloadPowerProfileGetProfilesInfo", time.Now())
+			return f(a0)
+		}
+	}
+	{
+		f := nvml.DeviceWorkloadPowerProfileSetRequestedProfiles
+		nvml.DeviceWorkloadPowerProfileSetRequestedProfiles = func(a0 nvml.Device, a1 *nvml.WorkloadPowerProfileRequestedProfiles) nvml.Return {
+			defer recordCall(calls, "DeviceWorkloadPowerProfileSetRequestedProfiles", time.Now())
+			return f(a0, a1)
+		}
+	}
+	{
+		f := nvml.DeviceWorkloadPowerProfileUpdateProfiles_v1
+		nvml.DeviceWorkloadPowerProfileUpdateProfiles_v1 = func(a0 nvml.Device, a1 nvml.PowerProfileOperation, a2 []nvml.PowerProfileType) nvml.Return {
+			defer recordCall(calls, "DeviceWorkloadPowerProfileUpdateProfiles_v1", time.Now())
+			return f(a0, a1, a2)
+		}
+	}
+	{
+		f := nvml.ErrorString
+		nvml.ErrorString = func(a0 nvml.Return) string {
+			defer recordCall(calls, "ErrorString", time.Now())
+			return f(a0)
+		}
+	}
+	{
+		f := nvml.EventSetCreate
+		nvml.EventSetCreate = func() (nvml.EventSet, nvml.Return) {
+			defer recordCall(calls, "EventSetCreate", time.Now())
+			return f()
+		}
+	}
+	{
+		f := nvml.EventSetFree
+		nvml.EventSetFree = func(a0 nvml.EventSet) nvml.Return {
+			defer recordCall(calls, "EventSetFree", time.Now())
+			return f(a0)
+		}
+	}
+	{
+		f := nvml.EventSetGetContextCount_v1
+		nvml.EventSetGetContextCount_v1 = func(a0 nvml.EventSet) (nvml.GetContextCount_v1, nvml.Return) {
+			defer recordCall(calls, "EventSetGetContextCount_v1", time.Now())
+			return f(a0)
+		}
+	}
+	{
+		f := nvml.EventSetGetContextData_v1
+		nvml.EventSetGetContextData_v1 = func(a0 nvml.EventSet, a1 nvml.GetContextData_v1) (nvml.GetContextData_v1, nvml.Return) {
+			defer recordCall(calls, "EventSetGetContextData_v1", time.Now())
+			return f(a0, a1)
+		}
+	}
+	{
+		f := nvml.EventSetGetContextInfo_v1
+		nvml.EventSetGetContextInfo_v1 = func(a0 nvml.EventSet, a1 uint32) (nvml.GetContextInfo_v1, nvml.Return) {
+			defer recordCall(calls, "EventSetGetContextInfo_v1", time.Now())
+			return f(a0, a1)
+		}
+	}
+	{
+		f := nvml.EventSetGetGpuOperationalEventContextLegacyXid_v1
+		nvml.EventSetGetGpuOperationalEventContextLegacyXid_v1 = func(a0 nvml.EventSet, a1 uint32) (uint32, nvml.Return) {
+			defer recordCall(calls, "EventSetGetGpuOperationalEventContextLegacyXid_v1", time.Now())
+			return f(a0, a1)
+		}
+	}
+	{
+		f := nvml.EventSetRegisterGpuOperationalEvents_v1
+		nvml.EventSetRegisterGpuOperationalEvents_v1 = func(a0 nvml.EventSet, a1 *nvml.GpuOperationalEventConfig_v1) nvml.Return {
+			defer recordCall(calls, "EventSetRegisterGpuOperationalEvents_v1", time.Now())
+			return f(a0, a1)
+		}
+	}
+	{
+		f := nvml.EventSetWait
+		nvml.EventSetWait = func(a0 nvml.EventSet, a1 uint32) (nvml.EventData, nvml.Return) {
+			defer recordCall(calls, "EventSetWait", time.Now())
+			return f(a0, a1)
+		}
+	}
+	{
+		f := nvml.EventSetWait_v3
+		nvml.EventSetWait_v3 = func(a0 nvml.EventSet, a1 uint32) (nvml.EventSetWaitData_v3, nvml.Return) {
+			defer recordCall(calls, "EventSetWait_v3", time.Now())
+			return f(a0, a1)
+		}
+	}
+	{
+		f := nvml.Extensions
+		nvml.Extensions = func() nvml.ExtendedInterface {
+			defer recordCall(calls, "Extensions", time.Now())
+			return f()
+		}
+	}
+	{
+		f := nvml.GetExcludedDeviceCount
+		nvml.GetExcludedDeviceCount = func() (int, nvml.Return) {
+			defer recordCall(calls, "GetExcludedDeviceCount", time.Now())
+			return f()
+		}
+	}
+	{
+		f := nvml.GetExcludedDeviceInfoByIndex
+		nvml.GetExcludedDeviceInfoByIndex = func(a0 int) (nvml.ExcludedDeviceInfo, nvml.Return) {
+			defer recordCall(calls, "GetExcludedDeviceInfoByIndex", time.Now())
+			return f(a0)
+		}
+	}
+	{
+		f := nvml.GetVgpuCompatibility
+		nvml.GetVgpuCompatibility = func(a0 *nvml.VgpuMetadata, a1 *nvml.VgpuPgpuMetadata) (nvml.VgpuPgpuCompatibility, nvml.Return) {
+			defer recordCall(calls, "GetVgpuCompatibility", time.Now())
+			return f(a0, a1)
+		}
+	}
+	{
+		f := nvml.GetVgpuDriverCapabilities
+		nvml.GetVgpuDriverCapabilities = func(a0 nvml.VgpuDriverCapability) (bool, nvml.Return) {
+			defer recordCall(calls, "GetVgpuDriverCapabilities", time.Now())
+			return f(a0)
+		}
+	}
+	{
+		f := nvml.GetVgpuVersion
+		nvml.GetVgpuVersion = func() (nvml.VgpuVersion, nvml.VgpuVersion, nvml.Return) {
+			defer recordCall(calls, "GetVgpuVersion", time.Now())
+			return f()
+		}
+	}
+	{
+		f := nvml.GpmMetricsGet
+		nvml.GpmMetricsGet = func(a0 *nvml.GpmMetricsGetType) nvml.Return {
+			defer recordCall(calls, "GpmMetricsGet", time.Now())
+			return f(a0)
+		}
+	}
+	{
+		f := nvml.GpmMetricsGetV
+		nvml.GpmMetricsGetV = func(a0 *nvml.GpmMetricsGetType) nvml.GpmMetricsGetVType {
+			defer recordCall(calls, "GpmMetricsGetV", time.Now())
+			return f(a0)
+		}
+	}
+	{
+		f := nvml.GpmMigSampleGet
+		nvml.GpmMigSampleGet = func(a0 nvml.Device, a1 int, a2 nvml.GpmSample) nvml.Return {
+			defer recordCall(calls, "GpmMigSampleGet", time.Now())
+			return f(a0, a1, a2)
+		}
+	}
+	{
+		f := nvml.GpmQueryDeviceSupport
+		nvml.GpmQueryDeviceSupport = func(a0 nvml.Device) (nvml.GpmSupport, nvml.Return) {
+			defer recordCall(calls, "GpmQueryDeviceSupport", time.Now())
+			return f(a0)
+		}
+	}
+	{
+		f := nvml.GpmQueryDeviceSupportV
+		nvml.GpmQueryDeviceSupportV = func(a0 nvml.Device) nvml.GpmSupportV {
+			defer recordCall(calls, "GpmQueryDeviceSupportV", time.Now())
+			return f(a0)
+		}
+	}
+	{
+		f := nvml.GpmQueryIfStreamingEnabled
+		nvml.GpmQueryIfStreamingEnabled = func(a0 nvml.Device) (uint32, nvml.Return) {
+			defer recordCall(calls, "GpmQueryIfStreamingEnabled", time.Now())
+			return f(a0)
+		}
+	}
+	{
+		f := nvml.GpmSampleAlloc
+		nvml.GpmSampleAlloc = func() (nvml.GpmSample, nvml.Return) {
+			defer recordCall(calls, "GpmSampleAlloc", time.Now())
+			return f()
+		}
+	}
+	{
+		f := nvml.GpmSampleFree
+		nvml.GpmSampleFree = func(a0 nvml.GpmSample) nvml.Return {
+			defer recordCall(calls, "GpmSampleFree", time.Now())
+			return f(a0)
+		}
+	}
+	{
+		f := nvml.GpmSampleGet
+		nvml.GpmSampleGet = func(a0 nvml.Device, a1 nvml.GpmSample) nvml.Return {
+			defer recordCall(calls, "GpmSampleGet", time.Now())
+			return f(a0, a1)
+		}
+	}
+	{
+		f := nvml.GpmSetStreamingEnabled
+		nvml.GpmSetStreamingEnabled = func(a0 nvml.Device, a1 uint32) nvml.Return {
+			defer recordCall(calls, "GpmSetStreamingEnabled", time.Now())
+			return f(a0, a1)
+		}
+	}
+	{
+		f := nvml.GpuInstanceCreateComputeInstance
+		nvml.GpuInstanceCreateComputeInstance = func(a0 nvml.GpuInstance, a1 *nvml.ComputeInstanceProfileInfo) (nvml.ComputeInstance, nvml.Return) {
+			defer recordCall(calls, "GpuInstanceCreateComputeInstance", time.Now())
+			return f(a0, a1)
+		}
+	}
+	{
+		f := nvml.GpuInstanceCreateComputeInstanceWithPlacement
+		nvml.GpuInstanceCreateComputeInstanceWithPlacement = func(a0 nvml.GpuInstance, a1 *nvml.ComputeInstanceProfileInfo, a2 *nvml.ComputeInstancePlacement) (nvml.ComputeInstance, nvml.Return) {
+			defer recordCall(calls, "GpuInstanceCreateComputeInstanceWithPlacement", time.Now())
+			return f(a0, a1, a2)
+		}
+	}
+	{
+		f := nvml.GpuInstanceDestroy
+		nvml.GpuInstanceDestroy = func(a0 nvml.GpuInstance) nvml.Return {
+			defer recordCall(calls, "GpuInstanceDestroy", time.Now())
+			return f(a0)
+		}
+	}
+	{
+		f := nvml.GpuInstanceGetActiveVgpus
+		nvml.GpuInstanceGetActiveVgpus = func(a0 nvml.GpuInstance) (nvml.ActiveVgpuInstanceInfo, nvml.Return) {
+			defer recordCall(calls, "GpuInstanceGetActiveVgpus", time.Now())
+			return f(a0)
+		}
+	}
+	{
+		f := nvml.GpuInstanceGetComputeInstanceById
+		nvml.GpuInstanceGetComputeInstanceById = func(a0 nvml.GpuInstance, a1 int) (nvml.ComputeInstance, nvml.Return) {
+			defer recordCall(calls, "GpuInstanceGetComputeInstanceById", time.Now())
+			return f(a0, a1)
+		}
+	}
+	{
+		f := nvml.GpuInstanceGetComputeInstancePossiblePlacements
+		nvml.GpuInstanceGetComputeInstancePossiblePlacements = func(a0 nvml.GpuInstance, a1 *nvml.ComputeInstanceProfileInfo) ([]nvml.ComputeInstancePlacement, nvml.Return) {
+			defer recordCall(calls, "GpuInstanceGetComputeInstancePossiblePlacements", time.Now())
+			return f(a0, a1)
+		}
+	}
+	{
+		f := nvml.GpuInstanceGetComputeInstanceProfileInfo
+		nvml.GpuInstanceGetComputeInstanceProfileInfo = func(a0 nvml.GpuInstance, a1 int, a2 int) (nvml.ComputeInstanceProfileInfo, nvml.Return) {
+			defer recordCall(calls, "GpuInstanceGetComputeInstanceProfileInfo", time.Now())
+			return f(a0, a1, a2)
+		}
+	}
+	{
+		f := nvml.GpuInstanceGetComputeInstanceProfileInfoV
+		nvml.GpuInstanceGetComputeInstanceProfileInfoV = func(a0 nvml.GpuInstance, a1 int, a2 int) nvml.ComputeInstanceProfileInfoHandler {
+			defer recordCall(calls, "GpuInstanceGetComputeInstanceProfileInfoV", time.Now())
+			return f(a0, a1, a2)
+		}
+	}
+	{
+		f := nvml.GpuInstanceGetComputeInstanceRemainingCapacity
+		nvml.GpuInstanceGetComputeInstanceRemainingCapacity = func(a0 nvml.GpuInstance, a1 *nvml.ComputeInstanceProfileInfo) (int, nvml.Return) {
+			defer recordCall(calls, "GpuInstanceGetComputeInstanceRemainingCapacity", time.Now())
+			return f(a0, a1)
+		}
+	}
+	{
+		f := nvml.GpuInstanceGetComputeInstances
+		nvml.GpuInstanceGetComputeInstances = func(a0 nvml.GpuInstance, a1 *nvml.ComputeInstanceProfileInfo) ([]nvml.ComputeInstance, nvml.Return) {
+			defer recordCall(calls, "GpuInstanceGetComputeInstances", time.Now())
+			return f(a0, a1)
+		}
+	}
+	{
+		f := nvml.GpuInstanceGetCreatableVgpus
+		nvml.GpuInstanceGetCreatableVgpus = func(a0 nvml.GpuInstance) (nvml.VgpuTypeIdInfo, nvml.Return) {
+			defer recordCall(calls, "GpuInstanceGetCreatableVgpus", time.Now())
+			return f(a0)
+		}
+	}
+	{
+		f := nvml.GpuInstanceGetInfo
+		nvml.GpuInstanceGetInfo = func(a0 nvml.GpuInstance) (nvml.GpuInstanceInfo, nvml.Return) {
+			defer recordCall(calls, "GpuInstanceGetInfo", time.Now())
+			return f(a0)
+		}
+	}
+	{
+		f := nvml.GpuInstanceGetVgpuHeterogeneousMode
+		nvml.GpuInstanceGetVgpuHeterogeneousMode = func(a0 nvml.GpuInstance) (nvml.VgpuHeterogeneousMode, nvml.Return) {
+			defer recordCall(calls, "GpuInstanceGetVgpuHeterogeneousMode", time.Now())
+			return f(a0)
+		}
+	}
+	{
+		f := nvml.GpuInstanceGetVgpuSchedulerLog
+		nvml.GpuInstanceGetVgpuSchedulerLog = func(a0 nvml.GpuInstance) (nvml.VgpuSchedulerLogInfo, nvml.Return) {
+			defer recordCall(calls, "GpuInstanceGetVgpuSchedulerLog", time.Now())
+			return f(a0)
+		}
+	}
+	{
+		f := nvml.GpuInstanceGetVgpuSchedulerLog_v2
+		nvml.GpuInstanceGetVgpuSchedulerLog_v2 = func(a0 nvml.GpuInstance, a1 nvml.VgpuSchedulerLogInfo_v2) (nvml.VgpuSchedulerLogInfo_v2, nvml.Return) {
+			defer recordCall(calls, "GpuInstanceGetVgpuSchedulerLog_v2", time.Now())
+			return f(a0, a1)
+		}
+	}
+	{
+		f := nvml.GpuInstanceGetVgpuSchedulerState
+		nvml.GpuInstanceGetVgpuSchedulerState = func(a0 nvml.GpuInstance) (nvml.VgpuSchedulerStateInfo, nvml.Return) {
+			defer recordCall(calls, "GpuInstanceGetVgpuSchedulerState", time.Now())
+			return f(a0)
+		}
+	}
+	{
+		f := nvml.GpuInstanceGetVgpuSchedulerState_v2
+		nvml.GpuInstanceGetVgpuSchedulerState_v2 = func(a0 nvml.GpuInstance, a1 nvml.VgpuSchedulerStateInfo_v2) (nvml.VgpuSchedulerStateInfo_v2, nvml.Return) {
+			defer recordCall(calls, "GpuInstanceGetVgpuSchedulerState_v2", time.Now())
+			return f(a0, a1)
+		}
+	}
+	{
+		f := nvml.GpuInstanceGetVgpuTypeCreatablePlacements
+		nvml.GpuInstanceGetVgpuTypeCreatablePlacements = func(a0 nvml.GpuInstance) (nvml.VgpuCreatablePlacementInfo, nvml.Return) {
+			defer recordCall(calls, "GpuInstanceGetVgpuTypeCreatablePlacements", time.Now())
+			return f(a0)
+		}
+	}
+	{
+		f := nvml.GpuInstanceSetVgpuHeterogeneousMode
+		nvml.GpuInstanceSetVgpuHeterogeneousMode = func(a0 nvml.GpuInstance, a1 *nvml.VgpuHeterogeneousMode) nvml.Return {
+			defer recordCall(calls, "GpuInstanceSetVgpuHeterogeneousMode", time.Now())
+			return f(a0, a1)
+		}
+	}
+	{
+		f := nvml.GpuInstanceSetVgpuSchedulerState
+		nvml.GpuInstanceSetVgpuSchedulerState = func(a0 nvml.GpuInstance, a1 *nvml.VgpuSchedulerState) nvml.Return {
+			defer recordCall(calls, "GpuInstanceSetVgpuSchedulerState", time.Now())
+			return f(a0, a1)
+		}
+	}
+	{
+		f := nvml.GpuInstanceSetVgpuSchedulerState_v2
+		nvml.GpuInstanceSetVgpuSchedulerState_v2 = func(a0 nvml.GpuInstance, a1 *nvml.VgpuSchedulerState_v2) nvml.Return {
+			defer recordCall(calls, "GpuInstanceSetVgpuSchedulerState_v2", time.Now())
+			return f(a0, a1)
+		}
+	}
+	{
+		f := nvml.Init
+		nvml.Init = func() nvml.Return {
+			defer recordCall(calls, "Init", time.Now())
+			return f()
+		}
+	}
+	{
+		f := nvml.InitWithFlags
+		nvml.InitWithFlags = func(a0 uint32) nvml.Return {
+			defer recordCall(calls, "InitWithFlags", time.Now())
+			return f(a0)
+		}
+	}
+	{
+		f := nvml.SetVgpuVersion
+		nvml.SetVgpuVersion = func(a0 *nvml.VgpuVersion) nvml.Return {
+			defer recordCall(calls, "SetVgpuVersion", time.Now())
+			return f(a0)
+		}
+	}
+	{
+		f := nvml.Shutdown
+		nvml.Shutdown = func() nvml.Return {
+			defer recordCall(calls, "Shutdown", time.Now())
+			return f()
+		}
+	}
+	{
+		f := nvml.SystemEventSetCreate
+		nvml.SystemEventSetCreate = func(a0 *nvml.SystemEventSetCreateRequest) nvml.Return {
+			defer recordCall(calls, "SystemEventSetCreate", time.Now())
+			return f(a0)
+		}
+	}
+	{
+		f := nvml.SystemEventSetFree
+		nvml.SystemEventSetFree = func(a0 *nvml.SystemEventSetFreeRequest) nvml.Return {
+			defer recordCall(calls, "SystemEventSetFree", time.Now())
+			return f(a0)
+		}
+	}
+	{
+		f := nvml.SystemEventSetWait
+		nvml.SystemEventSetWait = func(a0 *nvml.SystemEventSetWaitRequest) nvml.Return {
+			defer recordCall(calls, "SystemEventSetWait", time.Now())
+			return f(a0)
+		}
+	}
+	{
+		f := nvml.SystemGetCPER_v1
+		nvml.SystemGetCPER_v1 = func(a0 *nvml.GetCPER_v1) nvml.Return {
+			defer recordCall(calls, "SystemGetCPER_v1", time.Now())
+			return f(a0)
+		}
+	}
+	{
+		f := nvml.SystemGetConfComputeCapabilities
+		nvml.SystemGetConfComputeCapabilities = func() (nvml.ConfComputeSystemCaps, nvml.Return) {
+			defer recordCall(calls, "SystemGetConfComputeCapabilities", time.Now())
+			return f()
+		}
+	}
+	{
+		f := nvml.SystemGetConfComputeGpusReadyState
+		nvml.SystemGetConfComputeGpusReadyState = func() (uint32, nvml.Return) {
+			defer recordCall(calls, "SystemGetConfComputeGpusReadyState", time.Now())
+			return f()
+		}
+	}
+	{
+		f := nvml.SystemGetConfComputeKeyRotationThresholdInfo
+		nvml.SystemGetConfComputeKeyRotationThresholdInfo = func() (nvml.ConfComputeGetKeyRotationThresholdInfo, nvml.Return) {
+			defer recordCall(calls, "SystemGetConfComputeKeyRotationThresholdInfo", time.Now())
+			return f()
+		}
+	}
+	{
+		f := nvml.SystemGetConfComputeSettings
+		nvml.SystemGetConfComputeSettings = func() (nvml.SystemConfComputeSettings, nvml.Return) {
+			defer recordCall(calls, "SystemGetConfComputeSettings", time.Now())
+			return f()
+		}
+	}
+	{
+		f := nvml.SystemGetConfComputeState
+		nvml.SystemGetConfComputeState = func() (nvml.ConfComputeSystemState, nvml.Return) {
+			defer recordCall(calls, "SystemGetConfComputeState", time.Now())
+			return f()
+		}
+	}
+	{
+		f := nvml.SystemGetCudaDriverVersion
+		nvml.SystemGetCudaDriverVersion = func() (int, nvml.Return) {
+			defer recordCall(calls, "SystemGetCudaDriverVersion", time.Now())
+			return f()
+		}
+	}
+	{
+		f := nvml.SystemGetCudaDriverVersion_v2
+		nvml.SystemGetCudaDriverVersion_v2 = func() (int, nvml.Return) {
+			defer recordCall(calls, "SystemGetCudaDriverVersion_v2", time.Now())
+			return f()
+		}
+	}
+	{
+		f := nvml.SystemGetDriverBranch
+		nvml.SystemGetDriverBranch = func() (nvml.SystemDriverBranchInfo, nvml.Return) {
+			defer recordCall(calls, "SystemGetDriverBranch", time.Now())
+			return f()
+		}
+	}
+	{
+		f := nvml.SystemGetDriverVersion
+		nvml.SystemGetDriverVersion = func() (string, nvml.Return) {
+			defer recordCall(calls, "SystemGetDriverVersion", time.Now())
+			return f()
+		}
+	}
+	{
+		f := nvml.SystemGetHicVersion
+		nvml.SystemGetHicVersion = func() ([]nvml.HwbcEntry, nvml.Return) {
+			defer recordCall(calls, "SystemGetHicVersion", time.Now())
+			return f()
+		}
+	}
+	{
+		f := nvml.SystemGetNVMLVersion
+		nvml.SystemGetNVMLVersion = func() (string, nvml.Return) {
+			defer recordCall(calls, "SystemGetNVMLVersion", time.Now())
+			return f()
+		}
+	}
+	{
+		f := nvml.SystemGetNvlinkBwMode
+		nvml.SystemGetNvlinkBwMode = func() (uint32, nvml.Return) {
+			defer recordCall(calls, "SystemGetNvlinkBwMode", time.Now())
+			return f()
+		}
+	}
+	{
+		f := nvml.SystemGetProcessName
+		nvml.SystemGetProcessName = func(a0 int) (string, nvml.Return) {
+			defer recordCall(calls, "SystemGetProcessName", time.Now())
+			return f(a0)
+		}
+	}
+	{
+		f := nvml.SystemGetTopologyGpuSet
+		nvml.SystemGetTopologyGpuSet = func(a0 int) ([]nvml.Device, nvml.Return) {
+			defer recordCall(calls, "SystemGetTopologyGpuSet", time.Now())
+			return f(a0)
+		}
+	}
+	{
+		f := nvml.SystemRegisterEvents
+		nvml.SystemRegisterEvents = func(a0 *nvml.SystemRegisterEventRequest) nvml.Return {
+			defer recordCall(calls, "SystemRegisterEvents", time.Now())
+			return f(a0)
+		}
+	}
+	{
+		f := nvml.SystemSetConfComputeGpusReadyState
+		nvml.SystemSetConfComputeGpusReadyState = func(a0 uint32) nvml.Return {
+			defer recordCall(calls, "SystemSetConfComputeGpusReadyState", time.Now())
+			return f(a0)
+		}
+	}
+	{
+		f := nvml.SystemSetConfComputeKeyRotationThresholdInfo
+		nvml.SystemSetConfComputeKeyRotationThresholdInfo = func(a0 nvml.ConfComputeSetKeyRotationThresholdInfo) nvml.Return {
+			defer recordCall(calls, "SystemSetConfComputeKeyRotationThresholdInfo", time.Now())
+			return f(a0)
+		}
+	}
+	{
+		f := nvml.SystemSetNvlinkBwMode
+		nvml.SystemSetNvlinkBwMode = func(a0 uint32) nvml.Return {
+			defer recordCall(calls, "SystemSetNvlinkBwMode", time.Now())
+			return f(a0)
+		}
+	}
+	{
+		f := nvml.UnitGetCount
+		nvml.UnitGetCount = func() (int, nvml.Return) {
+			defer recordCall(calls, "UnitGetCount", time.Now())
+			return f()
+		}
+	}
+	{
+		f := nvml.UnitGetDevices
+		nvml.UnitGetDevices = func(a0 nvml.Unit) ([]nvml.Device, nvml.Return) {
+			defer recordCall(calls, "UnitGetDevices", time.Now())
+			return f(a0)
+		}
+	}
+	{
+		f := nvml.UnitGetFanSpeedInfo
+		nvml.UnitGetFanSpeedInfo = func(a0 nvml.Unit) (nvml.UnitFanSpeeds, nvml.Return) {
+			defer recordCall(calls, "UnitGetFanSpeedInfo", time.Now())
+			return f(a0)
+		}
+	}
+	{
+		f := nvml.UnitGetHandleByIndex
+		nvml.UnitGetHandleByIndex = func(a0 int) (nvml.Unit, nvml.Return) {
+			defer recordCall(calls, "UnitGetHandleByIndex", time.Now())
+			return f(a0)
+		}
+	}
+	{
+		f := nvml.UnitGetLedState
+		nvml.UnitGetLedState = func(a0 nvml.Unit) (nvml.LedState, nvml.Return) {
+			defer recordCall(calls, "UnitGetLedState", time.Now())
+			return f(a0)
+		}
+	}
+	{
+		f := nvml.UnitGetPsuInfo
+		nvml.UnitGetPsuInfo = func(a0 nvml.Unit) (nvml.PSUInfo, nvml.Return) {
+			defer recordCall(calls, "UnitGetPsuInfo", time.Now())
+			return f(a0)
+		}
+	}
+	{
+		f := nvml.UnitGetTemperature
+		nvml.UnitGetTemperature = func(a0 nvml.Unit, a1 int) (uint32, nvml.Return) {
+			defer recordCall(calls, "UnitGetTemperature", time.Now())
+			return f(a0, a1)
+		}
+	}
+	{
+		f := nvml.UnitGetUnitInfo
+		nvml.UnitGetUnitInfo = func(a0 nvml.Unit) (nvml.UnitInfo, nvml.Return) {
+			defer recordCall(calls, "UnitGetUnitInfo", time.Now())
+			return f(a0)
+		}
+	}
+	{
+		f := nvml.UnitSetLedState
+		nvml.UnitSetLedState = func(a0 nvml.Unit, a1 nvml.LedColor) nvml.Return {
+			defer recordCall(calls, "UnitSetLedState", time.Now())
+			return f(a0, a1)
+		}
+	}
+	{
+		f := nvml.VgpuInstanceClearAccountingPids
+		nvml.VgpuInstanceClearAccountingPids = func(a0 nvml.VgpuInstance) nvml.Return {
+			defer recordCall(calls, "VgpuInstanceClearAccountingPids", time.Now())
+			return f(a0)
+		}
+	}
+	{
+		f := nvml.VgpuInstanceGetAccountingMode
+		nvml.VgpuInstanceGetAccountingMode = func(a0 nvml.VgpuInstance) (nvml.EnableState, nvml.Return) {
+			defer recordCall(calls, "VgpuInstanceGetAccountingMode", time.Now())
+			return f(a0)
+		}
+	}
+	{
+		f := nvml.VgpuInstanceGetAccountingPids
+		nvml.VgpuInstanceGetAccountingPids = func(a0 nvml.VgpuInstance) ([]int, nvml.Return) {
+			defer recordCall(calls, "VgpuInstanceGetAccountingPids", time.Now())
+			return f(a0)
+		}
+	}
+	{
+		f := nvml.VgpuInstanceGetAccountingStats
+		nvml.VgpuInstanceGetAccountingStats = func(a0 nvml.VgpuInstance, a1 int) (nvml.AccountingStats, nvml.Return) {
+			defer recordCall(calls, "VgpuInstanceGetAccountingStats", time.Now())
+			return f(a0, a1)
+		}
+	}
+	{
+		f := nvml.VgpuInstanceGetEccMode
+		nvml.VgpuInstanceGetEccMode = func(a0 nvml.VgpuInstance) (nvml.EnableState, nvml.Return) {
+			defer recordCall(calls, "VgpuInstanceGetEccMode", time.Now())
+			return f(a0)
+		}
+	}
+	{
+		f := nvml.VgpuInstanceGetEncoderCapacity
+		nvml.VgpuInstanceGetEncoderCapacity = func(a0 nvml.VgpuInstance) (int, nvml.Return) {
+			defer recordCall(calls, "VgpuInstanceGetEncoderCapacity", time.Now())
+			return f(a0)
+		}
+	}
+	{
+		f := nvml.VgpuInstanceGetEncoderSessions
+		nvml.VgpuInstanceGetEncoderSessions = func(a0 nvml.VgpuInstance) (int, nvml.EncoderSessionInfo, nvml.Return) {
+			defer recordCall(calls, "VgpuInstanceGetEncoderSessions", time.Now())
+			return f(a0)
+		}
+	}
+	{
+		f := nvml.VgpuInstanceGetEncoderStats
+		nvml.VgpuInstanceGetEncoderStats = func(a0 nvml.VgpuInstance) (int, uint32, uint32, nvml.Return) {
+			defer recordCall(calls, "VgpuInstanceGetEncoderStats", time.Now())
+			return f(a0)
+		}
+	}
+	{
+		f := nvml.VgpuInstanceGetFBCSessions
+		nvml.VgpuInstanceGetFBCSessions = func(a0 nvml.VgpuInstance) (int, nvml.FBCSessionInfo, nvml.Return) {
+			defer recordCall(calls, "VgpuInstanceGetFBCSessions", time.Now())
+			return f(a0)
+		}
+	}
+	{
+		f := nvml.VgpuInstanceGetFBCStats
+		nvml.VgpuInstanceGetFBCStats = func(a0 nvml.VgpuInstance) (nvml.FBCStats, nvml.Return) {
+			defer recordCall(calls, "VgpuInstanceGetFBCStats", time.Now())
+			return f(a0)
+		}
+	}
+	{
+		f := nvml.VgpuInstanceGetFbUsage
+		nvml.VgpuInstanceGetFbUsage = func(a0 nvml.VgpuInstance) (uint64, nvml.Return) {
+			defer recordCall(calls, "VgpuInstanceGetFbUsage", time.Now())
+			return f(a0)
+		}
+	}
+	{
+		f := nvml.VgpuInstanceGetFrameRateLimit
+		nvml.VgpuInstanceGetFrameRateLimit = func(a0 nvml.VgpuInstance) (uint32, nvml.Return) {
+			defer recordCall(calls, "VgpuInstanceGetFrameRateLimit", time.Now())
+			return f(a0)
+		}
+	}
+	{
+		f := nvml.VgpuInstanceGetGpuInstanceId
+		nvml.VgpuInstanceGetGpuInstanceId = func(a0 nvml.VgpuInstance) (int, nvml.Return) {
+			defer recordCall(calls, "VgpuInstanceGetGpuInstanceId", time.Now())
+			return f(a0)
+		}
+	}
+	{
+		f := nvml.VgpuInstanceGetGpuPciId
+		nvml.VgpuInstanceGetGpuPciId = func(a0 nvml.VgpuInstance) (string, nvml.Return) {
+			defer recordCall(calls, "VgpuInstanceGetGpuPciId", time.Now())
+			return f(a0)
+		}
+	}
+	{
+		f := nvml.VgpuInstanceGetLicenseInfo
+		nvml.VgpuInstanceGetLicenseInfo = func(a0 nvml.VgpuInstance) (nvml.VgpuLicenseInfo, nvml.Return) {
+			defer recordCall(calls, "VgpuInstanceGetLicenseInfo", time.Now())
+			return f(a0)
+		}
+	}
+	{
+		f := nvml.VgpuInstanceGetLicenseStatus
+		nvml.VgpuInstanceGetLicenseStatus = func(a0 nvml.VgpuInstance) (int, nvml.Return) {
+			defer recordCall(calls, "VgpuInstanceGetLicenseStatus", time.Now())
+			return f(a0)
+		}
+	}
+	{
+		f := nvml.VgpuInstanceGetMdevUUID
+		nvml.VgpuInstanceGetMdevUUID = func(a0 nvml.VgpuInstance) (string, nvml.Return) {
+			defer recordCall(calls, "VgpuInstanceGetMdevUUID", time.Now())
+			return f(a0)
+		}
+	}
+	{
+		f := nvml.VgpuInstanceGetMetadata
+		nvml.VgpuInstanceGetMetadata = func(a0 nvml.VgpuInstance) (nvml.VgpuMetadata, nvml.Return) {
+			defer recordCall(calls, "VgpuInstanceGetMetadata", time.Now())
+			return f(a0)
+		}
+	}
+	{
+		f := nvml.VgpuInstanceGetRuntimeStateSize
+		nvml.VgpuInstanceGetRuntimeStateSize = func(a0 nvml.VgpuInstance) (nvml.VgpuRuntimeState, nvml.Return) {
+			defer recordCall(calls, "VgpuInstanceGetRuntimeStateSize", time.Now())
+			return f(a0)
+		}
+	}
+	{
+		f := nvml.VgpuInstanceGetType
+		nvml.VgpuInstanceGetType = func(a0 nvml.VgpuInstance) (nvml.VgpuTypeId, nvml.Return) {
+			defer recordCall(calls, "VgpuInstanceGetType", time.Now())
+			return f(a0)
+		}
+	}
+	{
+		f := nvml.VgpuInstanceGetUUID
+		nvml.VgpuInstanceGetUUID = func(a0 nvml.VgpuInstance) (string, nvml.Return) {
+			defer recordCall(calls, "VgpuInstanceGetUUID", time.Now())
+			return f(a0)
+		}
+	}
+	{
+		f := nvml.VgpuInstanceGetVmDriverVersion
+		nvml.VgpuInstanceGetVmDriverVersion = func(a0 nvml.VgpuInstance) (string, nvml.Return) {
+			defer recordCall(calls, "VgpuInstanceGetVmDriverVersion", time.Now())
+			return f(a0)
+		}
+	}
+	{
+		f := nvml.VgpuInstanceGetVmID
+		nvml.VgpuInstanceGetVmID = func(a0 nvml.VgpuInstance) (string, nvml.VgpuVmIdType, nvml.Return) {
+			defer recordCall(calls, "VgpuInstanceGetVmID", time.Now())
+			return f(a0)
+		}
+	}
+	{
+		f := nvml.VgpuInstanceSetEncoderCapacity
+		nvml.VgpuInstanceSetEncoderCapacity = func(a0 nvml.VgpuInstance, a1 int) nvml.Return {
+			defer recordCall(calls, "VgpuInstanceSetEncoderCapacity", time.Now())
+			return f(a0, a1)
+		}
+	}
+	{
+		f := nvml.VgpuTypeGetBAR1Info
+		nvml.VgpuTypeGetBAR1Info = func(a0 nvml.VgpuTypeId) (nvml.VgpuTypeBar1Info, nvml.Return) {
+			defer recordCall(calls, "VgpuTypeGetBAR1Info", time.Now())
+			return f(a0)
+		}
+	}
+	{
+		f := nvml.VgpuTypeGetCapabilities
+		nvml.VgpuTypeGetCapabilities = func(a0 nvml.VgpuTypeId, a1 nvml.VgpuCapability) (bool, nvml.Return) {
+			defer recordCall(calls, "VgpuTypeGetCapabilities", time.Now())
+			return f(a0, a1)
+		}
+	}
+	{
+		f := nvml.VgpuTypeGetClass
+		nvml.VgpuTypeGetClass = func(a0 nvml.VgpuTypeId) (string, nvml.Return) {
+			defer recordCall(calls, "VgpuTypeGetClass", time.Now())
+			return f(a0)
+		}
+	}
+	{
+		f := nvml.VgpuTypeGetDeviceID
+		nvml.VgpuTypeGetDeviceID = func(a0 nvml.VgpuTypeId) (uint64, uint64, nvml.Return) {
+			defer recordCall(calls, "VgpuTypeGetDeviceID", time.Now())
+			return f(a0)
+		}
+	}
+	{
+		f := nvml.VgpuTypeGetFrameRateLimit
+		nvml.VgpuTypeGetFrameRateLimit = func(a0 nvml.VgpuTypeId) (uint32, nvml.Return) {
+			defer recordCall(calls, "VgpuTypeGetFrameRateLimit", time.Now())
+			return f(a0)
+		}
+	}
+	{
+		f := nvml.VgpuTypeGetFramebufferSize
+		nvml.VgpuTypeGetFramebufferSize = func(a0 nvml.VgpuTypeId) (uint64, nvml.Return) {
+			defer recordCall(calls, "VgpuTypeGetFramebufferSize", time.Now())
+			return f(a0)
+		}
+	}
+	{
+		f := nvml.VgpuTypeGetGpuInstanceProfileId
+		nvml.VgpuTypeGetGpuInstanceProfileId = func(a0 nvml.VgpuTypeId) (uint32, nvml.Return) {
+			defer recordCall(calls, "VgpuTypeGetGpuInstanceProfileId", time.Now())
+			return f(a0)
+		}
+	}
+	{
+		f := nvml.VgpuTypeGetID
+		nvml.VgpuTypeGetID = func(a0 nvml.VgpuTypeId) uint32 {
+			defer recordCall(calls, "VgpuTypeGetID", time.Now())
+			return f(a0)
+		}
+	}
+	{
+		f := nvml.VgpuTypeGetLicense
+		nvml.VgpuTypeGetLicense = func(a0 nvml.VgpuTypeId) (string, nvml.Return) {
+			defer recordCall(calls, "VgpuTypeGetLicense", time.Now())
+			return f(a0)
+		}
+	}
+	{
+		f := nvml.VgpuTypeGetMaxInstances
+		nvml.VgpuTypeGetMaxInstances = func(a0 nvml.Device, a1 nvml.VgpuTypeId) (int, nvml.Return) {
+			defer recordCall(calls, "VgpuTypeGetMaxInstances", time.Now())
+			return f(a0, a1)
+		}
+	}
+	{
+		f := nvml.VgpuTypeGetMaxInstancesPerGpuInstance
+		nvml.VgpuTypeGetMaxInstancesPerGpuInstance = func(a0 *nvml.VgpuTypeMaxInstance) nvml.Return {
+			defer recordCall(calls, "VgpuTypeGetMaxInstancesPerGpuInstance", time.Now())
+			return f(a0)
+		}
+	}
+	{
+		f := nvml.VgpuTypeGetMaxInstancesPerVm
+		nvml.VgpuTypeGetMaxInstancesPerVm = func(a0 nvml.VgpuTypeId) (int, nvml.Return) {
+			defer recordCall(calls, "VgpuTypeGetMaxInstancesPerVm", time.Now())
+			return f(a0)
+		}
+	}
+	{
+		f := nvml.VgpuTypeGetName
+		nvml.VgpuTypeGetName = func(a0 nvml.VgpuTypeId) (string, nvml.Return) {
+			defer recordCall(calls, "VgpuTypeGetName", time.Now())
+			return f(a0)
+		}
+	}
+	{
+		f := nvml.VgpuTypeGetNumDisplayHeads
+		nvml.VgpuTypeGetNumDisplayHeads = func(a0 nvml.VgpuTypeId) (int, nvml.Return) {
+			defer recordCall(calls, "VgpuTypeGetNumDisplayHeads", time.Now())
+			return f(a0)
+		}
+	}
+	{
+		f := nvml.VgpuTypeGetResolution
+		nvml.VgpuTypeGetResolution = func(a0 nvml.VgpuTypeId, a1 int) (uint32, uint32, nvml.Return) {
+			defer recordCall(calls, "VgpuTypeGetResolution", time.Now())
+			return f(a0, a1)
+		}
+	}
+}
@@ -0,0 +1,95 @@
+package main
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"reflect"
+	"testing"
+
+	"github.com/NVIDIA/go-nvml/pkg/nvml"
+	"github.com/NVIDIA/go-nvml/pkg/nvml/mock"
+)
+
+// TestBenchNVMLGenerated checks that bench_nvml.go times every method of
+// nvml.Device and every package-level function of nvml, so it doesn't fall
+// behind go-nvml upgrades.
+func TestBenchNVMLGenerated(t *testing.T) {
+	file, err := parser.ParseFile(token.NewFileSet(), "bench_nvml.go", nil, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	methods := make(map[string]bool)
+	functions := make(map[string]bool)
+	for _, decl := range file.Decls {
+		fn, ok := decl.(*ast.FuncDecl)
+		if !ok {
+			continue
+		}
+		if fn.Recv != nil {
+			methods[fn.Name.Name] = true
+			continue
+		}
+		ast.Inspect(fn.Body, func(n ast.Node) bool {
+			if assign, ok := n.(*ast.AssignStmt); ok {
+				if sel, ok := assign.Lhs[0].(*ast.SelectorExpr); ok {
+					functions[sel.Sel.Name] = true
+				}
+			}
+			return true
+		})
+	}
+
+	device := reflect.TypeFor[nvml.Device]()
+	for i := range device.NumMethod() {
+		if name := device.Method(i).Name; !methods[name] {
+			t.Errorf("timedDevice doesn't time %s, run go generate", name)
+		}
+	}
+	library := reflect.TypeFor[nvml.Interface]()
+	for i := range library.NumMethod() {
+		if name := library.Method(i).Name; !functions[name] {
+			t.Errorf("timeLibraryCalls doesn't time nvml.%s, run go generate", name)
+		}
+	}
+}
+
+func TestTimedDevice(t *testing.T) {
+	calls := make(map[string]*benchStats)
+	mig := &mock.Device{
+		GetMemoryInfoFunc: func() (nvml.Memory, nvml.Return) {
+			return nvml.Memory{}, nvml.SUCCESS
+		},
+	}
+	d := timedDevice{
+		Device: &mock.Device{
+			GetFanSpeedFunc: func() (uint32, nvml.Return) {
+				return 50, nvml.SUCCESS
+			},
+			GetMigDeviceHandleByIndexFunc: func(int) (nvml.Device, nvml.Return) {
+				return mig, nvml.SUCCESS
+			},
+		},
+		calls: calls,
+	}
+
+	for range 2 {
+		if speed, ret := d.GetFanSpeed(); speed != 50 || ret != nvml.SUCCESS {
+			t.Errorf("GetFanSpeed() = %d, %v, want 50, SUCCESS", speed, ret)
+		}
+	}
+	handle, ret := d.GetMigDeviceHandleByIndex(0)
+	if ret != nvml.SUCCESS {
+		t.Fatalf("GetMigDeviceHandleByIndex() = %v", ret)
+	}
+	if _, ok := handle.(timedDevice); !ok {
+		t.Fatalf("GetMigDeviceHandleByIndex() = %T, want timedDevice", handle)
+	}
+	handle.GetMemoryInfo()
+
+	for name, want := range map[string]int{"GetFanSpeed": 2, "GetMigDeviceHandleByIndex": 1, "GetMemoryInfo": 1} {
+		if s := calls[name]; s == nil || s.count != want {
+			t.Errorf("calls of %s = %v, want %d", name, s, want)
+		}
+	}
+}
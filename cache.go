@@ -0,0 +1,67 @@
+package main
+
+import (
+	"fmt"
+	"math"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+)
+
+// cachingGatherer serves the metric families gathered by the wrapped
+// Gatherer for interval before gathering again, so that scrapes arriving
+// more often than interval don't query NVML every time.
+type cachingGatherer struct {
+	prometheus.Gatherer
+	interval time.Duration
+
+	mu       sync.Mutex
+	families []*dto.MetricFamily
+	err      error
+	gathered time.Time
+}
+
+func newCachingGatherer(g prometheus.Gatherer, interval time.Duration) *cachingGatherer {
+	return &cachingGatherer{Gatherer: g, interval: interval}
+}
+
+// Gather implements prometheus.Gatherer. Concurrent calls while the cache is
+// being refreshed wait for and share the same result.
+func (g *cachingGatherer) Gather() ([]*dto.MetricFamily, error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if g.families == nil || time.Since(g.gathered) >= g.interval {
+		g.families, g.err = g.Gatherer.Gather()
+		g.gathered = time.Now()
+	}
+	return g.families, g.err
+}
+
+// maxAge returns how much longer the cached metric families are served.
+func (g *cachingGatherer) maxAge() time.Duration {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if g.families == nil {
+		return g.interval
+	}
+	remaining := g.interval - time.Since(g.gathered)
+	if remaining <= 0 {
+		return g.interval
+	}
+	return remaining
+}
+
+// Handler sets Cache-Control on responses of next to the remaining lifetime
+// of the cached metric families.
+func (g *cachingGatherer) Handler(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		seconds := int(math.Ceil(g.maxAge().Seconds()))
+		w.Header().Set("Cache-Control", fmt.Sprintf("private, max-age=%d", seconds))
+		next.ServeHTTP(w, r)
+	})
+}
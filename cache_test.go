@@ -0,0 +1,123 @@
+package main
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	dto "github.com/prometheus/client_model/go"
+)
+
+// countingGatherer returns its families and err and counts its calls.
+type countingGatherer struct {
+	families []*dto.MetricFamily
+	err      error
+	calls    atomic.Int32
+	delay    time.Duration
+}
+
+func (g *countingGatherer) Gather() ([]*dto.MetricFamily, error) {
+	g.calls.Add(1)
+	time.Sleep(g.delay)
+	return g.families, g.err
+}
+
+func TestCachingGatherer(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		families []*dto.MetricFamily
+		err      error
+		interval time.Duration
+		wait     time.Duration
+		calls    int32
+	}{
+		{"cached", []*dto.MetricFamily{family("a", dto.MetricType_GAUGE, gauge(1))}, nil, time.Hour, 0, 1},
+		{"expired", []*dto.MetricFamily{family("a", dto.MetricType_GAUGE, gauge(1))}, nil, 10 * time.Millisecond, 20 * time.Millisecond, 2},
+		{"zero interval", []*dto.MetricFamily{family("a", dto.MetricType_GAUGE, gauge(1))}, nil, 0, 0, 2},
+		{"cached error", []*dto.MetricFamily{family("a", dto.MetricType_GAUGE, gauge(1))}, errors.New("partial"), time.Hour, 0, 1},
+		// Without any families there is nothing to serve, so every
+		// call gathers again.
+		{"empty", nil, errors.New("failed"), time.Hour, 0, 2},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			inner := &countingGatherer{families: tc.families, err: tc.err}
+			g := newCachingGatherer(inner, tc.interval)
+			for range 2 {
+				families, err := g.Gather()
+				if !errors.Is(err, tc.err) {
+					t.Errorf("Gather() error = %v, want %v", err, tc.err)
+				}
+				if len(families) != len(tc.families) {
+					t.Errorf("Gather() = %d families, want %d", len(families), len(tc.families))
+				}
+				time.Sleep(tc.wait)
+			}
+			if calls := inner.calls.Load(); calls != tc.calls {
+				t.Errorf("wrapped Gather called %d times, want %d", calls, tc.calls)
+			}
+		})
+	}
+}
+
+func TestCachingGathererConcurrent(t *testing.T) {
+	inner := &countingGatherer{
+		families: []*dto.MetricFamily{family("a", dto.MetricType_GAUGE, gauge(1))},
+		delay:    10 * time.Millisecond,
+	}
+	g := newCachingGatherer(inner, time.Hour)
+	var wg sync.WaitGroup
+	for range 10 {
+		wg.Go(func() {
+			if _, err := g.Gather(); err != nil {
+				t.Errorf("Gather() = %v", err)
+			}
+		})
+	}
+	wg.Wait()
+	if calls := inner.calls.Load(); calls != 1 {
+		t.Errorf("wrapped Gather called %d times, want 1", calls)
+	}
+}
+
+func TestCachingGathererHandler(t *testing.T) {
+	inner := &countingGatherer{families: []*dto.MetricFamily{family("a", dto.MetricType_GAUGE, gauge(1))}}
+	g := newCachingGatherer(inner, time.Minute)
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if _, err := g.Gather(); err != nil {
+			t.Errorf("Gather() = %v", err)
+		}
+	})
+	h := g.Handler(next)
+
+	for _, want := range []string{
+		// Nothing is cached yet, so the response lives for the
+		// whole interval.
+		"private, max-age=60",
+		// The families gathered by the first request expire within
+		// the interval.
+		"private, max-age=60",
+	} {
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+		if got := rec.Header().Get("Cache-Control"); got != want {
+			t.Errorf("Cache-Control = %q, want %q", got, want)
+		}
+	}
+
+	g.mu.Lock()
+	g.gathered = time.Now().Add(-45 * time.Second)
+	g.mu.Unlock()
+	if got := g.maxAge(); got <= 14*time.Second || got > 15*time.Second {
+		t.Errorf("maxAge() = %v, want about 15s", got)
+	}
+	g.mu.Lock()
+	g.gathered = time.Now().Add(-2 * time.Minute)
+	g.mu.Unlock()
+	if got := g.maxAge(); got != time.Minute {
+		t.Errorf("maxAge() of expired cache = %v, want %v", got, time.Minute)
+	}
+}
@@ -0,0 +1,82 @@
+package main
+
+import (
+	"log/slog"
+	"strconv"
+
+	"github.com/NVIDIA/go-nvml/pkg/nvml"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+func init() {
+	registerCollector("clkmon", defaultEnabled, newClkmonCollector)
+}
+
+// clockTypeNames maps NVML clock types to the values of the domain label.
+var clockTypeNames = map[nvml.ClockType]string{
+	nvml.CLOCK_GRAPHICS: "graphics",
+	nvml.CLOCK_SM:       "sm",
+	nvml.CLOCK_MEM:      "memory",
+	nvml.CLOCK_VIDEO:    "video",
+}
+
+// clkmonCollector exports the faults detected by the clock monitors of each
+// device, which catch clock domains running out of specification on
+// marginal silicon.
+type clkmonCollector struct {
+	logger *slog.Logger
+
+	fault     *prometheus.Desc
+	faultMask *prometheus.Desc
+}
+
+func newClkmonCollector(logger *slog.Logger) collector {
+	return &clkmonCollector{
+		logger: logger,
+		fault: deviceDesc("clock_monitor", "fault",
+			"Whether the clock monitor detected a fault in the clock domain of the GPU device (1) or not (0).",
+			"domain"),
+		faultMask: deviceDesc("clock_monitor", "fault_mask",
+			"Mask of the faults detected by the clock monitor in the clock domain of the GPU device. Only exported for faulty domains.",
+			"domain"),
+	}
+}
+
+func (c *clkmonCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.fault
+	ch <- c.faultMask
+}
+
+func (c *clkmonCollector) Collect(ch chan<- prometheus.Metric, devices []*device) {
+	for _, d := range devices {
+		status, ret := d.GetClkMonStatus()
+		if ret != nvml.SUCCESS {
+			c.logger.Debug("failed to get clock monitor status", "uuid", d.uuid, "err", ret)
+			continue
+		}
+
+		// The list only holds the domains with faults.
+		faults := make(map[string]uint32)
+		for _, info := range status.ClkMonList[:min(int(status.ClkMonListSize), len(status.ClkMonList))] {
+			domain, ok := clockTypeNames[nvml.ClockType(info.ClkApiDomain)]
+			if !ok {
+				domain = strconv.FormatUint(uint64(info.ClkApiDomain), 10)
+			}
+			faults[domain] |= info.ClkDomainFaultMask
+		}
+		for _, domain := range clockTypeNames {
+			if _, ok := faults[domain]; !ok {
+				faults[domain] = 0
+			}
+		}
+
+		for domain, mask := range faults {
+			fault := 0.0
+			if mask != 0 {
+				fault = 1
+				ch <- prometheus.MustNewConstMetric(c.faultMask, prometheus.GaugeValue, float64(mask), d.labelsWith(domain)...)
+			}
+			ch <- prometheus.MustNewConstMetric(c.fault, prometheus.GaugeValue, fault, d.labelsWith(domain)...)
+		}
+	}
+}
@@ -0,0 +1,116 @@
+package main
+
+import (
+	"log/slog"
+
+	"github.com/NVIDIA/go-nvml/pkg/nvml"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+func init() {
+	registerCollector("clocks", defaultEnabled, newClocksCollector)
+}
+
+// clockEventReasons maps the reasons NVML reports for lowering the clocks of
+// a device to the values of the reason label.
+var clockEventReasons = map[uint64]string{
+	nvml.ClocksEventReasonGpuIdle:                   "gpu_idle",
+	nvml.ClocksEventReasonApplicationsClocksSetting: "applications_clocks_setting",
+	nvml.ClocksEventReasonSwPowerCap:                "sw_power_cap",
+	nvml.ClocksThrottleReasonHwSlowdown:             "hw_slowdown",
+	nvml.ClocksEventReasonSyncBoost:                 "sync_boost",
+	nvml.ClocksEventReasonSwThermalSlowdown:         "sw_thermal_slowdown",
+	nvml.ClocksThrottleReasonHwThermalSlowdown:      "hw_thermal_slowdown",
+	nvml.ClocksThrottleReasonHwPowerBrakeSlowdown:   "hw_power_brake_slowdown",
+	nvml.ClocksEventReasonDisplayClockSetting:       "display_clock_setting",
+}
+
+// clocksCollector exports the current and maximum clock speeds of each
+// device, why its clocks are lowered, its performance state and the clock
+// offsets configured on it, which are non-zero when the device is
+// overclocked or underclocked.
+type clocksCollector struct {
+	logger *slog.Logger
+
+	clock            *prometheus.Desc
+	maxClock         *prometheus.Desc
+	throttleReason   *prometheus.Desc
+	performanceState *prometheus.Desc
+	offset           *prometheus.Desc
+}
+
+func newClocksCollector(logger *slog.Logger) collector {
+	return &clocksCollector{
+		logger: logger,
+		clock: deviceDesc("clock", "megahertz",
+			"Current speed of the clock domain (graphics, sm, memory or video) of the GPU device in MHz.",
+			"domain"),
+		maxClock: deviceDesc("clock", "max_megahertz",
+			"Maximum speed of the clock domain (graphics, sm, memory or video) of the GPU device in MHz.",
+			"domain"),
+		throttleReason: deviceDesc("clock", "throttle_reason",
+			"Whether the clocks of the GPU device are lowered for the reason (1) or not (0).",
+			"reason"),
+		performanceState: deviceDesc("", "performance_state",
+			"Performance state of the GPU device, from 0 (P0, maximum performance) to 15 (P15, minimum performance)."),
+		offset: deviceDesc("clock", "offset_megahertz",
+			"Offset applied to the clock domain (graphics or memory) of the GPU device in MHz.",
+			"domain"),
+	}
+}
+
+func (c *clocksCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.clock
+	ch <- c.maxClock
+	ch <- c.throttleReason
+	ch <- c.performanceState
+	ch <- c.offset
+}
+
+func (c *clocksCollector) Collect(ch chan<- prometheus.Metric, devices []*device) {
+	for _, d := range devices {
+		for clockType, domain := range clockTypeNames {
+			if clock, ret := d.GetClockInfo(clockType); ret == nvml.SUCCESS {
+				ch <- prometheus.MustNewConstMetric(c.clock, prometheus.GaugeValue, float64(clock), d.labelsWith(domain)...)
+			} else {
+				c.logger.Debug("failed to get clock", "uuid", d.uuid, "domain", domain, "err", ret)
+			}
+			if clock, ret := d.GetMaxClockInfo(clockType); ret == nvml.SUCCESS {
+				ch <- prometheus.MustNewConstMetric(c.maxClock, prometheus.GaugeValue, float64(clock), d.labelsWith(domain)...)
+			} else {
+				c.logger.Debug("failed to get max clock", "uuid", d.uuid, "domain", domain, "err", ret)
+			}
+		}
+
+		if reasons, ret := d.GetCurrentClocksEventReasons(); ret == nvml.SUCCESS {
+			for mask, reason := range clockEventReasons {
+				throttled := 0.0
+				if reasons&mask != 0 {
+					throttled = 1
+				}
+				ch <- prometheus.MustNewConstMetric(c.throttleReason, prometheus.GaugeValue, throttled, d.labelsWith(reason)...)
+			}
+		} else {
+			c.logger.Debug("failed to get clock event reasons", "uuid", d.uuid, "err", ret)
+		}
+
+		if pstate, ret := d.GetPerformanceState(); ret != nvml.SUCCESS {
+			c.logger.Debug("failed to get performance state", "uuid", d.uuid, "err", ret)
+		} else if pstate != nvml.PSTATE_UNKNOWN {
+			ch <- prometheus.MustNewConstMetric(c.performanceState, prometheus.GaugeValue, float64(pstate), d.labels...)
+		}
+
+		// GetClockOffsets can't be asked for another clock type than
+		// graphics, so both domains use the VF offset calls.
+		if offset, ret := d.GetGpcClkVfOffset(); ret == nvml.SUCCESS {
+			ch <- prometheus.MustNewConstMetric(c.offset, prometheus.GaugeValue, float64(offset), d.labelsWith("graphics")...)
+		} else {
+			c.logger.Debug("failed to get graphics clock offset", "uuid", d.uuid, "err", ret)
+		}
+		if offset, ret := d.GetMemClkVfOffset(); ret == nvml.SUCCESS {
+			ch <- prometheus.MustNewConstMetric(c.offset, prometheus.GaugeValue, float64(offset), d.labelsWith("memory")...)
+		} else {
+			c.logger.Debug("failed to get memory clock offset", "uuid", d.uuid, "err", ret)
+		}
+	}
+}
@@ -0,0 +1,406 @@
+package main
+
+import (
+	"cmp"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"log/slog"
+	"math"
+	"net/http"
+	"net/url"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+)
+
+var (
+	cloudWatchNamespace = flag.String("push.cloudwatch.namespace", "",
+		"CloudWatch namespace to push the GPU metrics to. Pushing is disabled when unset.")
+	cloudWatchRegion = flag.String("push.cloudwatch.region", "",
+		"AWS region of CloudWatch. Defaults to AWS_REGION, AWS_DEFAULT_REGION or the region of the EC2 instance.")
+	cloudWatchInterval = flag.Duration("push.cloudwatch.interval", time.Minute,
+		"Interval between pushes to CloudWatch.")
+	cloudWatchDimensions = flag.String("push.cloudwatch.dimensions", "",
+		"Comma-separated name=value dimensions added to every metric pushed to CloudWatch, e.g. cluster=prod.")
+	cloudWatchLabels = flag.String("push.cloudwatch.labels", "uuid,model",
+		"Comma-separated metric labels pushed to CloudWatch as dimensions. Other labels are dropped.")
+)
+
+const (
+	// cloudWatchBatchSize is the maximum number of metrics of a PutMetricData
+	// request.
+	cloudWatchBatchSize = 1000
+	// cloudWatchMaxDimensions is the maximum number of dimensions of a metric.
+	cloudWatchMaxDimensions = 30
+
+	defaultIMDSEndpoint = "http://169.254.169.254"
+)
+
+// awsCredentials sign requests to AWS. Expiration is zero for credentials
+// that don't expire.
+type awsCredentials struct {
+	AccessKeyID     string `json:"AccessKeyId"`
+	SecretAccessKey string
+	Token           string
+	Expiration      time.Time
+}
+
+type cloudWatchDimension struct {
+	name, value string
+}
+
+// cloudWatchPusher periodically pushes the GPU metrics of a Gatherer to
+// CloudWatch with PutMetricData, for accounts without Prometheus. It exports
+// the outcome of its pushes as metrics itself.
+type cloudWatchPusher struct {
+	logger     *slog.Logger
+	client     *http.Client
+	namespace  string
+	dimensions []cloudWatchDimension
+	labels     []string
+	// imdsEndpoint is the address of the EC2 instance metadata service.
+	imdsEndpoint string
+
+	// mu guards region and creds, which are resolved on the first push and
+	// whenever the credentials expire.
+	mu     sync.Mutex
+	region string
+	creds  *awsCredentials
+
+	lastSuccess atomic.Int64
+	failures    atomic.Int64
+
+	lastSuccessDesc *prometheus.Desc
+	failuresDesc    *prometheus.Desc
+}
+
+// newCloudWatchPusher returns a pusher configured by the push.cloudwatch
+// flags.
+func newCloudWatchPusher(logger *slog.Logger) (*cloudWatchPusher, error) {
+	p := &cloudWatchPusher{
+		logger:       logger,
+		client:       &http.Client{Timeout: 30 * time.Second},
+		namespace:    *cloudWatchNamespace,
+		region:       *cloudWatchRegion,
+		imdsEndpoint: defaultIMDSEndpoint,
+		lastSuccessDesc: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, "cloudwatch", "last_push_success_timestamp_seconds"),
+			"Time of the last successful push to CloudWatch since unix epoch in seconds.",
+			nil, nil,
+		),
+		failuresDesc: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, "cloudwatch", "push_failures_total"),
+			"Number of failed pushes to CloudWatch.",
+			nil, nil,
+		),
+	}
+	if p.region == "" {
+		p.region = cmp.Or(os.Getenv("AWS_REGION"), os.Getenv("AWS_DEFAULT_REGION"))
+	}
+	for _, dim := range strings.Split(*cloudWatchDimensions, ",") {
+		if dim = strings.TrimSpace(dim); dim == "" {
+			continue
+		}
+		name, value, ok := strings.Cut(dim, "=")
+		if !ok || name == "" || value == "" {
+			return nil, fmt.Errorf("invalid CloudWatch dimension %q", dim)
+		}
+		p.dimensions = append(p.dimensions, cloudWatchDimension{name, value})
+	}
+	for _, label := range strings.Split(*cloudWatchLabels, ",") {
+		if label = strings.TrimSpace(label); label != "" {
+			p.labels = append(p.labels, label)
+		}
+	}
+	if len(p.dimensions) > cloudWatchMaxDimensions {
+		return nil, fmt.Errorf("at most %d CloudWatch dimensions are allowed", cloudWatchMaxDimensions)
+	}
+	return p, nil
+}
+
+// Run pushes the metrics gathered from g every interval until ctx is done.
+func (p *cloudWatchPusher) Run(ctx context.Context, g prometheus.Gatherer, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+		if err := p.push(ctx, g); err != nil {
+			p.failures.Add(1)
+			p.logger.Error("failed to push metrics to CloudWatch", "namespace", p.namespace, "err", err)
+			continue
+		}
+		p.lastSuccess.Store(time.Now().Unix())
+	}
+}
+
+// Describe implements prometheus.Collector.
+func (p *cloudWatchPusher) Describe(ch chan<- *prometheus.Desc) {
+	ch <- p.lastSuccessDesc
+	ch <- p.failuresDesc
+}
+
+// Collect implements prometheus.Collector.
+func (p *cloudWatchPusher) Collect(ch chan<- prometheus.Metric) {
+	if last := p.lastSuccess.Load(); last > 0 {
+		ch <- prometheus.MustNewConstMetric(p.lastSuccessDesc, prometheus.GaugeValue, float64(last))
+	}
+	ch <- prometheus.MustNewConstMetric(p.failuresDesc, prometheus.CounterValue, float64(p.failures.Load()))
+}
+
+// push gathers the nvidia_gpu metrics of g and sends them in batches.
+func (p *cloudWatchPusher) push(ctx context.Context, g prometheus.Gatherer) error {
+	families, err := g.Gather()
+	if err != nil {
+		// Push what was gathered, like a scrape with --web.error-handling=continue.
+		p.logger.Debug("failed to gather some metrics for CloudWatch", "err", err)
+	}
+	data := p.metricData(families, time.Now())
+
+	region, creds, err := p.credentials(ctx)
+	if err != nil {
+		return err
+	}
+	for _, form := range p.requests(data) {
+		if err := p.putMetricData(ctx, region, creds, form.Encode()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// requests returns the forms of the PutMetricData requests sending data, in
+// batches of at most cloudWatchBatchSize metrics.
+func (p *cloudWatchPusher) requests(data []url.Values) []url.Values {
+	var forms []url.Values
+	for batch := range slices.Chunk(data, cloudWatchBatchSize) {
+		form := url.Values{
+			"Action":    {"PutMetricData"},
+			"Version":   {"2010-08-01"},
+			"Namespace": {p.namespace},
+		}
+		for i, datum := range batch {
+			for key, values := range datum {
+				form[fmt.Sprintf("MetricData.member.%d.%s", i+1, key)] = values
+			}
+		}
+		forms = append(forms, form)
+	}
+	return forms
+}
+
+// metricData converts the gauge, counter and untyped samples of the
+// nvidia_gpu metric families to the form fields of CloudWatch metrics,
+// without the MetricData.member.N prefix.
+func (p *cloudWatchPusher) metricData(families []*dto.MetricFamily, now time.Time) []url.Values {
+	var data []url.Values
+	for _, mf := range families {
+		name := mf.GetName()
+		if !strings.HasPrefix(name, namespace+"_") || strings.HasPrefix(name, namespace+"_cloudwatch_") {
+			continue
+		}
+		for _, m := range mf.GetMetric() {
+			var value float64
+			switch mf.GetType() {
+			case dto.MetricType_GAUGE:
+				value = m.GetGauge().GetValue()
+			case dto.MetricType_COUNTER:
+				value = m.GetCounter().GetValue()
+			case dto.MetricType_UNTYPED:
+				value = m.GetUntyped().GetValue()
+			default:
+				continue
+			}
+			// CloudWatch rejects NaN and infinite values.
+			if math.IsNaN(value) || math.IsInf(value, 0) {
+				continue
+			}
+			timestamp := now
+			if m.TimestampMs != nil {
+				timestamp = time.UnixMilli(m.GetTimestampMs())
+			}
+
+			datum := url.Values{
+				"MetricName": {strings.TrimPrefix(name, namespace+"_")},
+				"Value":      {strconv.FormatFloat(value, 'g', -1, 64)},
+				"Timestamp":  {timestamp.UTC().Format(time.RFC3339)},
+			}
+			dims := slices.Clone(p.dimensions)
+			for _, label := range m.GetLabel() {
+				if label.GetValue() != "" && slices.Contains(p.labels, label.GetName()) {
+					dims = append(dims, cloudWatchDimension{label.GetName(), label.GetValue()})
+				}
+			}
+			for i, dim := range dims[:min(len(dims), cloudWatchMaxDimensions)] {
+				datum.Set(fmt.Sprintf("Dimensions.member.%d.Name", i+1), dim.name)
+				datum.Set(fmt.Sprintf("Dimensions.member.%d.Value", i+1), dim.value)
+			}
+			data = append(data, datum)
+		}
+	}
+	return data
+}
+
+// putMetricData sends a PutMetricData request with the form encoded body,
+// signed with AWS Signature Version 4.
+func (p *cloudWatchPusher) putMetricData(ctx context.Context, region string, creds *awsCredentials, body string) error {
+	host := "monitoring." + region + ".amazonaws.com"
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, "https://"+host+"/", strings.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded; charset=utf-8")
+	signAWSRequest(req, body, region, "monitoring", creds, time.Now())
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("%s: %s", resp.Status, strings.TrimSpace(string(msg)))
+	}
+	return nil
+}
+
+// signAWSRequest adds the AWS Signature Version 4 headers to req, whose
+// request body is body.
+func signAWSRequest(req *http.Request, body, region, service string, creds *awsCredentials, now time.Time) {
+	amzDate := now.UTC().Format("20060102T150405Z")
+	date := amzDate[:8]
+	req.Header.Set("X-Amz-Date", amzDate)
+	if creds.Token != "" {
+		req.Header.Set("X-Amz-Security-Token", creds.Token)
+	}
+
+	headers := []string{"content-type", "host", "x-amz-date"}
+	if creds.Token != "" {
+		headers = append(headers, "x-amz-security-token")
+	}
+	var canonicalHeaders strings.Builder
+	for _, h := range headers {
+		value := req.Header.Get(h)
+		if h == "host" {
+			value = req.URL.Host
+		}
+		fmt.Fprintf(&canonicalHeaders, "%s:%s\n", h, strings.TrimSpace(value))
+	}
+	signedHeaders := strings.Join(headers, ";")
+	bodyHash := sha256.Sum256([]byte(body))
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		req.URL.EscapedPath(),
+		req.URL.RawQuery,
+		canonicalHeaders.String(),
+		signedHeaders,
+		hex.EncodeToString(bodyHash[:]),
+	}, "\n")
+
+	scope := date + "/" + region + "/" + service + "/aws4_request"
+	requestHash := sha256.Sum256([]byte(canonicalRequest))
+	stringToSign := "AWS4-HMAC-SHA256\n" + amzDate + "\n" + scope + "\n" + hex.EncodeToString(requestHash[:])
+
+	key := []byte("AWS4" + creds.SecretAccessKey)
+	for _, part := range []string{date, region, service, "aws4_request"} {
+		key = hmacSHA256(key, part)
+	}
+	signature := hex.EncodeToString(hmacSHA256(key, stringToSign))
+	req.Header.Set("Authorization", fmt.Sprintf("AWS4-HMAC-SHA256 Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		creds.AccessKeyID, scope, signedHeaders, signature))
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
+
+// credentials returns the region and the credentials to push with. The
+// credentials are read from the AWS_ACCESS_KEY_ID, AWS_SECRET_ACCESS_KEY and
+// AWS_SESSION_TOKEN environment variables, or else from the instance profile
+// of the EC2 instance, which is refreshed before it expires.
+func (p *cloudWatchPusher) credentials(ctx context.Context) (string, *awsCredentials, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.creds != nil && (p.creds.Expiration.IsZero() || time.Until(p.creds.Expiration) > 5*time.Minute) {
+		return p.region, p.creds, nil
+	}
+	if id, secret := os.Getenv("AWS_ACCESS_KEY_ID"), os.Getenv("AWS_SECRET_ACCESS_KEY"); id != "" && secret != "" {
+		if p.region == "" {
+			return "", nil, errors.New("no AWS region configured")
+		}
+		p.creds = &awsCredentials{AccessKeyID: id, SecretAccessKey: secret, Token: os.Getenv("AWS_SESSION_TOKEN")}
+		return p.region, p.creds, nil
+	}
+
+	token, err := p.imds(ctx, http.MethodPut, "/latest/api/token", "")
+	if err != nil {
+		return "", nil, fmt.Errorf("no AWS credentials in the environment and the instance metadata service is unavailable: %w", err)
+	}
+	if p.region == "" {
+		if p.region, err = p.imds(ctx, http.MethodGet, "/latest/meta-data/placement/region", token); err != nil {
+			return "", nil, fmt.Errorf("failed to get region of the instance: %w", err)
+		}
+	}
+	role, err := p.imds(ctx, http.MethodGet, "/latest/meta-data/iam/security-credentials/", token)
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to get instance profile: %w", err)
+	}
+	role, _, _ = strings.Cut(role, "\n")
+	content, err := p.imds(ctx, http.MethodGet, "/latest/meta-data/iam/security-credentials/"+role, token)
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to get credentials of instance profile %q: %w", role, err)
+	}
+	creds := &awsCredentials{}
+	if err := json.Unmarshal([]byte(content), creds); err != nil {
+		return "", nil, fmt.Errorf("failed to parse credentials of instance profile %q: %w", role, err)
+	}
+	p.creds = creds
+	return p.region, p.creds, nil
+}
+
+// imds sends a request to the EC2 instance metadata service, with the
+// IMDSv2 session token unless requesting one.
+func (p *cloudWatchPusher) imds(ctx context.Context, method, path, token string) (string, error) {
+	req, err := http.NewRequestWithContext(ctx, method, p.imdsEndpoint+path, nil)
+	if err != nil {
+		return "", err
+	}
+	if token == "" {
+		req.Header.Set("X-aws-ec2-metadata-token-ttl-seconds", "21600")
+	} else {
+		req.Header.Set("X-aws-ec2-metadata-token", token)
+	}
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+	content, err := io.ReadAll(io.LimitReader(resp.Body, 1<<16))
+	if err != nil {
+		return "", err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("%s: %s", resp.Status, strings.TrimSpace(string(content)))
+	}
+	return strings.TrimSpace(string(content)), nil
+}
@@ -0,0 +1,267 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	dto "github.com/prometheus/client_model/go"
+	"google.golang.org/protobuf/proto"
+)
+
+// The credentials and time of the AWS Signature Version 4 test suite.
+var (
+	sigV4Credentials = &awsCredentials{AccessKeyID: "AKIDEXAMPLE", SecretAccessKey: "wJalrXUtnFEMI/K7MDENG+bPxRfiCYEXAMPLEKEY"}
+	sigV4Time        = time.Date(2015, 8, 30, 12, 36, 0, 0, time.UTC)
+)
+
+func TestSignAWSRequest(t *testing.T) {
+	for _, tc := range []struct {
+		name, method, url, contentType, body, region, service string
+		want                                                  string
+	}{
+		{
+			// The example of the Signature Version 4 signing process of the
+			// AWS General Reference.
+			name:        "iam list users",
+			method:      http.MethodGet,
+			url:         "https://iam.amazonaws.com/?Action=ListUsers&Version=2010-05-08",
+			contentType: "application/x-www-form-urlencoded; charset=utf-8",
+			region:      "us-east-1",
+			service:     "iam",
+			want:        "AWS4-HMAC-SHA256 Credential=AKIDEXAMPLE/20150830/us-east-1/iam/aws4_request, SignedHeaders=content-type;host;x-amz-date, Signature=5d672d79c15b13162d9279b0855cfba6789a8edb4c82c400e06b5924a6f2b5d7",
+		},
+		{
+			// post-x-www-form-urlencoded of the test suite.
+			name:        "form post",
+			method:      http.MethodPost,
+			url:         "https://example.amazonaws.com/",
+			contentType: "application/x-www-form-urlencoded",
+			body:        "Param1=value1",
+			region:      "us-east-1",
+			service:     "service",
+			want:        "AWS4-HMAC-SHA256 Credential=AKIDEXAMPLE/20150830/us-east-1/service/aws4_request, SignedHeaders=content-type;host;x-amz-date, Signature=ff11897932ad3f4e8b18135d722051e5ac45fc38421b1da7b9d196a0fe09473a",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			req, err := http.NewRequest(tc.method, tc.url, strings.NewReader(tc.body))
+			if err != nil {
+				t.Fatal(err)
+			}
+			req.Header.Set("Content-Type", tc.contentType)
+			signAWSRequest(req, tc.body, tc.region, tc.service, sigV4Credentials, sigV4Time)
+			if got := req.Header.Get("X-Amz-Date"); got != "20150830T123600Z" {
+				t.Errorf("X-Amz-Date = %q", got)
+			}
+			if got := req.Header.Get("Authorization"); got != tc.want {
+				t.Errorf("Authorization = %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestSignAWSRequestSessionToken(t *testing.T) {
+	req, err := http.NewRequest(http.MethodPost, "https://monitoring.us-east-1.amazonaws.com/", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	creds := *sigV4Credentials
+	creds.Token = "session-token"
+	signAWSRequest(req, "", "us-east-1", "monitoring", &creds, sigV4Time)
+	if got := req.Header.Get("X-Amz-Security-Token"); got != "session-token" {
+		t.Errorf("X-Amz-Security-Token = %q", got)
+	}
+	if got := req.Header.Get("Authorization"); !strings.Contains(got, "SignedHeaders=content-type;host;x-amz-date;x-amz-security-token,") {
+		t.Errorf("session token not signed: %q", got)
+	}
+}
+
+func TestCloudWatchMetricData(t *testing.T) {
+	p := &cloudWatchPusher{
+		dimensions: []cloudWatchDimension{{"cluster", "prod"}},
+		labels:     []string{"uuid", "model"},
+	}
+	now := time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
+	labels := []*dto.LabelPair{label("minor_number", "0"), label("uuid", "GPU-0"), label("model", "a100"), label("name", "")}
+	families := []*dto.MetricFamily{
+		family("nvidia_gpu_duty_cycle", dto.MetricType_GAUGE, gauge(42, labels...)),
+		family("nvidia_gpu_xid_errors_total", dto.MetricType_COUNTER, &dto.Metric{
+			Label:       []*dto.LabelPair{label("uuid", "GPU-0"), label("model", ""), label("xid", "79")},
+			Counter:     &dto.Counter{Value: proto.Float64(3)},
+			TimestampMs: proto.Int64(now.Add(-time.Minute).UnixMilli()),
+		}),
+		family("nvidia_gpu_power_usage_milliwatts", dto.MetricType_GAUGE, gauge(math.NaN(), labels...), gauge(math.Inf(1), labels...)),
+		family("nvidia_gpu_cloudwatch_push_failures_total", dto.MetricType_COUNTER, &dto.Metric{Counter: &dto.Counter{Value: proto.Float64(1)}}),
+		family("nvidia_gpu_collector_scrape_duration_seconds", dto.MetricType_HISTOGRAM, &dto.Metric{Histogram: &dto.Histogram{}}),
+		family("go_goroutines", dto.MetricType_GAUGE, gauge(10)),
+	}
+
+	data := p.metricData(families, now)
+	want := []url.Values{
+		{
+			"MetricName":                {"duty_cycle"},
+			"Value":                     {"42"},
+			"Timestamp":                 {"2024-05-01T10:00:00Z"},
+			"Dimensions.member.1.Name":  {"cluster"},
+			"Dimensions.member.1.Value": {"prod"},
+			"Dimensions.member.2.Name":  {"uuid"},
+			"Dimensions.member.2.Value": {"GPU-0"},
+			"Dimensions.member.3.Name":  {"model"},
+			"Dimensions.member.3.Value": {"a100"},
+		},
+		{
+			// Counters are pushed as their raw value, with the timestamp of
+			// the sample. Empty labels are no dimensions.
+			"MetricName":                {"xid_errors_total"},
+			"Value":                     {"3"},
+			"Timestamp":                 {"2024-05-01T09:59:00Z"},
+			"Dimensions.member.1.Name":  {"cluster"},
+			"Dimensions.member.1.Value": {"prod"},
+			"Dimensions.member.2.Name":  {"uuid"},
+			"Dimensions.member.2.Value": {"GPU-0"},
+		},
+	}
+	if len(data) != len(want) {
+		t.Fatalf("got %d metrics, want %d: %v", len(data), len(want), data)
+	}
+	for i := range want {
+		if got := data[i].Encode(); got != want[i].Encode() {
+			t.Errorf("metric %d = %s, want %s", i, got, want[i].Encode())
+		}
+	}
+}
+
+func TestCloudWatchMetricDataMaxDimensions(t *testing.T) {
+	p := &cloudWatchPusher{labels: []string{"uuid"}}
+	for i := range cloudWatchMaxDimensions {
+		p.dimensions = append(p.dimensions, cloudWatchDimension{fmt.Sprintf("d%d", i), "v"})
+	}
+	data := p.metricData([]*dto.MetricFamily{
+		family("nvidia_gpu_duty_cycle", dto.MetricType_GAUGE, gauge(1, label("uuid", "GPU-0"))),
+	}, time.Now())
+	if len(data) != 1 {
+		t.Fatalf("got %d metrics, want 1", len(data))
+	}
+	if data[0].Has(fmt.Sprintf("Dimensions.member.%d.Name", cloudWatchMaxDimensions+1)) {
+		t.Errorf("metric has more than %d dimensions", cloudWatchMaxDimensions)
+	}
+}
+
+func TestCloudWatchRequests(t *testing.T) {
+	p := &cloudWatchPusher{namespace: "GPU"}
+	data := make([]url.Values, 2*cloudWatchBatchSize+1)
+	for i := range data {
+		data[i] = url.Values{"MetricName": {"duty_cycle"}, "Value": {fmt.Sprint(i)}}
+	}
+
+	forms := p.requests(data)
+	if len(forms) != 3 {
+		t.Fatalf("got %d requests, want 3", len(forms))
+	}
+	for i, form := range forms {
+		if form.Get("Action") != "PutMetricData" || form.Get("Namespace") != "GPU" {
+			t.Errorf("request %d = %v", i, form)
+		}
+		// Members are numbered from 1 in every request.
+		if got, want := form.Get("MetricData.member.1.Value"), fmt.Sprint(i*cloudWatchBatchSize); got != want {
+			t.Errorf("request %d starts with value %s, want %s", i, got, want)
+		}
+	}
+	if !forms[1].Has(fmt.Sprintf("MetricData.member.%d.Value", cloudWatchBatchSize)) ||
+		forms[1].Has(fmt.Sprintf("MetricData.member.%d.Value", cloudWatchBatchSize+1)) {
+		t.Errorf("full request doesn't hold %d metrics", cloudWatchBatchSize)
+	}
+	if forms[2].Has("MetricData.member.2.Value") {
+		t.Error("last request holds more than the remaining metric")
+	}
+	if forms := p.requests(nil); len(forms) != 0 {
+		t.Errorf("got %d requests without metrics", len(forms))
+	}
+}
+
+func TestCloudWatchCredentialsEnvironment(t *testing.T) {
+	t.Setenv("AWS_ACCESS_KEY_ID", "AKIDEXAMPLE")
+	t.Setenv("AWS_SECRET_ACCESS_KEY", "secret")
+	t.Setenv("AWS_SESSION_TOKEN", "token")
+
+	p := &cloudWatchPusher{region: "eu-west-1", imdsEndpoint: "http://127.0.0.1:0"}
+	region, creds, err := p.credentials(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if region != "eu-west-1" || creds.AccessKeyID != "AKIDEXAMPLE" || creds.SecretAccessKey != "secret" || creds.Token != "token" {
+		t.Errorf("credentials() = %s, %+v", region, creds)
+	}
+
+	p = &cloudWatchPusher{imdsEndpoint: "http://127.0.0.1:0"}
+	if _, _, err := p.credentials(context.Background()); err == nil {
+		t.Error("credentials() without a region succeeded")
+	}
+}
+
+func TestCloudWatchCredentialsIMDS(t *testing.T) {
+	t.Setenv("AWS_ACCESS_KEY_ID", "")
+	t.Setenv("AWS_SECRET_ACCESS_KEY", "")
+
+	expiration := time.Now().Add(time.Hour).UTC().Truncate(time.Second)
+	requests := 0
+	imds := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if r.URL.Path == "/latest/api/token" {
+			if r.Method != http.MethodPut || r.Header.Get("X-aws-ec2-metadata-token-ttl-seconds") == "" {
+				http.Error(w, "bad token request", http.StatusBadRequest)
+				return
+			}
+			fmt.Fprint(w, "imds-token")
+			return
+		}
+		if r.Header.Get("X-aws-ec2-metadata-token") != "imds-token" {
+			http.Error(w, "missing token", http.StatusUnauthorized)
+			return
+		}
+		switch r.URL.Path {
+		case "/latest/meta-data/placement/region":
+			fmt.Fprint(w, "us-west-2")
+		case "/latest/meta-data/iam/security-credentials/":
+			fmt.Fprint(w, "gpu-node\n")
+		case "/latest/meta-data/iam/security-credentials/gpu-node":
+			fmt.Fprintf(w, `{"Code": "Success", "AccessKeyId": "ASIAEXAMPLE", "SecretAccessKey": "secret", "Token": "token", "Expiration": %q}`,
+				expiration.Format(time.RFC3339))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer imds.Close()
+
+	p := &cloudWatchPusher{client: imds.Client(), logger: slog.New(slog.DiscardHandler), imdsEndpoint: imds.URL}
+	region, creds, err := p.credentials(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if region != "us-west-2" || creds.AccessKeyID != "ASIAEXAMPLE" || creds.Token != "token" || !creds.Expiration.Equal(expiration) {
+		t.Errorf("credentials() = %s, %+v", region, creds)
+	}
+
+	// Credentials are reused until they are about to expire.
+	before := requests
+	if _, _, err := p.credentials(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if requests != before {
+		t.Errorf("unexpired credentials were fetched again")
+	}
+	p.creds.Expiration = time.Now().Add(time.Minute)
+	if _, _, err := p.credentials(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if requests == before {
+		t.Errorf("expiring credentials weren't refreshed")
+	}
+}
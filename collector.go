@@ -0,0 +1,108 @@
+package main
+
+import (
+	"fmt"
+	"log/slog"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// collector collects a group of related metrics for every device.
+type collector interface {
+	// Describe sends the descriptors of all metrics the collector exports.
+	Describe(ch chan<- *prometheus.Desc)
+	// Collect sends the metrics of the given devices. Collect is never
+	// called concurrently on the same collector.
+	Collect(ch chan<- prometheus.Metric, devices []*device)
+}
+
+// nvmlIndependent is implemented by collectors that don't query the local
+// NVML. They also run when NVML is unavailable, and are passed no devices.
+type nvmlIndependent interface {
+	nvmlIndependent()
+}
+
+// stopper is implemented by collectors that run goroutines between
+// collections. stop ends them when the collector is disabled or the exporter
+// shuts down; the next collection may start them again.
+type stopper interface {
+	stop()
+}
+
+// Values of the isDefaultEnabled argument of registerCollector.
+const (
+	defaultEnabled  = true
+	defaultDisabled = false
+)
+
+var (
+	// collectorFactories holds the constructor of each collector by name.
+	collectorFactories = make(map[string]func(logger *slog.Logger) collector)
+	// collectorDefaults holds whether each collector is enabled by default.
+	collectorDefaults = make(map[string]bool)
+	// collectorIntervals holds the default refresh interval of collectors
+	// too slow to run at scrape time.
+	collectorIntervals = make(map[string]time.Duration)
+)
+
+// registerCollector makes a collector available under name. It is called
+// from the init function of the file implementing the collector.
+func registerCollector(name string, isDefaultEnabled bool, factory func(logger *slog.Logger) collector) {
+	collectorFactories[name] = factory
+	collectorDefaults[name] = isDefaultEnabled
+}
+
+// collectorNames returns the names of all collectors in sorted order.
+func collectorNames() []string {
+	names := make([]string, 0, len(collectorFactories))
+	for name := range collectorFactories {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// parseCollectorList parses a comma-separated list of collector names. It
+// fails on unknown names.
+func parseCollectorList(list string) ([]string, error) {
+	var names []string
+	for _, name := range strings.Split(list, ",") {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			continue
+		}
+		if _, ok := collectorFactories[name]; !ok {
+			return nil, fmt.Errorf("unknown collector %q", name)
+		}
+		names = append(names, name)
+	}
+	return names, nil
+}
+
+// applyCollectorLists updates disabled, which holds whether each collector is
+// disabled, with the comma-separated lists of --collector.enable and
+// --collector.disable. A collector can't be in both lists.
+func applyCollectorLists(disabled map[string]bool, enableList, disableList string) error {
+	enable, err := parseCollectorList(enableList)
+	if err != nil {
+		return fmt.Errorf("invalid --collector.enable: %w", err)
+	}
+	disable, err := parseCollectorList(disableList)
+	if err != nil {
+		return fmt.Errorf("invalid --collector.disable: %w", err)
+	}
+	for _, name := range enable {
+		if slices.Contains(disable, name) {
+			return fmt.Errorf("collector %q is both enabled and disabled", name)
+		}
+		disabled[name] = false
+	}
+	for _, name := range disable {
+		disabled[name] = true
+	}
+	return nil
+}
@@ -0,0 +1,75 @@
+package main
+
+import (
+	"maps"
+	"slices"
+	"testing"
+)
+
+func TestParseCollectorList(t *testing.T) {
+	for _, tc := range []struct {
+		list  string
+		want  []string
+		valid bool
+	}{
+		{"", nil, true},
+		{"ecc", []string{"ecc"}, true},
+		{" ecc , pcie,,clocks ", []string{"ecc", "pcie", "clocks"}, true},
+		{",", nil, true},
+		{"ecc,unknown", nil, false},
+		{"ECC", nil, false},
+	} {
+		got, err := parseCollectorList(tc.list)
+		if valid := err == nil; valid != tc.valid {
+			t.Errorf("parseCollectorList(%q) = %v, want valid %v", tc.list, err, tc.valid)
+			continue
+		}
+		if !slices.Equal(got, tc.want) {
+			t.Errorf("parseCollectorList(%q) = %q, want %q", tc.list, got, tc.want)
+		}
+	}
+}
+
+func TestCollectorNames(t *testing.T) {
+	names := collectorNames()
+	if !slices.IsSorted(names) {
+		t.Errorf("collectorNames() = %q, want sorted", names)
+	}
+	for _, name := range []string{"memory", "utilization", "power", "clocks", "pcie", "ecc", "nvlink", "remote", "xid"} {
+		if !slices.Contains(names, name) {
+			t.Errorf("collector %q is not registered", name)
+		}
+	}
+	for name, enabled := range map[string]bool{"memory": true, "ecc": true, "processes": false, "profiling": false, "remote": false} {
+		if collectorDefaults[name] != enabled {
+			t.Errorf("collector %q enabled by default = %v, want %v", name, collectorDefaults[name], enabled)
+		}
+	}
+}
+
+func TestApplyCollectorLists(t *testing.T) {
+	for _, tc := range []struct {
+		enable, disable string
+		want            map[string]bool
+		valid           bool
+	}{
+		{"", "", map[string]bool{"ecc": false, "processes": true}, true},
+		{"processes", "", map[string]bool{"ecc": false, "processes": false}, true},
+		{"", "ecc", map[string]bool{"ecc": true, "processes": true}, true},
+		{"processes", "ecc", map[string]bool{"ecc": true, "processes": false}, true},
+		{"ecc", "ecc", nil, false},
+		{"processes,ecc", "pcie,ecc", nil, false},
+		{"unknown", "", nil, false},
+		{"", "unknown", nil, false},
+	} {
+		disabled := map[string]bool{"ecc": false, "processes": true}
+		err := applyCollectorLists(disabled, tc.enable, tc.disable)
+		if valid := err == nil; valid != tc.valid {
+			t.Errorf("applyCollectorLists(%q, %q) = %v, want valid %v", tc.enable, tc.disable, err, tc.valid)
+			continue
+		}
+		if tc.valid && !maps.Equal(disabled, tc.want) {
+			t.Errorf("applyCollectorLists(%q, %q) disabled = %v, want %v", tc.enable, tc.disable, disabled, tc.want)
+		}
+	}
+}
@@ -0,0 +1,185 @@
+package main
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"syscall"
+
+	"github.com/fsnotify/fsnotify"
+	"go.yaml.in/yaml/v3"
+)
+
+// Config is the content of the configuration file.
+type Config struct {
+	// LogLevel overrides the --log.level flag when set.
+	LogLevel string `yaml:"log_level"`
+	// DerivedMetrics are per-device metrics computed from other metrics.
+	DerivedMetrics []DerivedMetricConfig `yaml:"derived_metrics"`
+	// Devices holds operator metadata about devices, keyed by uuid.
+	Devices map[string]DeviceMetadata `yaml:"devices"`
+	// RemoteHosts are hosts whose GPUs are collected over SSH.
+	RemoteHosts []RemoteHostConfig `yaml:"remote_hosts"`
+	// Labels are constant labels added to every exported metric.
+	Labels map[string]string `yaml:"labels"`
+
+	derivedMetrics []derivedMetric
+	// devices is Devices keyed by lowercase uuid.
+	devices map[string]DeviceMetadata
+}
+
+// loadConfig reads and validates the configuration file at path.
+func loadConfig(path string) (*Config, []byte, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	cfg := &Config{}
+	decoder := yaml.NewDecoder(bytes.NewReader(content))
+	decoder.KnownFields(true)
+	if err := decoder.Decode(cfg); err != nil && !errors.Is(err, io.EOF) {
+		return nil, nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+
+	if cfg.LogLevel != "" {
+		var level slog.Level
+		if err := level.UnmarshalText([]byte(cfg.LogLevel)); err != nil {
+			return nil, nil, fmt.Errorf("invalid log_level %q: %w", cfg.LogLevel, err)
+		}
+	}
+	if cfg.derivedMetrics, err = parseDerivedMetrics(cfg.DerivedMetrics); err != nil {
+		return nil, nil, err
+	}
+	if err := validateRemoteHosts(cfg.RemoteHosts); err != nil {
+		return nil, nil, err
+	}
+	if err := validateConstLabels(cfg.Labels); err != nil {
+		return nil, nil, err
+	}
+	// Derived metrics and device metadata find the device of a series by its
+	// uuid label.
+	if (len(cfg.DerivedMetrics) > 0 || len(cfg.Devices) > 0) && !slices.Contains(deviceLabels, "uuid") {
+		return nil, nil, errors.New("derived_metrics and devices require the uuid label in --labels.device")
+	}
+	cfg.devices = make(map[string]DeviceMetadata, len(cfg.Devices))
+	for uuid, md := range cfg.Devices {
+		key := strings.ToLower(uuid)
+		if _, dup := cfg.devices[key]; dup {
+			return nil, nil, fmt.Errorf("duplicate device %q", uuid)
+		}
+		cfg.devices[key] = md
+	}
+	return cfg, content, nil
+}
+
+// configReloader loads the configuration file and hands it to apply, on
+// startup and whenever a reload is triggered.
+type configReloader struct {
+	path   string
+	logger *slog.Logger
+	apply  func(*Config)
+
+	mu   sync.Mutex
+	hash [sha256.Size]byte
+}
+
+func newConfigReloader(path string, logger *slog.Logger, apply func(*Config)) *configReloader {
+	return &configReloader{path: path, logger: logger, apply: apply}
+}
+
+// Reload loads the configuration file and applies it if its content changed
+// since the last successful reload. An invalid file leaves the current
+// configuration in place.
+func (r *configReloader) Reload() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	cfg, content, err := loadConfig(r.path)
+	if err != nil {
+		return err
+	}
+	hash := sha256.Sum256(content)
+	if hash == r.hash {
+		return nil
+	}
+	r.apply(cfg)
+	r.hash = hash
+	r.logger.Info("loaded configuration file", "file", r.path)
+	return nil
+}
+
+// Hash returns the hex-encoded SHA-256 hash of the content of the
+// configuration file currently applied, or "" before the first successful
+// reload.
+func (r *configReloader) Hash() string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.hash == [sha256.Size]byte{} {
+		return ""
+	}
+	return hex.EncodeToString(r.hash[:])
+}
+
+// reload calls Reload and logs its error, for use by the reload triggers.
+func (r *configReloader) reload(trigger string) {
+	if err := r.Reload(); err != nil {
+		r.logger.Error("failed to reload configuration file", "file", r.path, "trigger", trigger, "err", err)
+	}
+}
+
+// WatchSignals reloads the configuration on SIGHUP.
+func (r *configReloader) WatchSignals() {
+	hup := make(chan os.Signal, 1)
+	signal.Notify(hup, syscall.SIGHUP)
+	for range hup {
+		r.reload("SIGHUP")
+	}
+}
+
+// WatchFile reloads the configuration whenever the configuration file
+// changes. It watches the parent directory rather than the file itself, so
+// editors that replace the file and Kubernetes ConfigMap mounts, which swap a
+// symlink to a new directory, are both picked up.
+func (r *configReloader) WatchFile() error {
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return err
+	}
+	if err := watcher.Add(filepath.Dir(r.path)); err != nil {
+		watcher.Close()
+		return err
+	}
+
+	go func() {
+		defer watcher.Close()
+		for {
+			select {
+			case event, ok := <-watcher.Events:
+				if !ok {
+					return
+				}
+				if event.Has(fsnotify.Chmod) {
+					continue
+				}
+				r.reload("fsnotify")
+			case err, ok := <-watcher.Errors:
+				if !ok {
+					return
+				}
+				r.logger.Error("configuration file watcher failed", "file", r.path, "err", err)
+			}
+		}
+	}()
+	return nil
+}
@@ -0,0 +1,69 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// configInfoCollector exports the running configuration of the exporter as
+// an info metric, so configuration drift across a fleet can be detected from
+// Prometheus.
+type configInfoCollector struct {
+	exporter *Exporter
+	// reloader is nil when no configuration file is used.
+	reloader *configReloader
+
+	include     string
+	exclude     string
+	minInterval time.Duration
+
+	info *prometheus.Desc
+}
+
+func newConfigInfoCollector(e *Exporter, reloader *configReloader, include, exclude string, minInterval time.Duration) *configInfoCollector {
+	return &configInfoCollector{
+		exporter:    e,
+		reloader:    reloader,
+		include:     include,
+		exclude:     exclude,
+		minInterval: minInterval,
+		info: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, "exporter", "config_info"),
+			"Always 1. Labeled with the enabled collectors, the background refresh intervals, the metric filters, the minimum scrape interval and a hash of the loaded configuration file.",
+			[]string{"collectors", "intervals", "metrics_include", "metrics_exclude", "min_scrape_interval", "config_hash"}, nil,
+		),
+	}
+}
+
+func (c *configInfoCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.info
+}
+
+func (c *configInfoCollector) Collect(ch chan<- prometheus.Metric) {
+	// Collectors are sorted by name, and can be toggled at runtime.
+	var enabled, intervals []string
+	for _, s := range c.exporter.Collectors() {
+		if !s.Enabled {
+			continue
+		}
+		enabled = append(enabled, s.Name)
+		if s.Interval > 0 {
+			intervals = append(intervals, fmt.Sprintf("%s=%v", s.Name, s.Interval))
+		}
+	}
+	hash := ""
+	if c.reloader != nil {
+		hash = c.reloader.Hash()
+	}
+	ch <- prometheus.MustNewConstMetric(c.info, prometheus.GaugeValue, 1,
+		strings.Join(enabled, ","),
+		strings.Join(intervals, ","),
+		c.include,
+		c.exclude,
+		c.minInterval.String(),
+		hash,
+	)
+}
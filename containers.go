@@ -0,0 +1,92 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+var containerRunDir = flag.String("collector.container-run-dir", "/run",
+	"Directory of the container runtime state, holding the OCI bundles of the containers with their pod annotations. Used by the processes and dra collectors.")
+
+// containerBundles are the paths of the OCI bundle config of a container by
+// id relative to --collector.container-run-dir, for containerd and CRI-O.
+var containerBundles = []string{
+	"containerd/io.containerd.runtime.v2.task/k8s.io/%s/config.json",
+	"containers/storage/overlay-containers/%s/userdata/config.json",
+}
+
+// podAnnotations are the annotations the container runtimes set on the OCI
+// bundle of a Kubernetes container, for containerd and CRI-O.
+var podAnnotations = []struct {
+	namespace, pod, podUID, container string
+}{
+	{"io.kubernetes.cri.sandbox-namespace", "io.kubernetes.cri.sandbox-name", "io.kubernetes.cri.sandbox-uid", "io.kubernetes.cri.container-name"},
+	{"io.kubernetes.pod.namespace", "io.kubernetes.pod.name", "io.kubernetes.pod.uid", "io.kubernetes.container.name"},
+}
+
+// kubernetesContainer identifies a container of a pod.
+type kubernetesContainer struct {
+	namespace, pod, podUID, container string
+}
+
+// readBundleContainer returns the Kubernetes container with the given id from
+// its OCI bundle. It returns an error satisfying os.IsNotExist if no runtime
+// has a bundle for id.
+func readBundleContainer(id string) (kubernetesContainer, error) {
+	for _, bundle := range containerBundles {
+		content, err := os.ReadFile(filepath.Join(*containerRunDir, fmt.Sprintf(bundle, id)))
+		if os.IsNotExist(err) {
+			continue
+		}
+		if err != nil {
+			return kubernetesContainer{}, err
+		}
+		return parseBundleContainer(content)
+	}
+	return kubernetesContainer{}, os.ErrNotExist
+}
+
+// bundleContainers returns the Kubernetes containers of every OCI bundle of
+// the container runtimes. Bundles that can't be read are skipped.
+func bundleContainers() []kubernetesContainer {
+	var containers []kubernetesContainer
+	for _, bundle := range containerBundles {
+		paths, _ := filepath.Glob(filepath.Join(*containerRunDir, fmt.Sprintf(bundle, "*")))
+		for _, path := range paths {
+			content, err := os.ReadFile(path)
+			if err != nil {
+				continue
+			}
+			if container, err := parseBundleContainer(content); err == nil && container.pod != "" {
+				containers = append(containers, container)
+			}
+		}
+	}
+	return containers
+}
+
+// parseBundleContainer returns the Kubernetes container described by the
+// annotations of an OCI bundle config.json. It is empty for containers that
+// aren't managed by the kubelet.
+func parseBundleContainer(content []byte) (kubernetesContainer, error) {
+	var spec struct {
+		Annotations map[string]string `json:"annotations"`
+	}
+	if err := json.Unmarshal(content, &spec); err != nil {
+		return kubernetesContainer{}, err
+	}
+	for _, keys := range podAnnotations {
+		if pod := spec.Annotations[keys.pod]; pod != "" {
+			return kubernetesContainer{
+				namespace: spec.Annotations[keys.namespace],
+				pod:       pod,
+				podUID:    spec.Annotations[keys.podUID],
+				container: spec.Annotations[keys.container],
+			}, nil
+		}
+	}
+	return kubernetesContainer{}, nil
+}
@@ -0,0 +1,89 @@
+package main
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestParseBundleContainer(t *testing.T) {
+	for _, tc := range []struct {
+		name, config string
+		want         kubernetesContainer
+		err          bool
+	}{
+		{
+			name: "containerd",
+			config: `{"ociVersion":"1.1.0","annotations":{
+				"io.kubernetes.cri.container-type":"container",
+				"io.kubernetes.cri.container-name":"trainer",
+				"io.kubernetes.cri.sandbox-name":"train-0",
+				"io.kubernetes.cri.sandbox-namespace":"ml",
+				"io.kubernetes.cri.sandbox-uid":"1b2c"}}`,
+			want: kubernetesContainer{namespace: "ml", pod: "train-0", podUID: "1b2c", container: "trainer"},
+		},
+		{
+			name: "cri-o",
+			config: `{"ociVersion":"1.0.2","annotations":{
+				"io.kubernetes.container.name":"trainer",
+				"io.kubernetes.pod.name":"train-0",
+				"io.kubernetes.pod.namespace":"ml",
+				"io.kubernetes.pod.uid":"1b2c"}}`,
+			want: kubernetesContainer{namespace: "ml", pod: "train-0", podUID: "1b2c", container: "trainer"},
+		},
+		{name: "not kubernetes", config: `{"ociVersion":"1.1.0","annotations":{"org.opencontainers.image.title":"x"}}`},
+		{name: "no annotations", config: `{"ociVersion":"1.1.0"}`},
+		{name: "invalid", config: `{`, err: true},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := parseBundleContainer([]byte(tc.config))
+			if (err != nil) != tc.err {
+				t.Fatalf("parseBundleContainer() error = %v, want error %v", err, tc.err)
+			}
+			if got != tc.want {
+				t.Errorf("parseBundleContainer() = %+v, want %+v", got, tc.want)
+			}
+		})
+	}
+}
+
+// writeBundle writes an OCI bundle config with the given annotations to path
+// relative to --collector.container-run-dir.
+func writeBundle(t *testing.T, path string, annotations map[string]string) {
+	t.Helper()
+	content, err := json.Marshal(map[string]any{"ociVersion": "1.1.0", "annotations": annotations})
+	if err != nil {
+		t.Fatal(err)
+	}
+	path = filepath.Join(*containerRunDir, path)
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, content, 0o600); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestReadBundleContainer(t *testing.T) {
+	defer func(dir string) { *containerRunDir = dir }(*containerRunDir)
+	*containerRunDir = t.TempDir()
+	writeBundle(t, "containers/storage/overlay-containers/cccc/userdata/config.json", map[string]string{
+		"io.kubernetes.pod.name":       "train-0",
+		"io.kubernetes.pod.namespace":  "ml",
+		"io.kubernetes.pod.uid":        "1b2c",
+		"io.kubernetes.container.name": "trainer",
+	})
+	writeBundle(t, "containerd/io.containerd.runtime.v2.task/k8s.io/dddd/config.json", nil)
+
+	container, err := readBundleContainer("cccc")
+	if want := (kubernetesContainer{namespace: "ml", pod: "train-0", podUID: "1b2c", container: "trainer"}); err != nil || container != want {
+		t.Errorf("readBundleContainer() = %+v, %v, want %+v", container, err, want)
+	}
+	if _, err := readBundleContainer("eeee"); !os.IsNotExist(err) {
+		t.Errorf("readBundleContainer() of a missing bundle = %v", err)
+	}
+	if containers := bundleContainers(); len(containers) != 1 || containers[0].pod != "train-0" {
+		t.Errorf("bundleContainers() = %+v, want only train-0", containers)
+	}
+}
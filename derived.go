@@ -0,0 +1,328 @@
+package main
+
+import (
+	"fmt"
+	"log/slog"
+	"math"
+	"regexp"
+	"slices"
+	"strconv"
+	"sync"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"google.golang.org/protobuf/proto"
+)
+
+// DerivedMetricConfig defines a per-device metric computed from other
+// per-device metrics at collection time.
+type DerivedMetricConfig struct {
+	// Name is the name of the exported metric.
+	Name string `yaml:"name"`
+	// Help is the help text of the exported metric.
+	Help string `yaml:"help"`
+	// Expr is an arithmetic expression over the names of other metrics,
+	// e.g. "nvidia_gpu_memory_used_bytes / nvidia_gpu_memory_total_bytes".
+	Expr string `yaml:"expr"`
+}
+
+var metricNameRE = regexp.MustCompile(`^[a-zA-Z_:][a-zA-Z0-9_:]*$`)
+
+// derivedMetric is a parsed DerivedMetricConfig.
+type derivedMetric struct {
+	name string
+	help string
+	expr expression
+}
+
+// parseDerivedMetrics validates and parses the derived metric definitions.
+func parseDerivedMetrics(configs []DerivedMetricConfig) ([]derivedMetric, error) {
+	metrics := make([]derivedMetric, 0, len(configs))
+	seen := make(map[string]bool, len(configs))
+	for _, c := range configs {
+		if !metricNameRE.MatchString(c.Name) {
+			return nil, fmt.Errorf("invalid derived metric name %q", c.Name)
+		}
+		if seen[c.Name] {
+			return nil, fmt.Errorf("duplicate derived metric %q", c.Name)
+		}
+		seen[c.Name] = true
+
+		expr, err := parseExpression(c.Expr)
+		if err != nil {
+			return nil, fmt.Errorf("derived metric %q: %w", c.Name, err)
+		}
+		help := c.Help
+		if help == "" {
+			help = "Derived from " + c.Expr + "."
+		}
+		metrics = append(metrics, derivedMetric{name: c.Name, help: help, expr: expr})
+	}
+	return metrics, nil
+}
+
+// derivedGatherer adds derived metrics to the metric families gathered from
+// the wrapped Gatherer. The expressions are evaluated once per device, with
+// the device identified by its uuid label.
+type derivedGatherer struct {
+	prometheus.Gatherer
+	logger *slog.Logger
+
+	mu      sync.RWMutex
+	metrics []derivedMetric
+}
+
+func newDerivedGatherer(g prometheus.Gatherer, logger *slog.Logger) *derivedGatherer {
+	return &derivedGatherer{Gatherer: g, logger: logger}
+}
+
+// SetMetrics replaces the derived metric definitions.
+func (g *derivedGatherer) SetMetrics(metrics []derivedMetric) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.metrics = metrics
+}
+
+// Gather implements prometheus.Gatherer.
+func (g *derivedGatherer) Gather() ([]*dto.MetricFamily, error) {
+	families, err := g.Gatherer.Gather()
+
+	g.mu.RLock()
+	metrics := g.metrics
+	g.mu.RUnlock()
+	if len(metrics) == 0 {
+		return families, err
+	}
+
+	// values maps a device uuid to the value of each metric that has exactly
+	// one series for that device.
+	values := make(map[string]map[string]float64)
+	// labels holds the device labels of each device.
+	labels := make(map[string][]*dto.LabelPair)
+	ambiguous := make(map[string]map[string]bool)
+	existing := make(map[string]bool, len(families))
+	for _, mf := range families {
+		existing[mf.GetName()] = true
+		for _, m := range mf.GetMetric() {
+			uuid, pairs := deviceLabelPairs(m)
+			if uuid == "" {
+				continue
+			}
+			value, ok := sampleValue(mf.GetType(), m)
+			if !ok {
+				continue
+			}
+			if values[uuid] == nil {
+				values[uuid] = make(map[string]float64)
+				ambiguous[uuid] = make(map[string]bool)
+				labels[uuid] = pairs
+			}
+			if _, dup := values[uuid][mf.GetName()]; dup {
+				ambiguous[uuid][mf.GetName()] = true
+			}
+			values[uuid][mf.GetName()] = value
+		}
+	}
+
+	for _, dm := range metrics {
+		if existing[dm.name] {
+			g.logger.Warn("derived metric clashes with an existing metric, skipping", "metric", dm.name)
+			continue
+		}
+		mf := &dto.MetricFamily{
+			Name: proto.String(dm.name),
+			Help: proto.String(dm.help),
+			Type: dto.MetricType_GAUGE.Enum(),
+		}
+		for uuid, vars := range values {
+			value, ok := dm.expr(func(name string) (float64, bool) {
+				if ambiguous[uuid][name] {
+					return 0, false
+				}
+				v, ok := vars[name]
+				return v, ok
+			})
+			if !ok || math.IsNaN(value) || math.IsInf(value, 0) {
+				continue
+			}
+			mf.Metric = append(mf.Metric, &dto.Metric{
+				Label: labels[uuid],
+				Gauge: &dto.Gauge{Value: proto.Float64(value)},
+			})
+		}
+		if len(mf.Metric) > 0 {
+			families = append(families, mf)
+		}
+	}
+	return families, err
+}
+
+// deviceLabelPairs returns the uuid and the device labels of m.
+func deviceLabelPairs(m *dto.Metric) (string, []*dto.LabelPair) {
+	var uuid string
+	var pairs []*dto.LabelPair
+	for _, lp := range m.GetLabel() {
+		if slices.Contains(deviceLabels, lp.GetName()) {
+			pairs = append(pairs, lp)
+		}
+		if lp.GetName() == "uuid" {
+			uuid = lp.GetValue()
+		}
+	}
+	return uuid, pairs
+}
+
+func sampleValue(t dto.MetricType, m *dto.Metric) (float64, bool) {
+	switch t {
+	case dto.MetricType_GAUGE:
+		return m.GetGauge().GetValue(), true
+	case dto.MetricType_COUNTER:
+		return m.GetCounter().GetValue(), true
+	case dto.MetricType_UNTYPED:
+		return m.GetUntyped().GetValue(), true
+	}
+	return 0, false
+}
+
+// expression evaluates an arithmetic expression, looking variables up with
+// lookup. It returns false if a variable is missing.
+type expression func(lookup func(string) (float64, bool)) (float64, bool)
+
+// parseExpression parses an arithmetic expression made of numbers, metric
+// names, parentheses and the +, -, * and / operators.
+func parseExpression(s string) (expression, error) {
+	p := &exprParser{input: s}
+	p.next()
+	expr, err := p.parseSum()
+	if err != nil {
+		return nil, err
+	}
+	if p.tok != "" {
+		return nil, fmt.Errorf("unexpected %q in expression %q", p.tok, s)
+	}
+	return expr, nil
+}
+
+type exprParser struct {
+	input string
+	pos   int
+	tok   string
+}
+
+var exprTokenRE = regexp.MustCompile(`^\s*([0-9]*\.?[0-9]+([eE][-+]?[0-9]+)?|[a-zA-Z_:][a-zA-Z0-9_:]*|[-+*/()]|\S)`)
+
+// next advances to the next token. tok is empty at end of input.
+func (p *exprParser) next() {
+	m := exprTokenRE.FindStringSubmatchIndex(p.input[p.pos:])
+	if m == nil {
+		p.tok = ""
+		p.pos = len(p.input)
+		return
+	}
+	p.tok = p.input[p.pos+m[2] : p.pos+m[3]]
+	p.pos += m[1]
+}
+
+func (p *exprParser) parseSum() (expression, error) {
+	left, err := p.parseProduct()
+	if err != nil {
+		return nil, err
+	}
+	for p.tok == "+" || p.tok == "-" {
+		op := p.tok
+		p.next()
+		right, err := p.parseProduct()
+		if err != nil {
+			return nil, err
+		}
+		left = binaryOp(op, left, right)
+	}
+	return left, nil
+}
+
+func (p *exprParser) parseProduct() (expression, error) {
+	left, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+	for p.tok == "*" || p.tok == "/" {
+		op := p.tok
+		p.next()
+		right, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		left = binaryOp(op, left, right)
+	}
+	return left, nil
+}
+
+func (p *exprParser) parseUnary() (expression, error) {
+	if p.tok != "-" {
+		return p.parsePrimary()
+	}
+	p.next()
+	operand, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+	return func(lookup func(string) (float64, bool)) (float64, bool) {
+		v, ok := operand(lookup)
+		return -v, ok
+	}, nil
+}
+
+func (p *exprParser) parsePrimary() (expression, error) {
+	tok := p.tok
+	switch {
+	case tok == "":
+		return nil, fmt.Errorf("unexpected end of expression %q", p.input)
+	case tok == "(":
+		p.next()
+		expr, err := p.parseSum()
+		if err != nil {
+			return nil, err
+		}
+		if p.tok != ")" {
+			return nil, fmt.Errorf("missing ) in expression %q", p.input)
+		}
+		p.next()
+		return expr, nil
+	case metricNameRE.MatchString(tok):
+		p.next()
+		return func(lookup func(string) (float64, bool)) (float64, bool) {
+			return lookup(tok)
+		}, nil
+	}
+	value, err := strconv.ParseFloat(tok, 64)
+	if err != nil {
+		return nil, fmt.Errorf("unexpected %q in expression %q", tok, p.input)
+	}
+	p.next()
+	return func(func(string) (float64, bool)) (float64, bool) {
+		return value, true
+	}, nil
+}
+
+func binaryOp(op string, left, right expression) expression {
+	return func(lookup func(string) (float64, bool)) (float64, bool) {
+		l, ok := left(lookup)
+		if !ok {
+			return 0, false
+		}
+		r, ok := right(lookup)
+		if !ok {
+			return 0, false
+		}
+		switch op {
+		case "+":
+			return l + r, true
+		case "-":
+			return l - r, true
+		case "*":
+			return l * r, true
+		default:
+			return l / r, true
+		}
+	}
+}
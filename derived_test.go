@@ -0,0 +1,228 @@
+package main
+
+import (
+	"log/slog"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestParseExpression(t *testing.T) {
+	vars := map[string]float64{
+		"a":                            2,
+		"b":                            3,
+		"c":                            4,
+		"nvidia_gpu_memory_used_bytes": 1024,
+		"nvidia:ratio":                 0.5,
+	}
+	lookup := func(name string) (float64, bool) {
+		v, ok := vars[name]
+		return v, ok
+	}
+
+	for _, tc := range []struct {
+		expr string
+		want float64
+	}{
+		{"1", 1},
+		{"1.5e3", 1500},
+		{".5", 0.5},
+		{"a + b * c", 14},
+		{"a * b + c", 10},
+		{"(a + b) * c", 20},
+		{"a - b - c", -5},
+		{"c / a / a", 1},
+		{"a - -b", 5},
+		{"-(a + b)", -5},
+		{"((a))", 2},
+		{"  a*b  ", 6},
+		{"nvidia_gpu_memory_used_bytes / 1024", 1},
+		{"nvidia:ratio * 100", 50},
+	} {
+		expr, err := parseExpression(tc.expr)
+		if err != nil {
+			t.Errorf("parseExpression(%q): %v", tc.expr, err)
+			continue
+		}
+		got, ok := expr(lookup)
+		if !ok || got != tc.want {
+			t.Errorf("%q = %v, %v, want %v, true", tc.expr, got, ok, tc.want)
+		}
+	}
+}
+
+func TestParseExpressionErrors(t *testing.T) {
+	for _, expr := range []string{
+		"",
+		"   ",
+		"a +",
+		"* a",
+		"(a + b",
+		"a + b)",
+		"()",
+		"a b",
+		"a % b",
+		"a{uuid=\"x\"}",
+		"1.2.3",
+	} {
+		if _, err := parseExpression(expr); err == nil {
+			t.Errorf("parseExpression(%q) succeeded", expr)
+		}
+	}
+}
+
+func TestExpressionMissingVariable(t *testing.T) {
+	lookup := func(name string) (float64, bool) {
+		return 1, name == "known"
+	}
+	for _, s := range []string{"unknown", "known + unknown", "unknown * known", "-unknown", "(known / unknown)"} {
+		expr, err := parseExpression(s)
+		if err != nil {
+			t.Fatalf("parseExpression(%q): %v", s, err)
+		}
+		if v, ok := expr(lookup); ok {
+			t.Errorf("%q = %v with a missing variable", s, v)
+		}
+	}
+}
+
+func TestParseDerivedMetrics(t *testing.T) {
+	for _, tc := range []struct {
+		name    string
+		configs []DerivedMetricConfig
+		err     string
+	}{
+		{"valid", []DerivedMetricConfig{{Name: "a", Expr: "b"}, {Name: "c:d", Expr: "1"}}, ""},
+		{"invalid name", []DerivedMetricConfig{{Name: "0a", Expr: "b"}}, "invalid derived metric name"},
+		{"duplicate", []DerivedMetricConfig{{Name: "a", Expr: "b"}, {Name: "a", Expr: "c"}}, "duplicate derived metric"},
+		{"invalid expression", []DerivedMetricConfig{{Name: "a", Expr: "b +"}}, `derived metric "a"`},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := parseDerivedMetrics(tc.configs)
+			if tc.err == "" && err != nil || tc.err != "" && (err == nil || !strings.Contains(err.Error(), tc.err)) {
+				t.Errorf("parseDerivedMetrics() = %v, want error containing %q", err, tc.err)
+			}
+		})
+	}
+}
+
+func label(name, value string) *dto.LabelPair {
+	return &dto.LabelPair{Name: proto.String(name), Value: proto.String(value)}
+}
+
+func gauge(value float64, labels ...*dto.LabelPair) *dto.Metric {
+	return &dto.Metric{Label: labels, Gauge: &dto.Gauge{Value: proto.Float64(value)}}
+}
+
+func family(name string, t dto.MetricType, metrics ...*dto.Metric) *dto.MetricFamily {
+	return &dto.MetricFamily{Name: proto.String(name), Type: t.Enum(), Metric: metrics}
+}
+
+func TestDerivedGatherer(t *testing.T) {
+	defer func(labels []string) { deviceLabels = labels }(deviceLabels)
+	deviceLabels = []string{"minor_number", "uuid"}
+
+	gpu0 := []*dto.LabelPair{label("minor_number", "0"), label("uuid", "GPU-0")}
+	gpu1 := []*dto.LabelPair{label("minor_number", "1"), label("uuid", "GPU-1")}
+	counter := &dto.Metric{
+		Label:   gpu0,
+		Counter: &dto.Counter{Value: proto.Float64(8)},
+	}
+	families := []*dto.MetricFamily{
+		family("used", dto.MetricType_GAUGE, gauge(1, gpu0...), gauge(0, gpu1...)),
+		family("total", dto.MetricType_GAUGE, gauge(4, gpu0...), gauge(0, gpu1...)),
+		family("errors_total", dto.MetricType_COUNTER, counter),
+		// The labels beyond the device labels are dropped from the join.
+		family("clock", dto.MetricType_GAUGE, gauge(1000, append(slices.Clone(gpu0), label("domain", "sm"))...)),
+		// Several series of a metric for the same device are ambiguous.
+		family("throttle", dto.MetricType_GAUGE,
+			gauge(1, append(slices.Clone(gpu0), label("reason", "idle"))...),
+			gauge(0, append(slices.Clone(gpu0), label("reason", "power"))...)),
+		// Series without a uuid aren't per-device.
+		family("up", dto.MetricType_GAUGE, gauge(1)),
+		family("histogram", dto.MetricType_HISTOGRAM, &dto.Metric{Label: gpu0, Histogram: &dto.Histogram{}}),
+	}
+
+	for _, tc := range []struct {
+		name string
+		expr string
+		want map[string]float64
+	}{
+		// 0 / 0 is NaN for GPU-1.
+		{"ratio", "used / total", map[string]float64{"GPU-0": 0.25}},
+		{"counter", "errors_total * 2", map[string]float64{"GPU-0": 16}},
+		{"label join", "clock + used", map[string]float64{"GPU-0": 1001}},
+		{"constant", "used * 0 + 1", map[string]float64{"GPU-0": 1, "GPU-1": 1}},
+		{"division by zero", "1 / used", map[string]float64{"GPU-0": 1}},
+		{"missing series", "used + errors_total", map[string]float64{"GPU-0": 9}},
+		{"ambiguous series", "throttle", nil},
+		{"unknown metric", "nvidia_gpu_unknown", nil},
+		{"non-device metric", "up", nil},
+		{"unsupported type", "histogram", nil},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			metrics, err := parseDerivedMetrics([]DerivedMetricConfig{{Name: "derived", Expr: tc.expr}})
+			if err != nil {
+				t.Fatal(err)
+			}
+			g := newDerivedGatherer(prometheus.GathererFunc(func() ([]*dto.MetricFamily, error) {
+				return slices.Clone(families), nil
+			}), slog.New(slog.DiscardHandler))
+			g.SetMetrics(metrics)
+
+			gathered, err := g.Gather()
+			if err != nil {
+				t.Fatal(err)
+			}
+			got := make(map[string]float64)
+			for _, mf := range gathered {
+				if mf.GetName() != "derived" {
+					continue
+				}
+				if mf.GetType() != dto.MetricType_GAUGE {
+					t.Errorf("type = %v, want gauge", mf.GetType())
+				}
+				for _, m := range mf.GetMetric() {
+					uuid, pairs := deviceLabelPairs(m)
+					if len(pairs) != len(m.GetLabel()) {
+						t.Errorf("labels = %v, want only device labels", m.GetLabel())
+					}
+					got[uuid] = m.GetGauge().GetValue()
+				}
+			}
+			if len(got) != len(tc.want) {
+				t.Fatalf("got %v, want %v", got, tc.want)
+			}
+			for uuid, want := range tc.want {
+				if got[uuid] != want {
+					t.Errorf("%s = %v, want %v", uuid, got[uuid], want)
+				}
+			}
+		})
+	}
+}
+
+func TestDerivedGathererClash(t *testing.T) {
+	metrics, err := parseDerivedMetrics([]DerivedMetricConfig{{Name: "used", Expr: "used * 2"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	g := newDerivedGatherer(prometheus.GathererFunc(func() ([]*dto.MetricFamily, error) {
+		return []*dto.MetricFamily{
+			family("used", dto.MetricType_GAUGE, gauge(1, label("uuid", "GPU-0"))),
+		}, nil
+	}), slog.New(slog.DiscardHandler))
+	g.SetMetrics(metrics)
+
+	gathered, err := g.Gather()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(gathered) != 1 || gathered[0].GetMetric()[0].GetGauge().GetValue() != 1 {
+		t.Errorf("derived metric replaced an existing metric: %v", gathered)
+	}
+}
@@ -0,0 +1,338 @@
+package main
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"log/slog"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+	"sync/atomic"
+
+	"github.com/NVIDIA/go-nvml/pkg/nvml"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// deviceLabelNames are the device labels --labels.device can choose from.
+var deviceLabelNames = []string{"minor_number", "uuid", "name", "model", "serial", "pci_bus_id"}
+
+// deviceLabels are the labels attached to every per-device metric. They are
+// set by setDeviceLabels before the collectors are created.
+var deviceLabels = []string{"minor_number", "uuid", "name", "model"}
+
+// setDeviceLabels sets deviceLabels to names, which must be known device
+// labels, each at most once, and include one that identifies the device.
+func setDeviceLabels(names []string) error {
+	identified := false
+	for i, name := range names {
+		if !slices.Contains(deviceLabelNames, name) {
+			return fmt.Errorf("unknown device label %q", name)
+		}
+		if slices.Contains(names[:i], name) {
+			return fmt.Errorf("duplicate device label %q", name)
+		}
+		if name != "name" && name != "model" {
+			identified = true
+		}
+	}
+	if !identified {
+		return errors.New("device labels must include one of minor_number, uuid, serial or pci_bus_id")
+	}
+	deviceLabels = names
+	return nil
+}
+
+// deviceFilter selects the devices to export by uuid and by NVML
+// enumeration index. Empty include sets select every device.
+type deviceFilter struct {
+	// includeUUIDs and excludeUUIDs hold lowercase uuids.
+	includeUUIDs   map[string]bool
+	excludeUUIDs   map[string]bool
+	includeIndexes map[int]bool
+	excludeIndexes map[int]bool
+}
+
+// selectedDevices is the filter applied by enumerateDevices. It is set from
+// flags before the exporter is created.
+var selectedDevices deviceFilter
+
+// newDeviceFilter parses comma-separated lists of uuids and indexes.
+func newDeviceFilter(includeUUIDs, excludeUUIDs, includeIndexes, excludeIndexes string) (deviceFilter, error) {
+	uuids := func(list string) map[string]bool {
+		set := make(map[string]bool)
+		for _, uuid := range strings.Split(list, ",") {
+			if uuid = strings.TrimSpace(uuid); uuid != "" {
+				set[strings.ToLower(uuid)] = true
+			}
+		}
+		return set
+	}
+	indexes := func(list string) (map[int]bool, error) {
+		set := make(map[int]bool)
+		for _, index := range strings.Split(list, ",") {
+			if index = strings.TrimSpace(index); index == "" {
+				continue
+			}
+			i, err := strconv.Atoi(index)
+			if err != nil || i < 0 {
+				return nil, fmt.Errorf("invalid device index %q", index)
+			}
+			set[i] = true
+		}
+		return set, nil
+	}
+
+	f := deviceFilter{includeUUIDs: uuids(includeUUIDs), excludeUUIDs: uuids(excludeUUIDs)}
+	var err error
+	if f.includeIndexes, err = indexes(includeIndexes); err != nil {
+		return deviceFilter{}, err
+	}
+	if f.excludeIndexes, err = indexes(excludeIndexes); err != nil {
+		return deviceFilter{}, err
+	}
+	return f, nil
+}
+
+// selects reports whether the device with the given index and uuid is
+// exported. A device must match every include set that isn't empty and no
+// exclude set.
+func (f deviceFilter) selects(index int, uuid string) bool {
+	uuid = strings.ToLower(uuid)
+	switch {
+	case len(f.includeUUIDs) > 0 && !f.includeUUIDs[uuid]:
+		return false
+	case len(f.includeIndexes) > 0 && !f.includeIndexes[index]:
+		return false
+	case f.excludeUUIDs[uuid] || f.excludeIndexes[index]:
+		return false
+	}
+	return true
+}
+
+// device is a GPU visible to NVML.
+type device struct {
+	nvml.Device
+	index int
+	minor int
+	uuid  string
+	name  string
+	// labels are the values of deviceLabels for the device.
+	labels []string
+}
+
+// enumerateDevices returns the devices visible to NVML that selectedDevices
+// selects. Devices that can't be identified are logged and skipped; stale is
+// the number of them skipped because NVML must be reinitialized (see
+// nvmlStale).
+func enumerateDevices(logger *slog.Logger) (devices []*device, stale int, err error) {
+	count, ret := nvml.DeviceGetCount()
+	if ret != nvml.SUCCESS {
+		return nil, 0, ret
+	}
+
+	skip := func(ret nvml.Return) {
+		if nvmlStale(ret) {
+			stale++
+		}
+	}
+	devices = make([]*device, 0, count)
+	for i := 0; i < count; i++ {
+		handle, ret := nvml.DeviceGetHandleByIndex(i)
+		if ret != nvml.SUCCESS {
+			skip(ret)
+			logger.Error("failed to get device handle", "index", i, "err", ret)
+			continue
+		}
+		minor, ret := handle.GetMinorNumber()
+		if ret != nvml.SUCCESS {
+			skip(ret)
+			logger.Error("failed to get device minor number", "index", i, "err", ret)
+			continue
+		}
+		uuid, ret := handle.GetUUID()
+		if ret != nvml.SUCCESS {
+			skip(ret)
+			logger.Error("failed to get device uuid", "minor_number", minor, "err", ret)
+			continue
+		}
+		if !selectedDevices.selects(i, uuid) {
+			continue
+		}
+		name, ret := handle.GetName()
+		if ret != nvml.SUCCESS {
+			skip(ret)
+			logger.Error("failed to get device name", "uuid", uuid, "err", ret)
+			continue
+		}
+		d := &device{
+			Device: handle,
+			index:  i,
+			minor:  minor,
+			uuid:   uuid,
+			name:   name,
+		}
+		d.labels = d.labelValues(logger)
+		devices = append(devices, d)
+	}
+	return devices, stale, nil
+}
+
+// nvmlSession counts the reinitializations of NVML, so state holding NVML
+// data across collections can tell that it predates the current session.
+var nvmlSession atomic.Uint64
+
+// nvmlStale reports whether ret means that the NVML session is no longer
+// usable, e.g. after the driver was reloaded or a GPU fell off the bus, and
+// NVML must be reinitialized.
+func nvmlStale(ret nvml.Return) bool {
+	return ret == nvml.ERROR_UNINITIALIZED || ret == nvml.ERROR_GPU_IS_LOST
+}
+
+// labelValues returns the values of deviceLabels for d. Labels NVML can't
+// report, and the host label of local devices, are empty.
+func (d *device) labelValues(logger *slog.Logger) []string {
+	values := make([]string, len(deviceLabels))
+	for i, label := range deviceLabels {
+		switch label {
+		case "minor_number":
+			values[i] = strconv.Itoa(d.minor)
+		case "uuid":
+			values[i] = d.uuid
+		case "name":
+			values[i] = d.name
+		case "model":
+			values[i] = modelName(d.name)
+		case "serial":
+			serial, ret := d.GetSerial()
+			if ret != nvml.SUCCESS {
+				logger.Debug("failed to get serial", "uuid", d.uuid, "err", ret)
+			}
+			values[i] = serial
+		case "pci_bus_id":
+			if pci, ret := d.GetPciInfo(); ret == nvml.SUCCESS {
+				values[i] = pciBusID(pci)
+			} else {
+				logger.Debug("failed to get PCI info", "uuid", d.uuid, "err", ret)
+			}
+		}
+	}
+	return values
+}
+
+// labelsWith returns the device labels followed by extra label values.
+func (d *device) labelsWith(extra ...string) []string {
+	return append(d.labels[:len(d.labels):len(d.labels)], extra...)
+}
+
+// runningProcesses returns the memory used by each compute, graphics and MPS
+// process running on d, by pid. The memory is math.MaxUint64 where NVML
+// can't report it, e.g. in MIG mode or under WDDM.
+func (d *device) runningProcesses() (map[uint32]uint64, error) {
+	// A process using both compute and graphics is listed by both.
+	processes := make(map[uint32]uint64)
+	for _, list := range []func() ([]nvml.ProcessInfo, nvml.Return){
+		d.GetComputeRunningProcesses,
+		d.GetGraphicsRunningProcesses,
+		d.GetMPSComputeRunningProcesses,
+	} {
+		infos, ret := list()
+		if ret == nvml.ERROR_NOT_SUPPORTED {
+			continue
+		}
+		if ret != nvml.SUCCESS {
+			return nil, ret
+		}
+		for _, p := range infos {
+			processes[p.Pid] = max(processes[p.Pid], p.UsedGpuMemory)
+		}
+	}
+	return processes, nil
+}
+
+// pciBusID formats the PCI address of a device like nvidia-smi, e.g.
+// "00000000:07:00.0".
+func pciBusID(pci nvml.PciInfo) string {
+	return fmt.Sprintf("%08X:%02X:%02X.0", pci.Domain, pci.Bus, pci.Device)
+}
+
+// cString converts a NUL-terminated C string returned by NVML.
+func cString(s []int8) string {
+	b := make([]byte, 0, len(s))
+	for _, c := range s {
+		if c == 0 {
+			break
+		}
+		b = append(b, byte(c))
+	}
+	return string(b)
+}
+
+// nvmlValue decodes a value of the given type returned by NVML, e.g. by
+// GetSamples. It returns false for unknown types.
+func nvmlValue(valueType nvml.ValueType, value [8]byte) (float64, bool) {
+	switch valueType {
+	case nvml.VALUE_TYPE_DOUBLE:
+		return math.Float64frombits(binary.NativeEndian.Uint64(value[:])), true
+	case nvml.VALUE_TYPE_UNSIGNED_INT:
+		return float64(binary.NativeEndian.Uint32(value[:])), true
+	case nvml.VALUE_TYPE_UNSIGNED_LONG, nvml.VALUE_TYPE_UNSIGNED_LONG_LONG:
+		return float64(binary.NativeEndian.Uint64(value[:])), true
+	case nvml.VALUE_TYPE_SIGNED_LONG_LONG:
+		return float64(int64(binary.NativeEndian.Uint64(value[:]))), true
+	case nvml.VALUE_TYPE_SIGNED_INT:
+		return float64(int32(binary.NativeEndian.Uint32(value[:]))), true
+	case nvml.VALUE_TYPE_UNSIGNED_SHORT:
+		return float64(binary.NativeEndian.Uint16(value[:])), true
+	}
+	return 0, false
+}
+
+// fieldValues queries the given field values of the device and returns them
+// in the same order, or nil for fields NVML couldn't report.
+func fieldValues(d *device, fields []nvml.FieldValue) ([]*float64, nvml.Return) {
+	if ret := d.GetFieldValues(fields); ret != nvml.SUCCESS {
+		return nil, ret
+	}
+	values := make([]*float64, len(fields))
+	for i, field := range fields {
+		if nvml.Return(field.NvmlReturn) != nvml.SUCCESS {
+			continue
+		}
+		if value, ok := nvmlValue(nvml.ValueType(field.ValueType), field.Value); ok {
+			values[i] = &value
+		}
+	}
+	return values, nvml.SUCCESS
+}
+
+// modelName normalizes a product name for use in PromQL matchers, e.g.
+// "NVIDIA A100-SXM4-80GB" becomes "a100-sxm4-80gb".
+func modelName(name string) string {
+	name = strings.TrimPrefix(strings.TrimSpace(name), "NVIDIA ")
+	var b strings.Builder
+	dash := false
+	for _, r := range strings.ToLower(name) {
+		if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') {
+			b.WriteRune(r)
+			dash = false
+			continue
+		}
+		if !dash && b.Len() > 0 {
+			b.WriteByte('-')
+			dash = true
+		}
+	}
+	return strings.TrimSuffix(b.String(), "-")
+}
+
+// deviceDesc returns a descriptor for a per-device metric with optional
+// extra labels.
+func deviceDesc(subsystem, name, help string, extraLabels ...string) *prometheus.Desc {
+	return prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, subsystem, name),
+		help,
+		append(deviceLabels[:len(deviceLabels):len(deviceLabels)], extraLabels...), nil,
+	)
+}
@@ -0,0 +1,137 @@
+package main
+
+import (
+	"log/slog"
+	"slices"
+	"testing"
+
+	"github.com/NVIDIA/go-nvml/pkg/nvml"
+	"github.com/NVIDIA/go-nvml/pkg/nvml/mock"
+)
+
+func TestModelName(t *testing.T) {
+	for _, tc := range []struct {
+		name, want string
+	}{
+		{"NVIDIA A100-SXM4-80GB", "a100-sxm4-80gb"},
+		{"NVIDIA GeForce RTX 4090", "geforce-rtx-4090"},
+		{"NVIDIA H100 80GB HBM3", "h100-80gb-hbm3"},
+		{"Tesla T4", "tesla-t4"},
+		{"Quadro RTX 6000", "quadro-rtx-6000"},
+		{"  NVIDIA L40S  ", "l40s"},
+		{"NVIDIA A100-SXM4-40GB MIG 1g.5gb", "a100-sxm4-40gb-mig-1g-5gb"},
+		{"NVIDIA  RTX A6000", "rtx-a6000"},
+		{"GRID A100D-4C", "grid-a100d-4c"},
+		{"(NVIDIA)", "nvidia"},
+		{"", ""},
+	} {
+		if got := modelName(tc.name); got != tc.want {
+			t.Errorf("modelName(%q) = %q, want %q", tc.name, got, tc.want)
+		}
+	}
+}
+
+func TestDeviceFilter(t *testing.T) {
+	const (
+		gpu0 = "GPU-6e7b3c2a-51f4-4d8e-9a0b-1c2d3e4f5a6b"
+		gpu1 = "GPU-0a1b2c3d-0000-0000-0000-000000000001"
+	)
+	for _, tc := range []struct {
+		name                                                       string
+		includeUUIDs, excludeUUIDs, includeIndexes, excludeIndexes string
+		want                                                       [2]bool
+	}{
+		{name: "no filters", want: [2]bool{true, true}},
+		{name: "include uuid", includeUUIDs: gpu1, want: [2]bool{false, true}},
+		{name: "include uuid case-insensitive", includeUUIDs: " gpu-6E7B3C2A-51F4-4D8E-9A0B-1C2D3E4F5A6B ", want: [2]bool{true, false}},
+		{name: "exclude uuid", excludeUUIDs: gpu0 + ",", want: [2]bool{false, true}},
+		{name: "include index", includeIndexes: "1", want: [2]bool{false, true}},
+		{name: "exclude indexes", excludeIndexes: "0, 1", want: [2]bool{false, false}},
+		{name: "include uuid and index", includeUUIDs: gpu0, includeIndexes: "1", want: [2]bool{false, false}},
+		{name: "exclude wins over include", includeUUIDs: gpu0 + "," + gpu1, excludeIndexes: "1", want: [2]bool{true, false}},
+		{name: "include index exclude uuid", includeIndexes: "0,1", excludeUUIDs: gpu0, want: [2]bool{false, true}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			f, err := newDeviceFilter(tc.includeUUIDs, tc.excludeUUIDs, tc.includeIndexes, tc.excludeIndexes)
+			if err != nil {
+				t.Fatal(err)
+			}
+			for i, uuid := range []string{gpu0, gpu1} {
+				if got := f.selects(i, uuid); got != tc.want[i] {
+					t.Errorf("selects(%d, %s) = %v, want %v", i, uuid, got, tc.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestDeviceFilterInvalidIndex(t *testing.T) {
+	for _, tc := range []struct{ include, exclude string }{
+		{"a", ""},
+		{"", "-1"},
+		{"0,1.5", ""},
+	} {
+		if _, err := newDeviceFilter("", "", tc.include, tc.exclude); err == nil {
+			t.Errorf("newDeviceFilter(%q, %q) succeeded", tc.include, tc.exclude)
+		}
+	}
+}
+
+func TestSetDeviceLabels(t *testing.T) {
+	defer func(labels []string) { deviceLabels = labels }(deviceLabels)
+	for _, tc := range []struct {
+		labels []string
+		valid  bool
+	}{
+		{[]string{"uuid"}, true},
+		{[]string{"minor_number", "uuid", "name", "model"}, true},
+		{[]string{"serial", "name"}, true},
+		{[]string{"pci_bus_id"}, true},
+		{[]string{"name", "model"}, false},
+		{nil, false},
+		{[]string{"uuid", "host"}, false},
+		{[]string{"uuid", "index"}, false},
+		{[]string{"uuid", "uuid"}, false},
+		{[]string{"uuid", "name", "model", "name"}, false},
+	} {
+		err := setDeviceLabels(tc.labels)
+		if valid := err == nil; valid != tc.valid {
+			t.Errorf("setDeviceLabels(%q) = %v, want valid %v", tc.labels, err, tc.valid)
+		}
+		if err == nil && !slices.Equal(deviceLabels, tc.labels) {
+			t.Errorf("deviceLabels = %q, want %q", deviceLabels, tc.labels)
+		}
+	}
+}
+
+func TestDeviceLabelValues(t *testing.T) {
+	defer func(labels []string) { deviceLabels = labels }(deviceLabels)
+	deviceLabels = []string{"minor_number", "uuid", "name", "model", "serial", "pci_bus_id", "host"}
+
+	d := &device{
+		minor: 3,
+		uuid:  "GPU-0",
+		name:  "NVIDIA A100-SXM4-80GB",
+		Device: &mock.Device{
+			GetSerialFunc: func() (string, nvml.Return) { return "1564720004631", nvml.SUCCESS },
+			GetPciInfoFunc: func() (nvml.PciInfo, nvml.Return) {
+				return nvml.PciInfo{Domain: 0, Bus: 0x17, Device: 0x1a}, nvml.SUCCESS
+			},
+		},
+	}
+	want := []string{"3", "GPU-0", "NVIDIA A100-SXM4-80GB", "a100-sxm4-80gb", "1564720004631", "00000000:17:1A.0", ""}
+	got := d.labelValues(slog.New(slog.DiscardHandler))
+	if !slices.Equal(got, want) {
+		t.Errorf("labelValues() = %q, want %q", got, want)
+	}
+
+	// Labels NVML can't report are empty.
+	d.Device = &mock.Device{
+		GetSerialFunc:  func() (string, nvml.Return) { return "", nvml.ERROR_NOT_SUPPORTED },
+		GetPciInfoFunc: func() (nvml.PciInfo, nvml.Return) { return nvml.PciInfo{}, nvml.ERROR_UNKNOWN },
+	}
+	got = d.labelValues(slog.New(slog.DiscardHandler))
+	if got[4] != "" || got[5] != "" {
+		t.Errorf("labelValues() = %q, want empty serial and pci_bus_id", got)
+	}
+}
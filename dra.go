@@ -0,0 +1,178 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"log/slog"
+	"os"
+	"regexp"
+	"strconv"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var (
+	draCheckpoint = flag.String("collector.dra.checkpoint-file", "/var/lib/kubelet/dra_manager_state",
+		"Path to the kubelet checkpoint of the prepared Dynamic Resource Allocation claims.")
+	draDriver = flag.String("collector.dra.driver", "gpu.nvidia.com",
+		"Name of the DRA driver whose devices are the GPUs.")
+)
+
+func init() {
+	registerCollector("dra", defaultDisabled, newDRACollector)
+}
+
+// draRescanInterval is the minimum time between reads of the OCI bundles for
+// pods whose name wasn't found before.
+const draRescanInterval = time.Minute
+
+// draDeviceName matches the names the NVIDIA DRA driver gives its devices:
+// gpu-<minor> for full GPUs and gpu-<minor>-mig-<profile>-<start>-<size> for
+// MIG slices.
+var draDeviceName = regexp.MustCompile(`^gpu-(\d+)(?:-mig-(\d+)-(\d+)-(\d+))?$`)
+
+// draCheckpointFile is the kubelet checkpoint file of the DRA manager. Data
+// holds the JSON encoded draCheckpointData.
+type draCheckpointFile struct {
+	Data string
+}
+
+type draCheckpointData struct {
+	Entries []draClaim
+}
+
+// draClaim is a resource claim prepared by the kubelet for the pods on the
+// node.
+type draClaim struct {
+	ClaimUID    string
+	ClaimName   string
+	Namespace   string
+	PodUIDs     map[string]struct{}
+	DriverState map[string]struct {
+		Devices []struct {
+			PoolName   string
+			DeviceName string
+		}
+	}
+}
+
+// draCollector exports which resource claim and pods each device, or MIG
+// slice of it, is allocated to by Kubernetes Dynamic Resource Allocation,
+// read from the kubelet checkpoint. The checkpoint only references pods by
+// uid, so their names are resolved from the OCI bundles of their containers.
+type draCollector struct {
+	logger *slog.Logger
+
+	// podNames caches the names of the pods of the claims by uid, or "" for
+	// pods whose name wasn't found when the bundles were last read at
+	// scanned. Pods that no longer hold a claim are dropped.
+	podNames map[string]string
+	scanned  time.Time
+
+	claimInfo *prometheus.Desc
+}
+
+func newDRACollector(logger *slog.Logger) collector {
+	return &draCollector{
+		logger:   logger,
+		podNames: make(map[string]string),
+		claimInfo: deviceDesc("dra", "claim_info",
+			"Always 1. Labeled with the DRA resource claim and the uid and name of a pod the GPU device, or the MIG slice given by mig_profile_id and mig_placement_start, is allocated to.",
+			"namespace", "claim", "claim_uid", "pod_uid", "pod_name", "dra_device", "mig_profile_id", "mig_placement_start"),
+	}
+}
+
+func (c *draCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.claimInfo
+}
+
+func (c *draCollector) Collect(ch chan<- prometheus.Metric, devices []*device) {
+	claims, err := readDRACheckpoint(*draCheckpoint)
+	if err != nil {
+		c.logger.Debug("failed to read DRA checkpoint", "file", *draCheckpoint, "err", err)
+		return
+	}
+
+	c.resolvePodNames(claims)
+
+	byMinor := make(map[int]*device, len(devices))
+	for _, d := range devices {
+		byMinor[d.minor] = d
+	}
+	for _, claim := range claims {
+		for _, allocated := range claim.DriverState[*draDriver].Devices {
+			match := draDeviceName.FindStringSubmatch(allocated.DeviceName)
+			if match == nil {
+				c.logger.Debug("unknown DRA device name", "claim_uid", claim.ClaimUID, "device", allocated.DeviceName)
+				continue
+			}
+			minor, _ := strconv.Atoi(match[1])
+			d, ok := byMinor[minor]
+			if !ok {
+				c.logger.Debug("DRA device not found", "claim_uid", claim.ClaimUID, "device", allocated.DeviceName)
+				continue
+			}
+			for podUID := range claim.PodUIDs {
+				ch <- prometheus.MustNewConstMetric(c.claimInfo, prometheus.GaugeValue, 1,
+					d.labelsWith(claim.Namespace, claim.ClaimName, claim.ClaimUID, podUID, c.podNames[podUID], allocated.DeviceName, match[2], match[3])...)
+			}
+		}
+	}
+}
+
+// resolvePodNames updates podNames with the pods of claims. The OCI bundles
+// are only read if a pod isn't known yet, or at most every
+// draRescanInterval if the name of a pod wasn't found, e.g. because none of
+// its containers runs yet.
+func (c *draCollector) resolvePodNames(claims []draClaim) {
+	pods := make(map[string]bool)
+	missing, unresolved := false, false
+	for _, claim := range claims {
+		for podUID := range claim.PodUIDs {
+			pods[podUID] = true
+			if name, ok := c.podNames[podUID]; !ok {
+				missing = true
+			} else if name == "" {
+				unresolved = true
+			}
+		}
+	}
+	for podUID := range c.podNames {
+		if !pods[podUID] {
+			delete(c.podNames, podUID)
+		}
+	}
+	if !missing && (!unresolved || time.Since(c.scanned) < draRescanInterval) {
+		return
+	}
+	c.scanned = time.Now()
+	for podUID := range pods {
+		if _, ok := c.podNames[podUID]; !ok {
+			c.podNames[podUID] = ""
+		}
+	}
+	for _, container := range bundleContainers() {
+		if pods[container.podUID] {
+			c.podNames[container.podUID] = container.pod
+		}
+	}
+}
+
+// readDRACheckpoint returns the claims in the kubelet DRA checkpoint at path.
+func readDRACheckpoint(path string) ([]draClaim, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var file draCheckpointFile
+	if err := json.Unmarshal(content, &file); err != nil {
+		return nil, err
+	}
+	var data draCheckpointData
+	if err := json.Unmarshal([]byte(file.Data), &data); err != nil {
+		return nil, fmt.Errorf("failed to parse checkpoint data: %w", err)
+	}
+	return data.Entries, nil
+}
@@ -0,0 +1,163 @@
+package main
+
+import (
+	"encoding/json"
+	"log/slog"
+	"maps"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// draCheckpointSample is the data of a kubelet DRA checkpoint holding a
+// claim for a full GPU shared by two pods and a claim for a MIG slice.
+const draCheckpointSample = `{
+	"kind": "DRACheckpoint",
+	"apiVersion": "checkpoint.dra.kubelet.k8s.io/v1",
+	"Entries": [
+		{
+			"ClaimUID": "7a1c0b9e-0000-0000-0000-000000000001",
+			"ClaimName": "train-gpu",
+			"Namespace": "ml",
+			"PodUIDs": {
+				"3f6e2d1c-0000-0000-0000-00000000000a": {},
+				"3f6e2d1c-0000-0000-0000-00000000000b": {}
+			},
+			"DriverState": {
+				"gpu.nvidia.com": {
+					"Devices": [
+						{
+							"PoolName": "node-1",
+							"DeviceName": "gpu-0",
+							"RequestNames": ["gpu"],
+							"CDIDeviceIDs": ["k8s.gpu.nvidia.com/device=gpu-0"]
+						}
+					]
+				}
+			}
+		},
+		{
+			"ClaimUID": "7a1c0b9e-0000-0000-0000-000000000002",
+			"ClaimName": "infer-mig",
+			"Namespace": "serving",
+			"PodUIDs": {"3f6e2d1c-0000-0000-0000-00000000000c": {}},
+			"DriverState": {
+				"gpu.nvidia.com": {
+					"Devices": [{"PoolName": "node-1", "DeviceName": "gpu-1-mig-19-2-1"}]
+				}
+			}
+		}
+	]
+}`
+
+func writeDRACheckpoint(t *testing.T, data string) string {
+	t.Helper()
+	content, err := json.Marshal(draCheckpointFile{Data: data})
+	if err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(t.TempDir(), "dra_manager_state")
+	if err := os.WriteFile(path, content, 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestReadDRACheckpoint(t *testing.T) {
+	claims, err := readDRACheckpoint(writeDRACheckpoint(t, draCheckpointSample))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(claims) != 2 {
+		t.Fatalf("got %d claims, want 2", len(claims))
+	}
+
+	gpu := claims[0]
+	if gpu.ClaimUID != "7a1c0b9e-0000-0000-0000-000000000001" || gpu.ClaimName != "train-gpu" || gpu.Namespace != "ml" {
+		t.Errorf("claim = %s %s/%s", gpu.ClaimUID, gpu.Namespace, gpu.ClaimName)
+	}
+	if len(gpu.PodUIDs) != 2 {
+		t.Errorf("got %d pods, want 2", len(gpu.PodUIDs))
+	}
+	if devices := gpu.DriverState["gpu.nvidia.com"].Devices; len(devices) != 1 || devices[0].DeviceName != "gpu-0" {
+		t.Errorf("devices = %+v, want gpu-0", devices)
+	}
+
+	mig := claims[1].DriverState["gpu.nvidia.com"].Devices
+	if len(mig) != 1 {
+		t.Fatalf("got %d MIG devices, want 1", len(mig))
+	}
+	match := draDeviceName.FindStringSubmatch(mig[0].DeviceName)
+	if match == nil || match[1] != "1" || match[2] != "19" || match[3] != "2" || match[4] != "1" {
+		t.Errorf("MIG device name %q parsed as %q", mig[0].DeviceName, match)
+	}
+}
+
+func TestReadDRACheckpointErrors(t *testing.T) {
+	for _, tc := range []struct {
+		name, content string
+	}{
+		{"not json", "{"},
+		{"data not json", `{"Data": "{", "Checksum": 1}`},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "dra_manager_state")
+			if err := os.WriteFile(path, []byte(tc.content), 0o600); err != nil {
+				t.Fatal(err)
+			}
+			if _, err := readDRACheckpoint(path); err == nil {
+				t.Error("readDRACheckpoint() succeeded")
+			}
+		})
+	}
+	if _, err := readDRACheckpoint(filepath.Join(t.TempDir(), "missing")); !os.IsNotExist(err) {
+		t.Errorf("readDRACheckpoint() of a missing file = %v", err)
+	}
+}
+
+func TestDRAResolvePodNames(t *testing.T) {
+	defer func(dir string) { *containerRunDir = dir }(*containerRunDir)
+	*containerRunDir = t.TempDir()
+	writeBundle(t, "containerd/io.containerd.runtime.v2.task/k8s.io/aaaa/config.json", map[string]string{
+		"io.kubernetes.cri.sandbox-name": "train-0",
+		"io.kubernetes.cri.sandbox-uid":  "3f6e2d1c-0000-0000-0000-00000000000a",
+	})
+	writeBundle(t, "containerd/io.containerd.runtime.v2.task/k8s.io/bbbb/config.json", map[string]string{
+		"io.kubernetes.cri.sandbox-name": "other",
+		"io.kubernetes.cri.sandbox-uid":  "3f6e2d1c-0000-0000-0000-0000000000ff",
+	})
+
+	claims, err := readDRACheckpoint(writeDRACheckpoint(t, draCheckpointSample))
+	if err != nil {
+		t.Fatal(err)
+	}
+	c := newDRACollector(slog.New(slog.DiscardHandler)).(*draCollector)
+	c.podNames["3f6e2d1c-0000-0000-0000-0000000000ee"] = "deleted"
+	c.resolvePodNames(claims)
+
+	want := map[string]string{
+		"3f6e2d1c-0000-0000-0000-00000000000a": "train-0",
+		"3f6e2d1c-0000-0000-0000-00000000000b": "",
+		"3f6e2d1c-0000-0000-0000-00000000000c": "",
+	}
+	if !maps.Equal(c.podNames, want) {
+		t.Fatalf("podNames = %v, want %v", c.podNames, want)
+	}
+
+	// Pods without a name are only looked up again after
+	// draRescanInterval.
+	writeBundle(t, "containerd/io.containerd.runtime.v2.task/k8s.io/cccc/config.json", map[string]string{
+		"io.kubernetes.cri.sandbox-name": "infer-0",
+		"io.kubernetes.cri.sandbox-uid":  "3f6e2d1c-0000-0000-0000-00000000000c",
+	})
+	c.resolvePodNames(claims)
+	if !maps.Equal(c.podNames, want) {
+		t.Errorf("podNames = %v within draRescanInterval, want %v", c.podNames, want)
+	}
+	c.scanned = c.scanned.Add(-draRescanInterval)
+	c.resolvePodNames(claims)
+	want["3f6e2d1c-0000-0000-0000-00000000000c"] = "infer-0"
+	if !maps.Equal(c.podNames, want) {
+		t.Errorf("podNames = %v after draRescanInterval, want %v", c.podNames, want)
+	}
+}
@@ -0,0 +1,74 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/common/expfmt"
+)
+
+// logState logs the state of every collector of e, including the metrics of
+// its most recent run, and the device enumeration error count.
+func logState(logger *slog.Logger, e *Exporter) {
+	count, last := e.EnumerationErrors()
+	logger.Info("dumping exporter state", "enumeration_errors", count, "last_enumeration_error", last)
+	for _, c := range e.Collectors() {
+		logger.Info("collector state",
+			"collector", c.Name,
+			"enabled", c.Enabled,
+			"interval", c.Interval,
+			"last_run", c.LastRun,
+			"last_duration", c.LastDuration,
+			"metrics", formatMetrics(c.Metrics),
+		)
+	}
+}
+
+// stateHandler serves the state logged by logState as plain text.
+func stateHandler(e *Exporter) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+		count, last := e.EnumerationErrors()
+		fmt.Fprintf(w, "enumeration_errors: %d\nlast_enumeration_error: %s\n", count, last)
+		for _, c := range e.Collectors() {
+			fmt.Fprintf(w, "\ncollector: %s\nenabled: %t\ninterval: %v\nlast_run: %s\nlast_duration: %v\n\n",
+				c.Name, c.Enabled, c.Interval, c.LastRun.Format(time.RFC3339Nano), c.LastDuration)
+			io.WriteString(w, formatMetrics(c.Metrics))
+		}
+	})
+}
+
+// replayCollector sends previously collected metrics.
+type replayCollector []prometheus.Metric
+
+// Describe implements prometheus.Collector. It sends no descriptors, so the
+// collector is unchecked.
+func (replayCollector) Describe(chan<- *prometheus.Desc) {}
+
+// Collect implements prometheus.Collector.
+func (c replayCollector) Collect(ch chan<- prometheus.Metric) {
+	for _, m := range c {
+		ch <- m
+	}
+}
+
+// formatMetrics returns metrics in the Prometheus text format.
+func formatMetrics(metrics []prometheus.Metric) string {
+	registry := prometheus.NewRegistry()
+	registry.MustRegister(replayCollector(metrics))
+	families, err := registry.Gather()
+
+	var b strings.Builder
+	for _, mf := range families {
+		expfmt.MetricFamilyToText(&b, mf)
+	}
+	if err != nil {
+		fmt.Fprintf(&b, "# error: %v\n", err)
+	}
+	return b.String()
+}
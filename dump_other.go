@@ -0,0 +1,8 @@
+//go:build !unix
+
+package main
+
+import "log/slog"
+
+// watchStateSignal does nothing, as SIGUSR1 only exists on Unix.
+func watchStateSignal(logger *slog.Logger, e *Exporter) {}
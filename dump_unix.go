@@ -0,0 +1,19 @@
+//go:build unix
+
+package main
+
+import (
+	"log/slog"
+	"os"
+	"os/signal"
+	"syscall"
+)
+
+// watchStateSignal logs the state of e on SIGUSR1.
+func watchStateSignal(logger *slog.Logger, e *Exporter) {
+	usr1 := make(chan os.Signal, 1)
+	signal.Notify(usr1, syscall.SIGUSR1)
+	for range usr1 {
+		logState(logger, e)
+	}
+}
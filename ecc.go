@@ -0,0 +1,91 @@
+package main
+
+import (
+	"log/slog"
+
+	"github.com/NVIDIA/go-nvml/pkg/nvml"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+func init() {
+	registerCollector("ecc", defaultEnabled, newEccCollector)
+}
+
+// memoryErrorTypeNames maps NVML memory error types to the values of the
+// type label.
+var memoryErrorTypeNames = map[nvml.MemoryErrorType]string{
+	nvml.MEMORY_ERROR_TYPE_CORRECTED:   "corrected",
+	nvml.MEMORY_ERROR_TYPE_UNCORRECTED: "uncorrected",
+}
+
+// eccCollector exports whether ECC is enabled on each device and, when it
+// is, the number of ECC errors since the driver was loaded (volatile) and
+// over the lifetime of the device (aggregate).
+type eccCollector struct {
+	logger *slog.Logger
+
+	enabled         *prometheus.Desc
+	pendingEnabled  *prometheus.Desc
+	volatileErrors  *prometheus.Desc
+	aggregateErrors *prometheus.Desc
+}
+
+func newEccCollector(logger *slog.Logger) collector {
+	return &eccCollector{
+		logger: logger,
+		enabled: deviceDesc("ecc", "enabled",
+			"Whether ECC is enabled on the GPU device (1) or not (0)."),
+		pendingEnabled: deviceDesc("ecc", "pending_enabled",
+			"Whether ECC will be enabled on the GPU device after the next reboot (1) or not (0)."),
+		volatileErrors: deviceDesc("ecc", "volatile_errors_total",
+			"Number of ECC errors of the type (corrected or uncorrected) of the GPU device since the driver was loaded.",
+			"type"),
+		aggregateErrors: deviceDesc("ecc", "aggregate_errors_total",
+			"Number of ECC errors of the type (corrected or uncorrected) over the lifetime of the GPU device.",
+			"type"),
+	}
+}
+
+func (c *eccCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.enabled
+	ch <- c.pendingEnabled
+	ch <- c.volatileErrors
+	ch <- c.aggregateErrors
+}
+
+func (c *eccCollector) Collect(ch chan<- prometheus.Metric, devices []*device) {
+	for _, d := range devices {
+		current, pending, ret := d.GetEccMode()
+		if ret != nvml.SUCCESS {
+			c.logger.Debug("failed to get ECC mode", "uuid", d.uuid, "err", ret)
+			continue
+		}
+		enabled := 0.0
+		if current == nvml.FEATURE_ENABLED {
+			enabled = 1
+		}
+		pendingEnabled := 0.0
+		if pending == nvml.FEATURE_ENABLED {
+			pendingEnabled = 1
+		}
+		ch <- prometheus.MustNewConstMetric(c.enabled, prometheus.GaugeValue, enabled, d.labels...)
+		ch <- prometheus.MustNewConstMetric(c.pendingEnabled, prometheus.GaugeValue, pendingEnabled, d.labels...)
+
+		// Errors are only counted while ECC is enabled.
+		if current != nvml.FEATURE_ENABLED {
+			continue
+		}
+		for errorType, name := range memoryErrorTypeNames {
+			if count, ret := d.GetTotalEccErrors(errorType, nvml.VOLATILE_ECC); ret == nvml.SUCCESS {
+				ch <- prometheus.MustNewConstMetric(c.volatileErrors, prometheus.CounterValue, float64(count), d.labelsWith(name)...)
+			} else {
+				c.logger.Debug("failed to get volatile ECC errors", "uuid", d.uuid, "type", name, "err", ret)
+			}
+			if count, ret := d.GetTotalEccErrors(errorType, nvml.AGGREGATE_ECC); ret == nvml.SUCCESS {
+				ch <- prometheus.MustNewConstMetric(c.aggregateErrors, prometheus.CounterValue, float64(count), d.labelsWith(name)...)
+			} else {
+				c.logger.Debug("failed to get aggregate ECC errors", "uuid", d.uuid, "type", name, "err", ret)
+			}
+		}
+	}
+}
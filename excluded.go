@@ -0,0 +1,89 @@
+package main
+
+import (
+	"log/slog"
+	"slices"
+
+	"github.com/NVIDIA/go-nvml/pkg/nvml"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+func init() {
+	registerCollector("excluded", defaultEnabled, newExcludedCollector)
+}
+
+// excludedCollector exports the GPUs excluded by the driver, e.g. after
+// uncorrectable errors or through the NVreg_ExcludedGpus module parameter.
+// Excluded GPUs are not enumerated by NVML, so they are not among the devices
+// passed to Collect.
+type excludedCollector struct {
+	logger *slog.Logger
+
+	count *prometheus.Desc
+	info  *prometheus.Desc
+	// busIDLabel is set when pci_bus_id isn't among the device labels. It
+	// is then added to the info metric, which would otherwise lack a label
+	// telling excluded devices apart.
+	busIDLabel bool
+}
+
+func newExcludedCollector(logger *slog.Logger) collector {
+	var extraLabels []string
+	busIDLabel := !slices.Contains(deviceLabels, "pci_bus_id")
+	if busIDLabel {
+		extraLabels = []string{"pci_bus_id"}
+	}
+	return &excludedCollector{
+		logger:     logger,
+		busIDLabel: busIDLabel,
+		count: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, "", "excluded_devices"),
+			"Number of GPU devices excluded by the driver.",
+			nil, nil,
+		),
+		info: deviceDesc("", "excluded_device_info",
+			"Information about a GPU device excluded by the driver. Always 1.",
+			extraLabels...),
+	}
+}
+
+func (c *excludedCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.count
+	ch <- c.info
+}
+
+func (c *excludedCollector) Collect(ch chan<- prometheus.Metric, _ []*device) {
+	count, ret := nvml.GetExcludedDeviceCount()
+	if ret != nvml.SUCCESS {
+		c.logger.Debug("failed to get excluded device count", "err", ret)
+		return
+	}
+	ch <- prometheus.MustNewConstMetric(c.count, prometheus.GaugeValue, float64(count))
+
+	for i := range count {
+		info, ret := nvml.GetExcludedDeviceInfoByIndex(i)
+		if ret != nvml.SUCCESS {
+			c.logger.Debug("failed to get excluded device info", "index", i, "err", ret)
+			continue
+		}
+		ch <- prometheus.MustNewConstMetric(c.info, prometheus.GaugeValue, 1, c.labelValues(info)...)
+	}
+}
+
+// labelValues returns the device labels of an excluded device. Only its uuid
+// and PCI bus id are known, the other labels are empty.
+func (c *excludedCollector) labelValues(info nvml.ExcludedDeviceInfo) []string {
+	values := make([]string, len(deviceLabels), len(deviceLabels)+1)
+	for i, label := range deviceLabels {
+		switch label {
+		case "uuid":
+			values[i] = cString(info.Uuid[:])
+		case "pci_bus_id":
+			values[i] = pciBusID(info.PciInfo)
+		}
+	}
+	if c.busIDLabel {
+		values = append(values, pciBusID(info.PciInfo))
+	}
+	return values
+}
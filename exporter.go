@@ -0,0 +1,597 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/NVIDIA/go-nvml/pkg/nvml"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const namespace = "nvidia_gpu"
+
+const (
+	// reinitThreshold is the number of consecutive device enumerations
+	// failing with stale NVML handles after which NVML is reinitialized.
+	reinitThreshold = 3
+	// reinitBackoff is the minimum time between reinitializations, so a GPU
+	// that stays lost doesn't reinitialize NVML on every collection.
+	reinitBackoff = time.Minute
+)
+
+// ExporterOpts configures an Exporter.
+type ExporterOpts struct {
+	// Timestamps attaches the time of collection to every exported sample.
+	Timestamps bool
+	// Interval is the refresh interval of the collectors without one in
+	// Intervals. When set, scrapes don't query NVML at all.
+	Interval time.Duration
+	// Intervals holds the refresh interval of collectors by name. Collectors
+	// with an interval are collected in the background once Start is called
+	// and served from cache; the others are collected at scrape time.
+	Intervals map[string]time.Duration
+	// Disabled holds the names of collectors that start disabled. They can
+	// be enabled at runtime with SetCollectorEnabled.
+	Disabled map[string]bool
+}
+
+// Exporter collects metrics for all NVIDIA GPUs visible to NVML.
+type Exporter struct {
+	logger *slog.Logger
+	opts   ExporterOpts
+
+	collectors []*scheduledCollector
+
+	// succeeded is closed after the first successful device enumeration.
+	succeeded     chan struct{}
+	succeededOnce sync.Once
+
+	// nvmlErr holds the error of nvml.Init, or nil once NVML is initialized.
+	nvmlErr atomic.Pointer[nvml.Return]
+
+	// reinitMu is read-locked by every collection that uses NVML, and
+	// locked to initialize, shut down and reinitialize NVML, so NVML is
+	// never shut down under a collection.
+	reinitMu sync.RWMutex
+	// staleEnumerations counts the consecutive device enumerations that hit
+	// stale NVML handles. Once there are reinitThreshold of them, reinitDue
+	// is set for the next collection to reinitialize NVML.
+	staleEnumerations atomic.Int64
+	reinitDue         atomic.Bool
+	// lastReinit holds the time of the last attempt to reinitialize NVML
+	// in unix nanoseconds.
+	lastReinit atomic.Int64
+	// recovering is set when NVML is shut down to be reinitialized or
+	// failed to be initialized at startup, and cleared once it is
+	// initialized. initialized is set once NVML was initialized at all.
+	recovering  atomic.Bool
+	initialized atomic.Bool
+	reinits     atomic.Int64
+
+	// enumerationErrors counts failed device enumerations, of which
+	// lastEnumerationError holds the error message of the most recent.
+	enumerationErrors    atomic.Int64
+	lastEnumerationError atomic.Pointer[string]
+
+	// deviceCount holds the device count of the last successful
+	// enumeration, served instead of querying NVML when Interval is set.
+	deviceCount atomic.Pointer[int]
+
+	nvmlUp             *prometheus.Desc
+	nvmlReinits        *prometheus.Desc
+	numDevices         *prometheus.Desc
+	lastScrape         *prometheus.Desc
+	lastScrapeDuration *prometheus.Desc
+}
+
+// scheduledCollector is a collector with its refresh interval and the
+// metrics of its last background run.
+type scheduledCollector struct {
+	name      string
+	collector collector
+	interval  time.Duration
+	// needsNVML is false for collectors implementing nvmlIndependent.
+	needsNVML bool
+
+	// enabled is cleared to skip the collector until it is enabled again.
+	enabled atomic.Bool
+
+	// runMu serializes runs of the collector.
+	runMu sync.Mutex
+
+	mu      sync.RWMutex
+	metrics []prometheus.Metric
+	// last holds the metrics, start time and duration of the most recent
+	// run, whether in the background or at scrape time.
+	last         []prometheus.Metric
+	lastRun      time.Time
+	lastDuration time.Duration
+}
+
+// NewExporter returns an Exporter with all collectors. Init must be called
+// before Start.
+func NewExporter(logger *slog.Logger, opts ExporterOpts) *Exporter {
+	e := &Exporter{
+		logger:    logger,
+		opts:      opts,
+		succeeded: make(chan struct{}),
+		nvmlUp: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, "", "nvml_up"),
+			"Whether NVML is initialized (1) or not (0), with the reason it isn't.",
+			[]string{"reason"}, nil,
+		),
+		nvmlReinits: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, "", "nvml_reinits_total"),
+			"Number of times NVML was reinitialized successfully after its handles became stale, e.g. after a driver reload.",
+			nil, nil,
+		),
+		numDevices: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, "", "num_devices"),
+			"Number of GPU devices.",
+			nil, nil,
+		),
+		lastScrape: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, "collector", "last_scrape_timestamp_seconds"),
+			"Time the most recent run of the collector started since unix epoch in seconds.",
+			[]string{"collector"}, nil,
+		),
+		lastScrapeDuration: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, "collector", "scrape_duration_seconds"),
+			"Duration of the most recent run of the collector in seconds.",
+			[]string{"collector"}, nil,
+		),
+	}
+	for _, name := range collectorNames() {
+		interval := opts.Intervals[name]
+		if interval <= 0 {
+			interval = opts.Interval
+		}
+		c := &scheduledCollector{
+			name:      name,
+			collector: collectorFactories[name](logger.With("collector", name)),
+			interval:  interval,
+		}
+		_, independent := c.collector.(nvmlIndependent)
+		c.needsNVML = !independent
+		c.enabled.Store(!opts.Disabled[name])
+		e.collectors = append(e.collectors, c)
+	}
+	return e
+}
+
+// Init initializes NVML. If NVML is unavailable, the error is returned and
+// the exporter only exports nvidia_gpu_nvml_up. Unless the NVML library is
+// missing, as on hosts without the NVIDIA driver, the initialization is
+// retried like a failed reinitialization, e.g. for a driver container that
+// starts after the exporter.
+func (e *Exporter) Init() error {
+	e.reinitMu.Lock()
+	defer e.reinitMu.Unlock()
+	if ret := nvml.Init(); ret != nvml.SUCCESS {
+		e.nvmlErr.Store(&ret)
+		if ret != nvml.ERROR_LIBRARY_NOT_FOUND {
+			e.lastReinit.Store(time.Now().UnixNano())
+			e.recovering.Store(true)
+		}
+		return ret
+	}
+	e.initialized.Store(true)
+	return nil
+}
+
+// Shutdown stops the goroutines of the collectors and shuts NVML down if
+// Init succeeded.
+func (e *Exporter) Shutdown() error {
+	for _, c := range e.collectors {
+		if s, ok := c.collector.(stopper); ok {
+			s.stop()
+		}
+	}
+	e.reinitMu.Lock()
+	defer e.reinitMu.Unlock()
+	if e.nvmlErr.Load() != nil {
+		return nil
+	}
+	if ret := nvml.Shutdown(); ret != nvml.SUCCESS {
+		return ret
+	}
+	return nil
+}
+
+// NVMLAvailable reports whether NVML was initialized successfully.
+func (e *Exporter) NVMLAvailable() bool {
+	return e.nvmlErr.Load() == nil
+}
+
+// Healthy reports whether NVML is usable, or was never available in the
+// first place. It is false while NVML fails to be initialized or
+// reinitialized.
+func (e *Exporter) Healthy() bool {
+	return !e.recovering.Load()
+}
+
+// checkStale counts the consecutive device enumerations that hit stale NVML
+// handles, and marks NVML to be reinitialized once there are reinitThreshold
+// of them. It is called with reinitMu read-locked, so the reinitialization
+// itself is left to recoverNVML.
+func (e *Exporter) checkStale(stale bool) {
+	if !stale {
+		e.staleEnumerations.Store(0)
+		return
+	}
+	if e.staleEnumerations.Add(1) >= reinitThreshold {
+		e.reinitDue.Store(true)
+	}
+}
+
+// backedOff reports whether reinitBackoff has passed since the last attempt
+// to reinitialize NVML.
+func (e *Exporter) backedOff() bool {
+	return time.Since(time.Unix(0, e.lastReinit.Load())) >= reinitBackoff
+}
+
+// recoverNVML reinitializes NVML if checkStale found its handles stale, or
+// retries its initialization if that failed, at most once per
+// reinitBackoff. It reports whether NVML is initialized and must be called
+// without reinitMu held.
+func (e *Exporter) recoverNVML() bool {
+	if !e.reinitDue.Load() && !e.recovering.Load() || !e.backedOff() {
+		return e.NVMLAvailable()
+	}
+	e.reinitMu.Lock()
+	defer e.reinitMu.Unlock()
+	if !e.backedOff() {
+		// Another collection reinitialized NVML while this one waited.
+		return e.NVMLAvailable()
+	}
+	if e.reinitDue.Swap(false) && e.NVMLAvailable() {
+		e.logger.Warn("NVML handles are stale, reinitializing NVML", "enumerations", e.staleEnumerations.Load())
+		e.staleEnumerations.Store(0)
+		if ret := nvml.Shutdown(); ret != nvml.SUCCESS {
+			e.logger.Debug("failed to shut down NVML", "err", ret)
+		}
+		ret := nvml.ERROR_UNINITIALIZED
+		e.nvmlErr.Store(&ret)
+		e.recovering.Store(true)
+	}
+	if e.recovering.Load() {
+		e.tryInit()
+	}
+	return e.NVMLAvailable()
+}
+
+// tryInit initializes NVML again after recoverNVML shut it down or Init
+// failed. It must be called with reinitMu locked.
+func (e *Exporter) tryInit() {
+	e.lastReinit.Store(time.Now().UnixNano())
+	if ret := nvml.Init(); ret != nvml.SUCCESS {
+		e.logger.Debug("failed to reinitialize NVML", "err", ret)
+		e.nvmlErr.Store(&ret)
+		return
+	}
+	e.nvmlErr.Store(nil)
+	e.recovering.Store(false)
+	nvmlSession.Add(1)
+	if !e.initialized.Swap(true) {
+		e.logger.Info("initialized NVML")
+		return
+	}
+	e.reinits.Add(1)
+	e.logger.Info("reinitialized NVML")
+}
+
+// nvmlReasons maps the errors of nvml.Init to the values of the reason label
+// of nvidia_gpu_nvml_up. Other errors are reported as "error".
+var nvmlReasons = map[nvml.Return]string{
+	nvml.ERROR_LIBRARY_NOT_FOUND:  "library_not_found",
+	nvml.ERROR_DRIVER_NOT_LOADED:  "driver_not_loaded",
+	nvml.ERROR_NO_PERMISSION:      "no_permission",
+	nvml.ERROR_FUNCTION_NOT_FOUND: "driver_too_old",
+}
+
+// CollectorState describes a collector of an Exporter.
+type CollectorState struct {
+	Name    string
+	Enabled bool
+	// Interval is the background refresh interval, or 0 for collectors
+	// collected at scrape time.
+	Interval time.Duration
+	// LastRun is the start time of the most recent run, and LastDuration
+	// how long it took. LastRun is zero if the collector hasn't run yet.
+	LastRun      time.Time
+	LastDuration time.Duration
+	// Metrics are the metrics of the most recent run.
+	Metrics []prometheus.Metric
+}
+
+// Collectors returns the state of every collector.
+func (e *Exporter) Collectors() []CollectorState {
+	states := make([]CollectorState, 0, len(e.collectors))
+	for _, c := range e.collectors {
+		c.mu.RLock()
+		states = append(states, CollectorState{
+			Name:         c.name,
+			Enabled:      c.enabled.Load(),
+			Interval:     c.interval,
+			LastRun:      c.lastRun,
+			LastDuration: c.lastDuration,
+			Metrics:      c.last,
+		})
+		c.mu.RUnlock()
+	}
+	return states
+}
+
+// EnumerationErrors returns the number of failed device enumerations and the
+// error message of the most recent one.
+func (e *Exporter) EnumerationErrors() (int64, string) {
+	var last string
+	if msg := e.lastEnumerationError.Load(); msg != nil {
+		last = *msg
+	}
+	return e.enumerationErrors.Load(), last
+}
+
+// SetCollectorEnabled enables or disables the named collector until the
+// exporter restarts. A disabled collector stops querying NVML and its
+// metrics are no longer exported.
+func (e *Exporter) SetCollectorEnabled(name string, enabled bool) error {
+	for _, c := range e.collectors {
+		if c.name != name {
+			continue
+		}
+		if c.enabled.Swap(enabled) != enabled {
+			e.logger.Info("changed collector state", "collector", name, "enabled", enabled)
+		}
+		if !enabled {
+			c.mu.Lock()
+			c.metrics = nil
+			c.mu.Unlock()
+			if s, ok := c.collector.(stopper); ok {
+				s.stop()
+			}
+		}
+		return nil
+	}
+	return fmt.Errorf("unknown collector %q", name)
+}
+
+// Start refreshes the enabled collectors that have an interval in the
+// background until ctx is done, and warms up the others with one collection
+// that is discarded. The returned channel is closed once every collector has
+// completed its first run, so the first scrape doesn't race a cold NVML.
+// Collectors that need NVML skip their runs while it is unavailable.
+func (e *Exporter) Start(ctx context.Context) <-chan struct{} {
+	ready := make(chan struct{})
+
+	var wg sync.WaitGroup
+	for _, c := range e.collectors {
+		wg.Add(1)
+		if c.interval <= 0 {
+			go func() {
+				defer wg.Done()
+				if c.enabled.Load() {
+					e.refresh(c)
+				}
+			}()
+			continue
+		}
+		go func() {
+			first := true
+			ticker := time.NewTicker(c.interval)
+			defer ticker.Stop()
+			for {
+				if c.enabled.Load() {
+					if metrics, ok := e.refresh(c); ok {
+						// The collector may have been disabled while it
+						// ran; SetCollectorEnabled clears its metrics under
+						// c.mu after clearing enabled, so check again here.
+						c.mu.Lock()
+						if c.enabled.Load() {
+							c.metrics = metrics
+						}
+						c.mu.Unlock()
+					}
+				}
+				if first {
+					wg.Done()
+					first = false
+				}
+
+				select {
+				case <-ctx.Done():
+					return
+				case <-ticker.C:
+				}
+			}
+		}()
+	}
+
+	go func() {
+		wg.Wait()
+		close(ready)
+	}()
+	return ready
+}
+
+// refresh runs c outside of a scrape. Collectors that need NVML run with
+// reinitMu read-locked, and not at all while NVML is unavailable, in which
+// case refresh returns false.
+func (e *Exporter) refresh(c *scheduledCollector) ([]prometheus.Metric, bool) {
+	if !c.needsNVML {
+		return e.run(c, nil), true
+	}
+	var metrics []prometheus.Metric
+	ok := e.UseNVML(func() {
+		metrics = e.run(c, e.devices())
+	})
+	return metrics, ok
+}
+
+// UseNVML calls f if NVML is available, with reinitMu read-locked so that
+// NVML isn't reinitialized while f uses it, and reports whether it did.
+func (e *Exporter) UseNVML(f func()) bool {
+	if !e.recoverNVML() {
+		return false
+	}
+	e.reinitMu.RLock()
+	defer e.reinitMu.RUnlock()
+	if !e.NVMLAvailable() {
+		return false
+	}
+	f()
+	return true
+}
+
+// devices enumerates the devices for a collection, counting the enumerations
+// that hit stale handles for recoverNVML. It must be called with reinitMu
+// read-locked and NVML available.
+func (e *Exporter) devices() []*device {
+	devices, stale, err := enumerateDevices(e.logger)
+	if err != nil {
+		e.logger.Error("failed to enumerate devices", "err", err)
+		e.enumerationErrors.Add(1)
+		msg := err.Error()
+		e.lastEnumerationError.Store(&msg)
+		var ret nvml.Return
+		e.checkStale(errors.As(err, &ret) && nvmlStale(ret))
+		return nil
+	}
+	e.checkStale(stale > 0)
+	if count, ret := nvml.DeviceGetCount(); ret == nvml.SUCCESS {
+		e.deviceCount.Store(&count)
+	}
+	e.succeededOnce.Do(func() { close(e.succeeded) })
+	return devices
+}
+
+// Succeeded returns a channel that is closed once NVML has successfully
+// enumerated the devices for a collection.
+func (e *Exporter) Succeeded() <-chan struct{} {
+	return e.succeeded
+}
+
+// Ready reports whether NVML has successfully enumerated the devices for a
+// collection at least once.
+func (e *Exporter) Ready() bool {
+	select {
+	case <-e.succeeded:
+		return true
+	default:
+		return false
+	}
+}
+
+// run collects the metrics of c for devices.
+func (e *Exporter) run(c *scheduledCollector, devices []*device) []prometheus.Metric {
+	c.runMu.Lock()
+	defer c.runMu.Unlock()
+
+	now := time.Now()
+	ch := make(chan prometheus.Metric)
+	go func() {
+		c.collector.Collect(ch, devices)
+		close(ch)
+	}()
+
+	var metrics []prometheus.Metric
+	for m := range ch {
+		if e.opts.Timestamps {
+			m = prometheus.NewMetricWithTimestamp(now, m)
+		}
+		metrics = append(metrics, m)
+	}
+
+	c.mu.Lock()
+	c.last = metrics
+	c.lastRun = now
+	c.lastDuration = time.Since(now)
+	c.mu.Unlock()
+	return metrics
+}
+
+// Describe implements prometheus.Collector.
+func (e *Exporter) Describe(ch chan<- *prometheus.Desc) {
+	ch <- e.nvmlUp
+	ch <- e.nvmlReinits
+	ch <- e.numDevices
+	ch <- e.lastScrape
+	ch <- e.lastScrapeDuration
+	for _, c := range e.collectors {
+		c.collector.Describe(ch)
+	}
+}
+
+// Collect implements prometheus.Collector.
+func (e *Exporter) Collect(ch chan<- prometheus.Metric) {
+	ch <- prometheus.MustNewConstMetric(e.nvmlReinits, prometheus.CounterValue, float64(e.reinits.Load()))
+	e.recoverNVML()
+	e.reinitMu.RLock()
+	defer e.reinitMu.RUnlock()
+	ret := e.nvmlErr.Load()
+	nvmlAvailable := ret == nil
+	if nvmlAvailable {
+		ch <- prometheus.MustNewConstMetric(e.nvmlUp, prometheus.GaugeValue, 1, "")
+		e.collectDeviceCount(ch)
+	} else {
+		reason, ok := nvmlReasons[*ret]
+		if !ok {
+			reason = "error"
+		}
+		ch <- prometheus.MustNewConstMetric(e.nvmlUp, prometheus.GaugeValue, 0, reason)
+	}
+
+	var devices []*device
+	enumerated := false
+	for _, c := range e.collectors {
+		if !c.enabled.Load() || c.needsNVML && !nvmlAvailable {
+			continue
+		}
+		if c.interval > 0 {
+			c.mu.RLock()
+			for _, m := range c.metrics {
+				ch <- m
+			}
+			c.mu.RUnlock()
+		} else if !c.needsNVML {
+			for _, m := range e.run(c, nil) {
+				ch <- m
+			}
+		} else {
+			if !enumerated {
+				devices = e.devices()
+				enumerated = true
+			}
+			for _, m := range e.run(c, devices) {
+				ch <- m
+			}
+		}
+
+		c.mu.RLock()
+		lastRun, lastDuration := c.lastRun, c.lastDuration
+		c.mu.RUnlock()
+		if !lastRun.IsZero() {
+			ch <- prometheus.MustNewConstMetric(e.lastScrape, prometheus.GaugeValue, float64(lastRun.UnixNano())/1e9, c.name)
+			ch <- prometheus.MustNewConstMetric(e.lastScrapeDuration, prometheus.GaugeValue, lastDuration.Seconds(), c.name)
+		}
+	}
+}
+
+// collectDeviceCount sends the number of devices, from the last enumeration
+// if scrapes must not query NVML.
+func (e *Exporter) collectDeviceCount(ch chan<- prometheus.Metric) {
+	if e.opts.Interval > 0 {
+		if count := e.deviceCount.Load(); count != nil {
+			ch <- prometheus.MustNewConstMetric(e.numDevices, prometheus.GaugeValue, float64(*count))
+		}
+	} else if count, ret := nvml.DeviceGetCount(); ret != nvml.SUCCESS {
+		e.logger.Error("failed to get device count", "err", ret)
+	} else {
+		ch <- prometheus.MustNewConstMetric(e.numDevices, prometheus.GaugeValue, float64(count))
+	}
+}
@@ -0,0 +1,108 @@
+package main
+
+import (
+	"context"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/NVIDIA/go-nvml/pkg/nvml"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// blockingCollector sends one metric once release is closed, after closing
+// started.
+type blockingCollector struct {
+	desc    *prometheus.Desc
+	started chan struct{}
+	release chan struct{}
+}
+
+func (c *blockingCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.desc
+}
+
+func (c *blockingCollector) Collect(ch chan<- prometheus.Metric, devices []*device) {
+	close(c.started)
+	<-c.release
+	ch <- prometheus.MustNewConstMetric(c.desc, prometheus.GaugeValue, 1)
+}
+
+func (c *blockingCollector) nvmlIndependent() {}
+
+func TestSetCollectorEnabledDuringRun(t *testing.T) {
+	c := &blockingCollector{
+		desc:    prometheus.NewDesc("test_metric", "Test metric.", nil, nil),
+		started: make(chan struct{}),
+		release: make(chan struct{}),
+	}
+	sc := &scheduledCollector{name: "test", collector: c, interval: time.Hour}
+	sc.enabled.Store(true)
+	e := &Exporter{logger: slog.New(slog.DiscardHandler), collectors: []*scheduledCollector{sc}}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	ready := e.Start(ctx)
+	<-c.started
+	if err := e.SetCollectorEnabled("test", false); err != nil {
+		t.Fatal(err)
+	}
+	close(c.release)
+	<-ready
+
+	sc.mu.RLock()
+	defer sc.mu.RUnlock()
+	if len(sc.metrics) != 0 {
+		t.Errorf("disabled collector kept %d metrics of a run in flight", len(sc.metrics))
+	}
+}
+
+func TestCheckStale(t *testing.T) {
+	e := &Exporter{logger: slog.New(slog.DiscardHandler)}
+	for i, stale := range []bool{true, true, false, true, true} {
+		e.checkStale(stale)
+		if e.reinitDue.Load() {
+			t.Fatalf("reinitialization due after enumeration %d", i)
+		}
+	}
+	e.checkStale(true)
+	if !e.reinitDue.Load() {
+		t.Errorf("reinitialization not due after %d stale enumerations", reinitThreshold)
+	}
+}
+
+func TestRecoverNVMLBackoff(t *testing.T) {
+	e := &Exporter{logger: slog.New(slog.DiscardHandler)}
+	ret := nvml.ERROR_UNINITIALIZED
+	e.nvmlErr.Store(&ret)
+	e.recovering.Store(true)
+
+	if e.recoverNVML() {
+		t.Skip("NVML is available")
+	}
+	attempted := e.lastReinit.Load()
+	if attempted == 0 {
+		t.Fatal("recoverNVML didn't retry the initialization")
+	}
+	e.recoverNVML()
+	if e.lastReinit.Load() != attempted {
+		t.Error("recoverNVML retried the initialization within reinitBackoff")
+	}
+
+	e.lastReinit.Store(time.Now().Add(-reinitBackoff).UnixNano())
+	e.recoverNVML()
+	if e.lastReinit.Load() <= attempted {
+		t.Error("recoverNVML didn't retry the initialization after reinitBackoff")
+	}
+}
+
+func TestInitWithoutLibrary(t *testing.T) {
+	e := &Exporter{logger: slog.New(slog.DiscardHandler)}
+	if err := e.Init(); err != nvml.ERROR_LIBRARY_NOT_FOUND {
+		t.Skipf("Init() = %v, want the NVML library to be missing", err)
+	}
+	// Without the library there is nothing to retry.
+	if !e.Healthy() || e.recoverNVML() || e.lastReinit.Load() != 0 {
+		t.Error("Init without the NVML library is retried")
+	}
+}
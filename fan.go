@@ -0,0 +1,53 @@
+package main
+
+import (
+	"log/slog"
+
+	"github.com/NVIDIA/go-nvml/pkg/nvml"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+func init() {
+	registerCollector("fan", defaultEnabled, newFanCollector)
+}
+
+// fanCollector exports the fan speed of each device.
+type fanCollector struct {
+	logger *slog.Logger
+
+	speed    *prometheus.Desc
+	speedRPM *prometheus.Desc
+}
+
+func newFanCollector(logger *slog.Logger) collector {
+	return &fanCollector{
+		logger: logger,
+		speed: deviceDesc("", "fanspeed_percent",
+			"Fan speed of the GPU device as a percent of its maximum."),
+		speedRPM: deviceDesc("", "fanspeed_rpm",
+			"Fan speed of the GPU device in revolutions per minute. Requires driver 550 or newer."),
+	}
+}
+
+func (c *fanCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.speed
+	ch <- c.speedRPM
+}
+
+func (c *fanCollector) Collect(ch chan<- prometheus.Metric, devices []*device) {
+	for _, d := range devices {
+		// Like GetFanSpeed, GetFanSpeedRPM reports the first fan.
+		if rpm, ret := d.GetFanSpeedRPM(); ret == nvml.SUCCESS {
+			ch <- prometheus.MustNewConstMetric(c.speedRPM, prometheus.GaugeValue, float64(rpm.Speed), d.labels...)
+		} else {
+			c.logger.Debug("failed to get fan speed in RPM", "uuid", d.uuid, "err", ret)
+		}
+
+		speed, ret := d.GetFanSpeed()
+		if ret != nvml.SUCCESS {
+			c.logger.Debug("failed to get fan speed", "uuid", d.uuid, "err", ret)
+			continue
+		}
+		ch <- prometheus.MustNewConstMetric(c.speed, prometheus.GaugeValue, float64(speed), d.labels...)
+	}
+}
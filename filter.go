@@ -0,0 +1,51 @@
+package main
+
+import (
+	"regexp"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+)
+
+// filterGatherer drops metric families gathered by the wrapped Gatherer
+// whose names don't match include or do match exclude. A nil regexp
+// disables the corresponding filter.
+type filterGatherer struct {
+	prometheus.Gatherer
+	include *regexp.Regexp
+	exclude *regexp.Regexp
+}
+
+// newFilterGatherer compiles the include and exclude patterns, which must
+// match whole metric names. Empty patterns disable the filter.
+func newFilterGatherer(g prometheus.Gatherer, include, exclude string) (*filterGatherer, error) {
+	f := &filterGatherer{Gatherer: g}
+	var err error
+	if include != "" {
+		if f.include, err = regexp.Compile("^(?:" + include + ")$"); err != nil {
+			return nil, err
+		}
+	}
+	if exclude != "" {
+		if f.exclude, err = regexp.Compile("^(?:" + exclude + ")$"); err != nil {
+			return nil, err
+		}
+	}
+	return f, nil
+}
+
+// Gather implements prometheus.Gatherer.
+func (f *filterGatherer) Gather() ([]*dto.MetricFamily, error) {
+	families, err := f.Gatherer.Gather()
+	filtered := families[:0]
+	for _, mf := range families {
+		if f.include != nil && !f.include.MatchString(mf.GetName()) {
+			continue
+		}
+		if f.exclude != nil && f.exclude.MatchString(mf.GetName()) {
+			continue
+		}
+		filtered = append(filtered, mf)
+	}
+	return filtered, err
+}
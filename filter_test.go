@@ -0,0 +1,85 @@
+package main
+
+import (
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+)
+
+func TestFilterGatherer(t *testing.T) {
+	names := []string{
+		"nvidia_gpu_duty_cycle",
+		"nvidia_gpu_memory_used_bytes",
+		"nvidia_gpu_memory_total_bytes",
+		"nvidia_gpu_process_memory_used_bytes",
+		"go_goroutines",
+	}
+	for _, tc := range []struct {
+		name, include, exclude string
+		want                   []string
+	}{
+		{"no filters", "", "", names},
+		{"include", "nvidia_gpu_memory_.*", "", []string{"nvidia_gpu_memory_used_bytes", "nvidia_gpu_memory_total_bytes"}},
+		{"exclude", "", "nvidia_gpu_process_.*|go_.*", names[:3]},
+		{"include and exclude", "nvidia_gpu_.*", "nvidia_gpu_memory_total_bytes", []string{"nvidia_gpu_duty_cycle", "nvidia_gpu_memory_used_bytes", "nvidia_gpu_process_memory_used_bytes"}},
+		// Patterns are anchored to whole names.
+		{"anchored include", "memory_used_bytes", "", nil},
+		{"anchored exclude", "", "duty_cycle", names},
+		{"alternation anchored", "go_goroutines|nvidia_gpu_duty_cycle", "", []string{"nvidia_gpu_duty_cycle", "go_goroutines"}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			f, err := newFilterGatherer(prometheus.GathererFunc(func() ([]*dto.MetricFamily, error) {
+				var families []*dto.MetricFamily
+				for _, name := range names {
+					families = append(families, family(name, dto.MetricType_GAUGE))
+				}
+				return families, nil
+			}), tc.include, tc.exclude)
+			if err != nil {
+				t.Fatal(err)
+			}
+			families, err := f.Gather()
+			if err != nil {
+				t.Fatal(err)
+			}
+			var got []string
+			for _, mf := range families {
+				got = append(got, mf.GetName())
+			}
+			if !slices.Equal(got, tc.want) {
+				t.Errorf("got %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestFilterGathererInvalidPattern(t *testing.T) {
+	for _, tc := range []struct{ include, exclude string }{
+		{"nvidia_gpu_(", ""},
+		{"", "[a-"},
+	} {
+		if _, err := newFilterGatherer(prometheus.DefaultGatherer, tc.include, tc.exclude); err == nil {
+			t.Errorf("newFilterGatherer(%q, %q) succeeded", tc.include, tc.exclude)
+		}
+	}
+}
+
+func TestFilterGathererKeepsError(t *testing.T) {
+	gatherErr := errors.New("collector failed")
+	f, err := newFilterGatherer(prometheus.GathererFunc(func() ([]*dto.MetricFamily, error) {
+		return []*dto.MetricFamily{family("a", dto.MetricType_GAUGE), family("b", dto.MetricType_GAUGE)}, gatherErr
+	}), "", "b")
+	if err != nil {
+		t.Fatal(err)
+	}
+	families, err := f.Gather()
+	if !errors.Is(err, gatherErr) {
+		t.Errorf("Gather() error = %v, want %v", err, gatherErr)
+	}
+	if len(families) != 1 || families[0].GetName() != "a" {
+		t.Errorf("Gather() = %v, want the partial result filtered", families)
+	}
+}
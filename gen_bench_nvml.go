@@ -0,0 +1,130 @@
+//go:build ignore
+
+// gen_bench_nvml generates bench_nvml.go, which implements every method of
+// nvml.Device on timedDevice and wraps every package-level function of nvml,
+// so that the bench subcommand times all NVML calls without listing them by
+// hand. Run it with go generate after upgrading go-nvml.
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"log"
+	"os"
+	"reflect"
+	"strings"
+
+	"github.com/NVIDIA/go-nvml/pkg/nvml"
+)
+
+func main() {
+	device := reflect.TypeFor[nvml.Device]()
+	// go-nvml declares a package-level function variable for every method
+	// of nvml.Interface.
+	library := reflect.TypeFor[nvml.Interface]()
+
+	var b bytes.Buffer
+	fmt.Fprintf(&b, "// Code generated by go run gen_bench_nvml.go; DO NOT EDIT.\n\n")
+	fmt.Fprintf(&b, "package main\n\n")
+	fmt.Fprintf(&b, "import (\n\t\"time\"\n\n\t\"github.com/NVIDIA/go-nvml/pkg/nvml\"\n)\n")
+	for i := range device.NumMethod() {
+		m := device.Method(i)
+		if !m.IsExported() {
+			continue
+		}
+		fmt.Fprintf(&b, "\nfunc (d timedDevice) %s%s {\n", m.Name, signature(m.Type))
+		writeBody(&b, m.Name, m.Type, "d.Device."+m.Name, "d.calls", device)
+		fmt.Fprintf(&b, "}\n")
+	}
+
+	fmt.Fprintf(&b, "\n// timeLibraryCalls replaces the package-level functions of nvml with ones\n")
+	fmt.Fprintf(&b, "// that record their duration in calls. Devices they return are wrapped in\n")
+	fmt.Fprintf(&b, "// timedDevice.\n")
+	fmt.Fprintf(&b, "func timeLibraryCalls(calls map[string]*benchStats) {\n")
+	for i := range library.NumMethod() {
+		m := library.Method(i)
+		if !m.IsExported() {
+			continue
+		}
+		fmt.Fprintf(&b, "\t{\n\t\tf := nvml.%s\n", m.Name)
+		fmt.Fprintf(&b, "\t\tnvml.%s = func%s {\n", m.Name, signature(m.Type))
+		writeBody(&b, m.Name, m.Type, "f", "calls", device)
+		fmt.Fprintf(&b, "\t\t}\n\t}\n")
+	}
+	fmt.Fprintf(&b, "}\n")
+
+	src, err := format.Source(b.Bytes())
+	if err != nil {
+		log.Fatalf("failed to format generated code: %v", err)
+	}
+	if err := os.WriteFile("bench_nvml.go", src, 0o644); err != nil {
+		log.Fatal(err)
+	}
+}
+
+// signature returns the parameters and results of the function type t, with
+// the parameters named a0, a1 and so on.
+func signature(t reflect.Type) string {
+	params := make([]string, t.NumIn())
+	for i := range t.NumIn() {
+		typ := t.In(i).String()
+		if t.IsVariadic() && i == t.NumIn()-1 {
+			typ = "..." + t.In(i).Elem().String()
+		}
+		params[i] = fmt.Sprintf("a%d %s", i, typ)
+	}
+	results := make([]string, t.NumOut())
+	for i := range t.NumOut() {
+		results[i] = t.Out(i).String()
+	}
+	switch len(results) {
+	case 0:
+		return fmt.Sprintf("(%s)", strings.Join(params, ", "))
+	case 1:
+		return fmt.Sprintf("(%s) %s", strings.Join(params, ", "), results[0])
+	default:
+		return fmt.Sprintf("(%s) (%s)", strings.Join(params, ", "), strings.Join(results, ", "))
+	}
+}
+
+// writeBody writes the body of a function of type t that records the
+// duration of calling fn in calls under name. Devices fn returns, such as MIG
+// device handles, are wrapped in timedDevice too.
+func writeBody(b *bytes.Buffer, name string, t reflect.Type, fn, calls string, device reflect.Type) {
+	args := make([]string, t.NumIn())
+	for i := range t.NumIn() {
+		args[i] = fmt.Sprintf("a%d", i)
+		if t.IsVariadic() && i == t.NumIn()-1 {
+			args[i] += "..."
+		}
+	}
+	var wrapped []int
+	for i := range t.NumOut() {
+		if t.Out(i) == device {
+			wrapped = append(wrapped, i)
+		}
+	}
+
+	call := fmt.Sprintf("%s(%s)", fn, strings.Join(args, ", "))
+	switch {
+	case t.NumOut() == 0:
+		fmt.Fprintf(b, "\tdefer recordCall(%s, %q, time.Now())\n", calls, name)
+		fmt.Fprintf(b, "\t%s\n", call)
+	case len(wrapped) == 0:
+		fmt.Fprintf(b, "\tdefer recordCall(%s, %q, time.Now())\n", calls, name)
+		fmt.Fprintf(b, "\treturn %s\n", call)
+	default:
+		vars := make([]string, t.NumOut())
+		for i := range vars {
+			vars[i] = fmt.Sprintf("r%d", i)
+		}
+		fmt.Fprintf(b, "\tstart := time.Now()\n")
+		fmt.Fprintf(b, "\t%s := %s\n", strings.Join(vars, ", "), call)
+		fmt.Fprintf(b, "\trecordCall(%s, %q, start)\n", calls, name)
+		for _, i := range wrapped {
+			fmt.Fprintf(b, "\tif r%d != nil {\n\t\tr%d = timedDevice{Device: r%d, calls: %s}\n\t}\n", i, i, i, calls)
+		}
+		fmt.Fprintf(b, "\treturn %s\n", strings.Join(vars, ", "))
+	}
+}
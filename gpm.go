@@ -0,0 +1,116 @@
+package main
+
+import (
+	"log/slog"
+
+	"github.com/NVIDIA/go-nvml/pkg/nvml"
+)
+
+// gpmSampler computes GPU Performance Monitoring (GPM) metrics. GPM metrics
+// are computed over the interval between two samples, so the sampler keeps
+// the previous sample per device or MIG device uuid and the first call for a
+// device only records a sample. A gpmSampler must not be used concurrently.
+type gpmSampler struct {
+	logger  *slog.Logger
+	samples map[string]nvml.GpmSample
+	// sampled holds the keys sampled since the previous sweep.
+	sampled map[string]bool
+	// session is the NVML session the samples were taken in.
+	session uint64
+}
+
+func newGPMSampler(logger *slog.Logger) *gpmSampler {
+	return &gpmSampler{
+		logger:  logger,
+		samples: make(map[string]nvml.GpmSample),
+		sampled: make(map[string]bool),
+		session: nvmlSession.Load(),
+	}
+}
+
+// sweep frees the samples of the devices that weren't sampled since the
+// previous sweep, e.g. destroyed MIG devices or lost GPUs. Collectors call
+// it at the end of every collection.
+func (s *gpmSampler) sweep() {
+	for key, sample := range s.samples {
+		if !s.sampled[key] {
+			sample.Free()
+			delete(s.samples, key)
+		}
+	}
+	clear(s.sampled)
+}
+
+// metrics takes a sample of d and returns the value of each of ids over the
+// interval since the previous sample. Metrics NVML can't compute for the
+// device are missing from the result, which is nil on devices without GPM
+// support and on the first call for a device.
+func (s *gpmSampler) metrics(d *device, ids ...nvml.GpmMetricId) map[nvml.GpmMetricId]float64 {
+	return s.compute(d, d.uuid, d.GpmSampleGet, ids)
+}
+
+// migMetrics is like metrics for the GPU instance of d with the given id,
+// which is identified by the key, e.g. the uuid of its MIG device.
+func (s *gpmSampler) migMetrics(d *device, gpuInstanceID int, key string, ids ...nvml.GpmMetricId) map[nvml.GpmMetricId]float64 {
+	return s.compute(d, key, func(sample nvml.GpmSample) nvml.Return {
+		return d.GpmMigSampleGet(gpuInstanceID, sample)
+	}, ids)
+}
+
+// compute takes a sample with get, stores it under key and computes ids
+// over the interval since the previous sample stored under key.
+func (s *gpmSampler) compute(d *device, key string, get func(nvml.GpmSample) nvml.Return, ids []nvml.GpmMetricId) map[nvml.GpmMetricId]float64 {
+	if session := nvmlSession.Load(); session != s.session {
+		// Intervals can't span a reinitialization of NVML.
+		for _, sample := range s.samples {
+			sample.Free()
+		}
+		clear(s.samples)
+		s.session = session
+	}
+	s.sampled[key] = true
+
+	support, ret := d.GpmQueryDeviceSupport()
+	if ret != nvml.SUCCESS || support.IsSupportedDevice == 0 {
+		return nil
+	}
+
+	sample, ret := nvml.GpmSampleAlloc()
+	if ret != nvml.SUCCESS {
+		s.logger.Debug("failed to allocate GPM sample", "uuid", key, "err", ret)
+		return nil
+	}
+	if ret := get(sample); ret != nvml.SUCCESS {
+		s.logger.Debug("failed to get GPM sample", "uuid", key, "err", ret)
+		sample.Free()
+		return nil
+	}
+
+	previous, ok := s.samples[key]
+	s.samples[key] = sample
+	if !ok {
+		return nil
+	}
+	defer previous.Free()
+
+	request := nvml.GpmMetricsGetType{
+		NumMetrics: uint32(len(ids)),
+		Sample1:    previous,
+		Sample2:    sample,
+	}
+	for i, id := range ids {
+		request.Metrics[i].MetricId = uint32(id)
+	}
+	if ret := nvml.GpmMetricsGet(&request); ret != nvml.SUCCESS {
+		s.logger.Debug("failed to get GPM metrics", "uuid", key, "err", ret)
+		return nil
+	}
+
+	values := make(map[nvml.GpmMetricId]float64, len(ids))
+	for i, id := range ids {
+		if nvml.Return(request.Metrics[i].NvmlReturn) == nvml.SUCCESS {
+			values[id] = request.Metrics[i].Value
+		}
+	}
+	return values
+}
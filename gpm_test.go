@@ -0,0 +1,63 @@
+package main
+
+import (
+	"log/slog"
+	"testing"
+
+	"github.com/NVIDIA/go-nvml/pkg/nvml"
+	"github.com/NVIDIA/go-nvml/pkg/nvml/mock"
+)
+
+func newMockSample() *mock.GpmSample {
+	return &mock.GpmSample{FreeFunc: func() nvml.Return { return nvml.SUCCESS }}
+}
+
+// unsupportedDevice returns a device without GPM support, so sampling it
+// stops before taking a sample.
+func unsupportedDevice(uuid string) *device {
+	return &device{
+		uuid: uuid,
+		Device: &mock.Device{
+			GpmQueryDeviceSupportFunc: func() (nvml.GpmSupport, nvml.Return) {
+				return nvml.GpmSupport{}, nvml.ERROR_NOT_SUPPORTED
+			},
+		},
+	}
+}
+
+func TestGPMSamplerSweep(t *testing.T) {
+	s := newGPMSampler(slog.New(slog.DiscardHandler))
+	kept, lost := newMockSample(), newMockSample()
+	s.samples["GPU-0"] = kept
+	s.samples["MIG-1"] = lost
+
+	s.metrics(unsupportedDevice("GPU-0"))
+	s.sweep()
+	if _, ok := s.samples["GPU-0"]; !ok || len(kept.FreeCalls()) != 0 {
+		t.Error("sweep dropped the sample of a sampled device")
+	}
+	if _, ok := s.samples["MIG-1"]; ok || len(lost.FreeCalls()) != 1 {
+		t.Error("sweep kept the sample of a device that wasn't sampled")
+	}
+
+	// Every collection must sample a device again to keep its sample.
+	s.sweep()
+	if len(s.samples) != 0 || len(kept.FreeCalls()) != 1 {
+		t.Errorf("second sweep kept %d samples", len(s.samples))
+	}
+}
+
+func TestGPMSamplerReinit(t *testing.T) {
+	s := newGPMSampler(slog.New(slog.DiscardHandler))
+	previous := newMockSample()
+	s.samples["GPU-0"] = previous
+
+	nvmlSession.Add(1)
+	s.metrics(unsupportedDevice("GPU-0"))
+	if len(s.samples) != 0 || len(previous.FreeCalls()) != 1 {
+		t.Error("sample of the previous NVML session was kept")
+	}
+	if s.session != nvmlSession.Load() {
+		t.Errorf("session = %d, want %d", s.session, nvmlSession.Load())
+	}
+}
@@ -0,0 +1,163 @@
+package main
+
+import (
+	"log/slog"
+
+	"github.com/NVIDIA/go-nvml/pkg/nvml"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+func init() {
+	registerCollector("health", defaultEnabled, newHealthCollector)
+}
+
+// Health states of a device.
+const (
+	// healthHealthy is a device without known problems.
+	healthHealthy = "healthy"
+	// healthDegraded is a device that still works but needs attention, e.g.
+	// because of pending memory row remapping or hardware slowdown.
+	healthDegraded = "degraded"
+	// healthLost is a device that fell off the bus, requires a reset or can
+	// no longer be enumerated.
+	healthLost = "lost"
+)
+
+var healthStates = []string{healthHealthy, healthDegraded, healthLost}
+
+// hwSlowdownReasons are the clock event reasons that indicate a hardware
+// problem rather than a workload or configuration choice.
+const hwSlowdownReasons = nvml.ClocksThrottleReasonHwSlowdown |
+	nvml.ClocksThrottleReasonHwThermalSlowdown |
+	nvml.ClocksThrottleReasonHwPowerBrakeSlowdown
+
+// evaluateHealth returns the health state of d.
+func evaluateHealth(d *device) string {
+	if _, ret := d.GetTemperature(nvml.TEMPERATURE_GPU); ret == nvml.ERROR_GPU_IS_LOST || ret == nvml.ERROR_RESET_REQUIRED {
+		return healthLost
+	}
+
+	if _, _, pending, failure, ret := d.GetRemappedRows(); ret == nvml.SUCCESS {
+		if pending || failure {
+			return healthDegraded
+		}
+	} else if status, ret := d.GetRetiredPagesPendingStatus(); ret == nvml.SUCCESS && status == nvml.FEATURE_ENABLED {
+		return healthDegraded
+	}
+
+	if reasons, ret := d.GetCurrentClocksEventReasons(); ret == nvml.SUCCESS && reasons&hwSlowdownReasons != 0 {
+		return healthDegraded
+	}
+	return healthHealthy
+}
+
+// healthCollector exports the number of devices in each health state and
+// whether each device is healthy, and counts per device how often it was
+// lost and recovered. Devices that were enumerated before but no longer are
+// counted as lost until they reappear.
+type healthCollector struct {
+	logger *slog.Logger
+
+	devices    *prometheus.Desc
+	healthy    *prometheus.Desc
+	resets     *prometheus.Desc
+	recoveries *prometheus.Desc
+
+	// known holds the history of every device enumerated so far by uuid.
+	known map[string]*deviceHistory
+}
+
+// deviceHistory tracks the availability of a device across collections.
+type deviceHistory struct {
+	// labels are the device labels from the last time it was enumerated, so
+	// its counters can be exported while it is missing.
+	labels []string
+	lost   bool
+
+	resets     int
+	recoveries int
+}
+
+// setLost records whether the device is lost, counting the transitions.
+func (h *deviceHistory) setLost(lost bool) {
+	switch {
+	case lost && !h.lost:
+		h.resets++
+	case !lost && h.lost:
+		h.recoveries++
+	}
+	h.lost = lost
+}
+
+func newHealthCollector(logger *slog.Logger) collector {
+	return &healthCollector{
+		logger: logger,
+		devices: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, "", "devices"),
+			"Number of GPU devices of the node in each health state (healthy, degraded or lost).",
+			[]string{"state"}, nil,
+		),
+		healthy: deviceDesc("device", "healthy",
+			"Whether the GPU device is healthy (1) or degraded or lost (0)."),
+		resets: deviceDesc("", "resets_total",
+			"Number of times the GPU device was observed to fall off the bus, require a reset or disappear from enumeration."),
+		recoveries: deviceDesc("", "recoveries_total",
+			"Number of times the GPU device was observed to become available again after a reset."),
+		known: make(map[string]*deviceHistory),
+	}
+}
+
+func (c *healthCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.devices
+	ch <- c.healthy
+	ch <- c.resets
+	ch <- c.recoveries
+}
+
+func (c *healthCollector) Collect(ch chan<- prometheus.Metric, devices []*device) {
+	if devices == nil {
+		// Enumeration failed, so the state of the devices is unknown.
+		return
+	}
+
+	counts := make(map[string]int, len(healthStates))
+	present := make(map[string]bool, len(devices))
+	for _, d := range devices {
+		state := evaluateHealth(d)
+		if state != healthHealthy {
+			c.logger.Debug("device is not healthy", "uuid", d.uuid, "state", state)
+		}
+		counts[state]++
+		present[d.uuid] = true
+		healthy := 0.0
+		if state == healthHealthy {
+			healthy = 1
+		}
+		ch <- prometheus.MustNewConstMetric(c.healthy, prometheus.GaugeValue, healthy, d.labels...)
+
+		h := c.known[d.uuid]
+		if h == nil {
+			h = &deviceHistory{}
+			c.known[d.uuid] = h
+		}
+		h.labels = d.labels
+		if h.lost && state != healthLost {
+			c.logger.Info("device recovered", "uuid", d.uuid)
+		}
+		h.setLost(state == healthLost)
+	}
+	for uuid, h := range c.known {
+		if !present[uuid] {
+			c.logger.Debug("device is no longer enumerated", "uuid", uuid)
+			counts[healthLost]++
+			h.setLost(true)
+			ch <- prometheus.MustNewConstMetric(c.healthy, prometheus.GaugeValue, 0, h.labels...)
+		}
+		ch <- prometheus.MustNewConstMetric(c.resets, prometheus.CounterValue, float64(h.resets), h.labels...)
+		ch <- prometheus.MustNewConstMetric(c.recoveries, prometheus.CounterValue, float64(h.recoveries), h.labels...)
+	}
+
+	for _, state := range healthStates {
+		ch <- prometheus.MustNewConstMetric(c.devices, prometheus.GaugeValue, float64(counts[state]), state)
+	}
+}
@@ -0,0 +1,153 @@
+package main
+
+import (
+	"log/slog"
+	"testing"
+
+	"github.com/NVIDIA/go-nvml/pkg/nvml"
+	"github.com/NVIDIA/go-nvml/pkg/nvml/mock"
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+)
+
+func TestDeviceHistorySetLost(t *testing.T) {
+	for _, tc := range []struct {
+		name       string
+		lost       []bool
+		resets     int
+		recoveries int
+	}{
+		{"healthy", []bool{false, false}, 0, 0},
+		{"lost", []bool{false, true}, 1, 0},
+		{"lost from the start", []bool{true}, 1, 0},
+		{"still lost", []bool{false, true, true, true}, 1, 0},
+		{"recovered", []bool{false, true, false}, 1, 1},
+		{"lost again", []bool{false, true, false, true, true, false}, 2, 2},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			h := &deviceHistory{}
+			for _, lost := range tc.lost {
+				h.setLost(lost)
+			}
+			if h.resets != tc.resets || h.recoveries != tc.recoveries {
+				t.Errorf("resets, recoveries = %d, %d, want %d, %d", h.resets, h.recoveries, tc.resets, tc.recoveries)
+			}
+			if want := tc.lost[len(tc.lost)-1]; h.lost != want {
+				t.Errorf("lost = %v, want %v", h.lost, want)
+			}
+		})
+	}
+}
+
+// healthDevice returns a device whose temperature query returns tempRet and
+// whose clock event reasons are reasons.
+func healthDevice(uuid string, tempRet nvml.Return, reasons uint64) *device {
+	return &device{
+		uuid:   uuid,
+		labels: []string{uuid},
+		Device: &mock.Device{
+			GetTemperatureFunc: func(nvml.TemperatureSensors) (uint32, nvml.Return) {
+				return 40, tempRet
+			},
+			GetRemappedRowsFunc: func() (int, int, bool, bool, nvml.Return) {
+				return 0, 0, false, false, nvml.SUCCESS
+			},
+			GetCurrentClocksEventReasonsFunc: func() (uint64, nvml.Return) {
+				return reasons, nvml.SUCCESS
+			},
+		},
+	}
+}
+
+func TestEvaluateHealth(t *testing.T) {
+	for _, tc := range []struct {
+		name    string
+		device  *device
+		pending bool
+		want    string
+	}{
+		{"healthy", healthDevice("GPU-0", nvml.SUCCESS, 0), false, healthHealthy},
+		{"sw slowdown", healthDevice("GPU-0", nvml.SUCCESS, nvml.ClocksThrottleReasonSwPowerCap), false, healthHealthy},
+		{"hw slowdown", healthDevice("GPU-0", nvml.SUCCESS, nvml.ClocksThrottleReasonHwThermalSlowdown), false, healthDegraded},
+		{"pending remap", healthDevice("GPU-0", nvml.SUCCESS, 0), true, healthDegraded},
+		{"lost", healthDevice("GPU-0", nvml.ERROR_GPU_IS_LOST, 0), false, healthLost},
+		{"reset required", healthDevice("GPU-0", nvml.ERROR_RESET_REQUIRED, 0), false, healthLost},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.device.Device.(*mock.Device).GetRemappedRowsFunc = func() (int, int, bool, bool, nvml.Return) {
+				return 0, 0, tc.pending, false, nvml.SUCCESS
+			}
+			if got := evaluateHealth(tc.device); got != tc.want {
+				t.Errorf("evaluateHealth() = %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
+
+// healthCounts collects c for devices and returns the resets and recoveries
+// counted for each device by its first label.
+func healthCounts(t *testing.T, c collector, devices []*device) (resets, recoveries map[string]float64) {
+	t.Helper()
+	resets, recoveries = make(map[string]float64), make(map[string]float64)
+	ch := make(chan prometheus.Metric)
+	go func() {
+		c.Collect(ch, devices)
+		close(ch)
+	}()
+	hc := c.(*healthCollector)
+	for m := range ch {
+		var pb dto.Metric
+		if err := m.Write(&pb); err != nil {
+			t.Fatal(err)
+		}
+		switch m.Desc() {
+		case hc.resets:
+			resets[pb.GetLabel()[0].GetValue()] = pb.GetCounter().GetValue()
+		case hc.recoveries:
+			recoveries[pb.GetLabel()[0].GetValue()] = pb.GetCounter().GetValue()
+		}
+	}
+	return resets, recoveries
+}
+
+func TestHealthCollector(t *testing.T) {
+	defer func(labels []string) { deviceLabels = labels }(deviceLabels)
+	deviceLabels = []string{"uuid"}
+
+	c := newHealthCollector(slog.New(slog.DiscardHandler))
+	gpu0 := healthDevice("GPU-0", nvml.SUCCESS, 0)
+	gpu1 := healthDevice("GPU-1", nvml.SUCCESS, 0)
+	lost1 := healthDevice("GPU-1", nvml.ERROR_GPU_IS_LOST, 0)
+
+	for i, step := range []struct {
+		devices    []*device
+		resets     map[string]float64
+		recoveries map[string]float64
+	}{
+		{[]*device{gpu0, gpu1}, map[string]float64{"GPU-0": 0, "GPU-1": 0}, map[string]float64{"GPU-0": 0, "GPU-1": 0}},
+		// A lost device counts as one reset for as long as it stays lost.
+		{[]*device{gpu0, lost1}, map[string]float64{"GPU-0": 0, "GPU-1": 1}, map[string]float64{"GPU-0": 0, "GPU-1": 0}},
+		{[]*device{gpu0, lost1}, map[string]float64{"GPU-0": 0, "GPU-1": 1}, map[string]float64{"GPU-0": 0, "GPU-1": 0}},
+		{[]*device{gpu0, gpu1}, map[string]float64{"GPU-0": 0, "GPU-1": 1}, map[string]float64{"GPU-0": 0, "GPU-1": 1}},
+		// A device that is no longer enumerated is lost too.
+		{[]*device{gpu0}, map[string]float64{"GPU-0": 0, "GPU-1": 2}, map[string]float64{"GPU-0": 0, "GPU-1": 1}},
+		// Failed enumeration leaves the history unchanged.
+		{nil, map[string]float64{}, map[string]float64{}},
+		{[]*device{gpu0, gpu1}, map[string]float64{"GPU-0": 0, "GPU-1": 2}, map[string]float64{"GPU-0": 0, "GPU-1": 2}},
+	} {
+		resets, recoveries := healthCounts(t, c, step.devices)
+		for uuid, want := range step.resets {
+			if resets[uuid] != want {
+				t.Errorf("step %d: resets of %s = %v, want %v", i, uuid, resets[uuid], want)
+			}
+		}
+		for uuid, want := range step.recoveries {
+			if recoveries[uuid] != want {
+				t.Errorf("step %d: recoveries of %s = %v, want %v", i, uuid, recoveries[uuid], want)
+			}
+		}
+		if len(resets) != len(step.resets) {
+			t.Errorf("step %d: resets exported for %d devices, want %d", i, len(resets), len(step.resets))
+		}
+	}
+}
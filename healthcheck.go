@@ -0,0 +1,74 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/NVIDIA/go-nvml/pkg/nvml"
+)
+
+// runHealthcheck runs the healthcheck subcommand, which checks the readiness
+// endpoint of a running exporter, or NVML itself, and returns 0 if healthy
+// and 1 otherwise. It is meant for container health checks and init systems
+// that can't make HTTP requests themselves.
+func runHealthcheck(args []string) int {
+	fs := flag.NewFlagSet("healthcheck", flag.ExitOnError)
+	url := fs.String("url", "http://localhost:9445/readyz", "URL of the readiness endpoint of the exporter.")
+	timeout := fs.Duration("timeout", 5*time.Second, "Maximum duration of the check.")
+	checkNVML := fs.Bool("nvml", false, "Check that NVML can be initialized and enumerate the devices instead of querying the exporter.")
+	fs.Usage = func() {
+		fmt.Fprintf(fs.Output(), "Usage: %s healthcheck [flags]\n\nExits 0 if the exporter is ready and 1 otherwise.\n\n", os.Args[0])
+		fs.PrintDefaults()
+	}
+	fs.Parse(args)
+
+	var err error
+	if *checkNVML {
+		err = pingNVML()
+	} else {
+		err = checkReady(*url, *timeout)
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "unhealthy: %v\n", err)
+		return 1
+	}
+	return 0
+}
+
+// checkReady fails unless a GET request to url returns 200 within timeout.
+func checkReady(url string, timeout time.Duration) error {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return err
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("%s: %s", resp.Status, strings.TrimSpace(string(body)))
+	}
+	return nil
+}
+
+// pingNVML initializes NVML and counts the devices.
+func pingNVML() error {
+	if ret := nvml.Init(); ret != nvml.SUCCESS {
+		return fmt.Errorf("failed to initialize NVML: %w", ret)
+	}
+	defer nvml.Shutdown()
+	if _, ret := nvml.DeviceGetCount(); ret != nvml.SUCCESS {
+		return fmt.Errorf("failed to get device count: %w", ret)
+	}
+	return nil
+}
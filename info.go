@@ -0,0 +1,61 @@
+package main
+
+import (
+	"log/slog"
+	"strconv"
+
+	"github.com/NVIDIA/go-nvml/pkg/nvml"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+func init() {
+	registerCollector("info", defaultEnabled, newInfoCollector)
+}
+
+// driverModelNames maps NVML driver models to the values of the current and
+// pending labels. NVML calls TCC mode WDM.
+var driverModelNames = map[nvml.DriverModel]string{
+	nvml.DRIVER_WDDM: "wddm",
+	nvml.DRIVER_WDM:  "tcc",
+	nvml.DRIVER_MCDM: "mcdm",
+}
+
+// infoCollector exports identifying information about each device.
+type infoCollector struct {
+	logger *slog.Logger
+
+	info        *prometheus.Desc
+	driverModel *prometheus.Desc
+}
+
+func newInfoCollector(logger *slog.Logger) collector {
+	return &infoCollector{
+		logger: logger,
+		info: deviceDesc("", "info",
+			"Information about the GPU device, including its NVML enumeration index as used by CUDA_VISIBLE_DEVICES. Always 1.",
+			"index"),
+		driverModel: deviceDesc("", "driver_model",
+			"Current and pending Windows driver model (wddm, tcc or mcdm) of the GPU device. Always 1. Only exported on Windows.",
+			"current", "pending"),
+	}
+}
+
+func (c *infoCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.info
+	ch <- c.driverModel
+}
+
+func (c *infoCollector) Collect(ch chan<- prometheus.Metric, devices []*device) {
+	for _, d := range devices {
+		ch <- prometheus.MustNewConstMetric(c.info, prometheus.GaugeValue, 1, d.labelsWith(strconv.Itoa(d.index))...)
+
+		current, pending, ret := d.GetDriverModel_v2()
+		if ret == nvml.ERROR_FUNCTION_NOT_FOUND {
+			current, pending, ret = d.GetDriverModel()
+		}
+		if ret == nvml.SUCCESS {
+			ch <- prometheus.MustNewConstMetric(c.driverModel, prometheus.GaugeValue, 1,
+				d.labelsWith(driverModelNames[current], driverModelNames[pending])...)
+		}
+	}
+}
@@ -0,0 +1,126 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+
+	"github.com/NVIDIA/go-nvml/pkg/nvml"
+)
+
+// architectureNames maps NVML device architectures to their names in the
+// inventory.
+var architectureNames = map[nvml.DeviceArchitecture]string{
+	nvml.DEVICE_ARCH_KEPLER:    "kepler",
+	nvml.DEVICE_ARCH_MAXWELL:   "maxwell",
+	nvml.DEVICE_ARCH_PASCAL:    "pascal",
+	nvml.DEVICE_ARCH_VOLTA:     "volta",
+	nvml.DEVICE_ARCH_TURING:    "turing",
+	nvml.DEVICE_ARCH_AMPERE:    "ampere",
+	nvml.DEVICE_ARCH_ADA:       "ada",
+	nvml.DEVICE_ARCH_HOPPER:    "hopper",
+	nvml.DEVICE_ARCH_BLACKWELL: "blackwell",
+}
+
+// inventory is the response of the inventory endpoint.
+type inventory struct {
+	DriverVersion string            `json:"driver_version,omitempty"`
+	CUDAVersion   string            `json:"cuda_version,omitempty"`
+	Devices       []inventoryDevice `json:"devices"`
+}
+
+// inventoryDevice holds the static details of a device. Fields NVML can't
+// report for the device are omitted.
+type inventoryDevice struct {
+	Index             int    `json:"index"`
+	MinorNumber       int    `json:"minor_number"`
+	UUID              string `json:"uuid"`
+	Name              string `json:"name"`
+	Serial            string `json:"serial,omitempty"`
+	BoardPartNumber   string `json:"board_part_number,omitempty"`
+	PCIBusID          string `json:"pci_bus_id,omitempty"`
+	VBIOSVersion      string `json:"vbios_version,omitempty"`
+	MemoryTotalBytes  uint64 `json:"memory_total_bytes,omitempty"`
+	Architecture      string `json:"architecture,omitempty"`
+	ComputeCapability string `json:"compute_capability,omitempty"`
+}
+
+// inventoryHandler serves the static details of every device as JSON. It
+// returns 503 while NVML is unavailable.
+func inventoryHandler(logger *slog.Logger, e *Exporter) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var inv inventory
+		var err error
+		if !e.UseNVML(func() { inv, err = readInventory(logger) }) {
+			http.Error(w, "NVML is unavailable", http.StatusServiceUnavailable)
+			return
+		}
+		if err != nil {
+			http.Error(w, fmt.Sprintf("failed to enumerate devices: %v", err), http.StatusServiceUnavailable)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(inv); err != nil {
+			logger.Debug("failed to write inventory", "err", err)
+		}
+	})
+}
+
+// readInventory returns the inventory of the node from NVML.
+func readInventory(logger *slog.Logger) (inventory, error) {
+	devices, _, err := enumerateDevices(logger)
+	if err != nil {
+		return inventory{}, err
+	}
+
+	inv := inventory{Devices: make([]inventoryDevice, 0, len(devices))}
+	if version, ret := nvml.SystemGetDriverVersion(); ret == nvml.SUCCESS {
+		inv.DriverVersion = version
+	}
+	if version, ret := nvml.SystemGetCudaDriverVersion_v2(); ret == nvml.SUCCESS {
+		inv.CUDAVersion = fmt.Sprintf("%d.%d", version/1000, version%1000/10)
+	}
+	for _, d := range devices {
+		inv.Devices = append(inv.Devices, d.inventory(logger))
+	}
+	return inv, nil
+}
+
+// inventory returns the static details of d.
+func (d *device) inventory(logger *slog.Logger) inventoryDevice {
+	inv := inventoryDevice{Index: d.index, MinorNumber: d.minor, UUID: d.uuid, Name: d.name}
+
+	var ret nvml.Return
+	if inv.Serial, ret = d.GetSerial(); ret != nvml.SUCCESS {
+		logger.Debug("failed to get serial", "uuid", d.uuid, "err", ret)
+	}
+	if inv.BoardPartNumber, ret = d.GetBoardPartNumber(); ret != nvml.SUCCESS {
+		logger.Debug("failed to get board part number", "uuid", d.uuid, "err", ret)
+	}
+	if pci, ret := d.GetPciInfo(); ret == nvml.SUCCESS {
+		inv.PCIBusID = pciBusID(pci)
+	} else {
+		logger.Debug("failed to get PCI info", "uuid", d.uuid, "err", ret)
+	}
+	if inv.VBIOSVersion, ret = d.GetVbiosVersion(); ret != nvml.SUCCESS {
+		logger.Debug("failed to get VBIOS version", "uuid", d.uuid, "err", ret)
+	}
+	if memory, ret := d.GetMemoryInfo(); ret == nvml.SUCCESS {
+		inv.MemoryTotalBytes = memory.Total
+	} else {
+		logger.Debug("failed to get memory info", "uuid", d.uuid, "err", ret)
+	}
+	if arch, ret := d.GetArchitecture(); ret == nvml.SUCCESS {
+		inv.Architecture = architectureNames[arch]
+	} else {
+		logger.Debug("failed to get architecture", "uuid", d.uuid, "err", ret)
+	}
+	if major, minor, ret := d.GetCudaComputeCapability(); ret == nvml.SUCCESS {
+		inv.ComputeCapability = fmt.Sprintf("%d.%d", major, minor)
+	} else {
+		logger.Debug("failed to get CUDA compute capability", "uuid", d.uuid, "err", ret)
+	}
+	return inv
+}
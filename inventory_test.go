@@ -0,0 +1,23 @@
+package main
+
+import (
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/NVIDIA/go-nvml/pkg/nvml"
+)
+
+func TestInventoryHandlerWithoutNVML(t *testing.T) {
+	logger := slog.New(slog.DiscardHandler)
+	e := &Exporter{logger: logger}
+	ret := nvml.ERROR_LIBRARY_NOT_FOUND
+	e.nvmlErr.Store(&ret)
+
+	rec := httptest.NewRecorder()
+	inventoryHandler(logger, e).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/v1/inventory", nil))
+	if rec.Code != http.StatusServiceUnavailable {
+		t.Errorf("status = %d, want %d", rec.Code, http.StatusServiceUnavailable)
+	}
+}
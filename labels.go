@@ -0,0 +1,84 @@
+package main
+
+import (
+	"cmp"
+	"fmt"
+	"maps"
+	"regexp"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"google.golang.org/protobuf/proto"
+)
+
+// labelNameRE matches valid label names.
+var labelNameRE = regexp.MustCompile(`^[a-zA-Z_][a-zA-Z0-9_]*$`)
+
+// validateConstLabels checks that the labels of the configuration file have
+// valid names that don't clash with the device labels.
+func validateConstLabels(labels map[string]string) error {
+	for name := range labels {
+		if !labelNameRE.MatchString(name) || strings.HasPrefix(name, "__") {
+			return fmt.Errorf("invalid label name %q", name)
+		}
+		if slices.Contains(deviceLabelNames, name) || name == "host" {
+			return fmt.Errorf("label %q clashes with a device label", name)
+		}
+	}
+	return nil
+}
+
+// constLabelsGatherer adds constant labels to every metric gathered from the
+// wrapped Gatherer. Metrics that already have a label of the same name keep
+// their own value.
+type constLabelsGatherer struct {
+	prometheus.Gatherer
+
+	mu     sync.RWMutex
+	labels []*dto.LabelPair
+}
+
+func newConstLabelsGatherer(g prometheus.Gatherer) *constLabelsGatherer {
+	return &constLabelsGatherer{Gatherer: g}
+}
+
+// SetLabels replaces the constant labels.
+func (g *constLabelsGatherer) SetLabels(labels map[string]string) {
+	pairs := make([]*dto.LabelPair, 0, len(labels))
+	for _, name := range slices.Sorted(maps.Keys(labels)) {
+		pairs = append(pairs, &dto.LabelPair{Name: proto.String(name), Value: proto.String(labels[name])})
+	}
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.labels = pairs
+}
+
+// Gather implements prometheus.Gatherer.
+func (g *constLabelsGatherer) Gather() ([]*dto.MetricFamily, error) {
+	families, err := g.Gatherer.Gather()
+
+	g.mu.RLock()
+	labels := g.labels
+	g.mu.RUnlock()
+	if len(labels) == 0 {
+		return families, err
+	}
+
+	for _, mf := range families {
+		for _, m := range mf.GetMetric() {
+			pairs := m.GetLabel()
+			for _, lp := range labels {
+				if !slices.ContainsFunc(pairs, func(p *dto.LabelPair) bool { return p.GetName() == lp.GetName() }) {
+					pairs = append(pairs, lp)
+				}
+			}
+			slices.SortFunc(pairs, func(a, b *dto.LabelPair) int { return cmp.Compare(a.GetName(), b.GetName()) })
+			m.Label = pairs
+		}
+	}
+	return families, err
+}
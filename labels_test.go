@@ -0,0 +1,58 @@
+package main
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+)
+
+func TestValidateConstLabels(t *testing.T) {
+	for _, tc := range []struct {
+		labels map[string]string
+		valid  bool
+	}{
+		{map[string]string{"cluster": "prod", "region": "eu-west-1", "_rack": "a"}, true},
+		{nil, true},
+		{map[string]string{"0cluster": "prod"}, false},
+		{map[string]string{"cluster-name": "prod"}, false},
+		{map[string]string{"__name__": "x"}, false},
+		{map[string]string{"uuid": "x"}, false},
+		{map[string]string{"pci_bus_id": "x"}, false},
+		{map[string]string{"host": "x"}, false},
+	} {
+		err := validateConstLabels(tc.labels)
+		if valid := err == nil; valid != tc.valid {
+			t.Errorf("validateConstLabels(%v) = %v, want valid %v", tc.labels, err, tc.valid)
+		}
+	}
+}
+
+func TestConstLabelsGatherer(t *testing.T) {
+	g := newConstLabelsGatherer(prometheus.GathererFunc(func() ([]*dto.MetricFamily, error) {
+		return []*dto.MetricFamily{
+			family("a", dto.MetricType_GAUGE, gauge(1, label("uuid", "GPU-0")), gauge(2, label("cluster", "own"))),
+		}, nil
+	}))
+	g.SetLabels(map[string]string{"zone": "b", "cluster": "prod"})
+
+	families, err := g.Gather()
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := [][]string{
+		{"cluster=prod", "uuid=GPU-0", "zone=b"},
+		// Labels of the metric take precedence.
+		{"cluster=own", "zone=b"},
+	}
+	for i, m := range families[0].GetMetric() {
+		var got []string
+		for _, lp := range m.GetLabel() {
+			got = append(got, lp.GetName()+"="+lp.GetValue())
+		}
+		if !slices.Equal(got, want[i]) {
+			t.Errorf("metric %d labels = %q, want %q", i, got, want[i])
+		}
+	}
+}
@@ -0,0 +1,42 @@
+package main
+
+import (
+	"fmt"
+	"net/http"
+	"sync"
+)
+
+// reloadHandler reloads the configuration file on POST or PUT, like the
+// Prometheus /-/reload endpoint.
+func reloadHandler(reloader *configReloader) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost && r.Method != http.MethodPut {
+			w.Header().Set("Allow", "POST, PUT")
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		if reloader == nil {
+			http.Error(w, "no configuration file", http.StatusBadRequest)
+			return
+		}
+		if err := reloader.Reload(); err != nil {
+			http.Error(w, fmt.Sprintf("failed to reload configuration file: %v", err), http.StatusInternalServerError)
+			return
+		}
+	})
+}
+
+// quitHandler closes quit on POST or PUT, like the Prometheus /-/quit
+// endpoint.
+func quitHandler(quit chan<- struct{}) http.Handler {
+	var once sync.Once
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost && r.Method != http.MethodPut {
+			w.Header().Set("Allow", "POST, PUT")
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		fmt.Fprintln(w, "Requesting termination... Goodbye!")
+		once.Do(func() { close(quit) })
+	})
+}
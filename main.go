@@ -0,0 +1,373 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/http/pprof"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/NVIDIA/go-nvml/pkg/nvml"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+var (
+	listenAddress     = flag.String("web.listen-address", ":9445", "Address to listen on for web interface and telemetry.")
+	adminAddress      = flag.String("web.admin-listen-address", "", "Address to listen on for the health, pprof, admin and lifecycle endpoints. They are served on --web.listen-address when unset.")
+	enablePprof       = flag.Bool("web.enable-pprof", false, "Serve the Go profiling endpoints under /debug/pprof/ and the exporter state under /debug/state.")
+	metricsPath       = flag.String("web.telemetry-path", "/metrics", "Path under which to expose metrics.")
+	logLevel          = flag.String("log.level", "info", "Only log messages with the given severity or above. One of: [debug, info, warn, error].")
+	configFile        = flag.String("config.file", "", "Path to the configuration file. Reloaded on SIGHUP.")
+	configWatch       = flag.Bool("config.watch", false, "Watch the configuration file and its directory and reload on changes.")
+	adminToken        = flag.String("web.admin-token-file", "", "Path to a file containing the bearer token for the admin endpoints. Admin endpoints are disabled when unset.")
+	lifecycle         = flag.Bool("web.enable-lifecycle", false, "Enable shutdown and reload via HTTP request.")
+	include           = flag.String("metrics.include", "", "Regular expression of metric names to expose. All metrics are exposed when unset.")
+	exclude           = flag.String("metrics.exclude", "", "Regular expression of metric names not to expose.")
+	maxRequests       = flag.Int("web.max-requests", 40, "Maximum number of parallel scrape requests. 0 disables the limit.")
+	timeout           = flag.Duration("web.handler-timeout", 0, "Maximum duration of a scrape request before it fails with 503. 0 disables the timeout.")
+	errorHandling     = flag.String("web.error-handling", "continue", "Handling of errors during collection. One of: [continue, http, panic].")
+	minInterval       = flag.Duration("web.min-scrape-interval", 0, "Serve cached metrics to scrapes arriving within this interval of the previous collection. 0 disables caching.")
+	warmup            = flag.Duration("collector.warmup-timeout", time.Minute, "Maximum time to wait for the first collection before serving requests. 0 serves immediately.")
+	readyGrace        = flag.Duration("web.readiness-grace", 0, "Wait up to this long after startup for a successful NVML collection before opening the listener, and exit if none happens. Ignored if the NVML library isn't installed. 0 opens the listener regardless.")
+	readHeaderTimeout = flag.Duration("web.read-header-timeout", 10*time.Second, "Maximum duration for reading the request headers. 0 disables the timeout.")
+	readTimeout       = flag.Duration("web.read-timeout", 0, "Maximum duration for reading the entire request, including the body. 0 disables the timeout.")
+	writeTimeout      = flag.Duration("web.write-timeout", 0, "Maximum duration from the end of the request headers until the end of the response. 0 disables the timeout.")
+	idleTimeout       = flag.Duration("web.idle-timeout", 0, "Maximum time to wait for the next request on a keep-alive connection. Defaults to --web.read-timeout when 0.")
+	runAsUser         = flag.String("security.run-as-user", "", "User name or uid to switch to after NVML is initialized. The exporter keeps its privileges when unset.")
+	collectorInterval = flag.Duration("collector.interval", 0, "Refresh interval of the collectors in the background, so scrapes are served from cache and never query NVML. 0 collects at scrape time.")
+	enableCollectors  = flag.String("collector.enable", "", "Comma-separated collectors to enable, overriding their defaults and --collector.<name>.")
+	disableCollectors = flag.String("collector.disable", "", "Comma-separated collectors to disable, overriding their defaults and --collector.<name>.")
+	includeUUIDs      = flag.String("device.include-uuid", "", "Comma-separated uuids of the devices to export. All devices are exported when unset.")
+	excludeUUIDs      = flag.String("device.exclude-uuid", "", "Comma-separated uuids of devices not to export.")
+	includeIndexes    = flag.String("device.include-index", "", "Comma-separated NVML indexes of the devices to export. All devices are exported when unset.")
+	excludeIndexes    = flag.String("device.exclude-index", "", "Comma-separated NVML indexes of devices not to export.")
+	labelsDevice      = flag.String("labels.device", "minor_number,uuid,name,model", "Comma-separated labels attached to per-device metrics. Any of: [minor_number, uuid, name, model, serial, pci_bus_id].")
+	timestamps        = flag.Bool("collector.timestamps", false, "Attach the collection time to exported samples. Prometheus then ignores samples older than its staleness window.")
+)
+
+func main() {
+	if len(os.Args) > 1 {
+		switch os.Args[1] {
+		case "bench":
+			os.Exit(runBench(os.Args[2:]))
+		case "healthcheck":
+			os.Exit(runHealthcheck(os.Args[2:]))
+		case "service":
+			os.Exit(runServiceCommand(os.Args[2:]))
+		}
+	}
+
+	intervals := make(map[string]*time.Duration)
+	enabled := make(map[string]*bool)
+	for _, name := range collectorNames() {
+		enabled[name] = flag.Bool("collector."+name, collectorDefaults[name],
+			fmt.Sprintf("Enable the %s collector.", name))
+		usage := fmt.Sprintf("Refresh interval of the %s collector in the background. Defaults to --collector.interval.", name)
+		if collectorIntervals[name] > 0 {
+			usage = fmt.Sprintf("Refresh interval of the %s collector in the background.", name)
+		}
+		intervals[name] = flag.Duration("collector."+name+".interval", collectorIntervals[name], usage)
+	}
+	flag.Parse()
+
+	opts := ExporterOpts{
+		Timestamps: *timestamps,
+		Interval:   *collectorInterval,
+		Intervals:  make(map[string]time.Duration),
+		Disabled:   make(map[string]bool),
+	}
+	for name, interval := range intervals {
+		opts.Intervals[name] = *interval
+		opts.Disabled[name] = !*enabled[name]
+	}
+	if err := applyCollectorLists(opts.Disabled, *enableCollectors, *disableCollectors); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(2)
+	}
+
+	selection, err := newDeviceFilter(*includeUUIDs, *excludeUUIDs, *includeIndexes, *excludeIndexes)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "invalid device filter: %v\n", err)
+		os.Exit(2)
+	}
+	selectedDevices = selection
+	var labels []string
+	for _, label := range strings.Split(*labelsDevice, ",") {
+		if label = strings.TrimSpace(label); label != "" {
+			labels = append(labels, label)
+		}
+	}
+	if err := setDeviceLabels(labels); err != nil {
+		fmt.Fprintf(os.Stderr, "invalid --labels.device: %v\n", err)
+		os.Exit(2)
+	}
+	if !opts.Disabled["remote"] {
+		// Tells the devices of the remote hosts apart from the local ones.
+		deviceLabels = append(deviceLabels, "host")
+	}
+	if isWindowsService() {
+		os.Exit(runService(opts))
+	}
+	os.Exit(run(opts, func(opts *slog.HandlerOptions) slog.Handler {
+		return slog.NewTextHandler(os.Stderr, opts)
+	}, nil))
+}
+
+// run runs the exporter until it is asked to shut down, by a signal, a quit
+// request or shutdown being closed, and returns the exit code. newHandler
+// creates the log handler.
+func run(opts ExporterOpts, newHandler func(*slog.HandlerOptions) slog.Handler, shutdown <-chan struct{}) int {
+	var level slog.LevelVar
+	if err := level.UnmarshalText([]byte(*logLevel)); err != nil {
+		fmt.Fprintf(os.Stderr, "invalid log level %q: %v\n", *logLevel, err)
+		return 2
+	}
+	logger := slog.New(newHandler(&slog.HandlerOptions{Level: &level}))
+
+	registry := prometheus.NewRegistry()
+	metadata := newMetadataGatherer(registry)
+	derived := newDerivedGatherer(metadata, logger)
+	gatherer := newConstLabelsGatherer(derived)
+
+	var reloader *configReloader
+	if *configFile != "" {
+		reloader = newConfigReloader(*configFile, logger, func(cfg *Config) {
+			levelText := *logLevel
+			if cfg.LogLevel != "" {
+				levelText = cfg.LogLevel
+			}
+			// The level was validated when the file was loaded.
+			_ = level.UnmarshalText([]byte(levelText))
+			derived.SetMetrics(cfg.derivedMetrics)
+			gatherer.SetLabels(cfg.Labels)
+			metadata.SetMetadata(cfg.devices)
+			setRemoteHosts(cfg.RemoteHosts)
+		})
+		if err := reloader.Reload(); err != nil {
+			logger.Error("failed to load configuration file", "file", *configFile, "err", err)
+			return 1
+		}
+		go reloader.WatchSignals()
+		if *configWatch {
+			if err := reloader.WatchFile(); err != nil {
+				logger.Error("failed to watch configuration file", "file", *configFile, "err", err)
+				return 1
+			}
+		}
+	}
+
+	exporter := NewExporter(logger, opts)
+	if err := exporter.Init(); err != nil {
+		logger.Warn("NVML is unavailable, only exporting its status", "err", err, "retrying", !exporter.Healthy())
+	} else if version, ret := nvml.SystemGetDriverVersion(); ret == nvml.SUCCESS {
+		logger.Info("initialized NVML", "driver_version", version)
+	}
+	// NVML may be initialized later even if Init failed.
+	defer func() {
+		if err := exporter.Shutdown(); err != nil {
+			logger.Error("failed to shut down NVML", "err", err)
+		}
+	}()
+	if *runAsUser != "" {
+		if err := dropPrivileges(*runAsUser); err != nil {
+			logger.Error("failed to drop privileges", "user", *runAsUser, "err", err)
+			return 1
+		}
+		logger.Info("dropped privileges", "user", *runAsUser)
+	}
+
+	start := time.Now()
+	ctx, stop := context.WithCancel(context.Background())
+	defer stop()
+	ready := exporter.Start(ctx)
+	go watchStateSignal(logger, exporter)
+	registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		exporter,
+		newConfigInfoCollector(exporter, reloader, *include, *exclude, *minInterval),
+	)
+
+	mux := http.NewServeMux()
+	handlerOpts := promhttp.HandlerOpts{
+		ErrorLog:            slog.NewLogLogger(logger.Handler(), slog.LevelError),
+		Registry:            registry,
+		MaxRequestsInFlight: *maxRequests,
+		Timeout:             *timeout,
+	}
+	switch *errorHandling {
+	case "continue":
+		handlerOpts.ErrorHandling = promhttp.ContinueOnError
+	case "http":
+		handlerOpts.ErrorHandling = promhttp.HTTPErrorOnError
+	case "panic":
+		handlerOpts.ErrorHandling = promhttp.PanicOnError
+	default:
+		logger.Error("invalid error handling mode", "mode", *errorHandling)
+		return 2
+	}
+
+	filter, err := newFilterGatherer(gatherer, *include, *exclude)
+	if err != nil {
+		logger.Error("invalid metric filter", "err", err)
+		return 2
+	}
+
+	if *cloudWatchNamespace != "" {
+		pusher, err := newCloudWatchPusher(logger)
+		if err != nil {
+			logger.Error("invalid CloudWatch configuration", "err", err)
+			return 2
+		}
+		registry.MustRegister(pusher)
+		go pusher.Run(ctx, filter, *cloudWatchInterval)
+	}
+
+	var metricsHandler http.Handler
+	if *minInterval > 0 {
+		cache := newCachingGatherer(filter, *minInterval)
+		metricsHandler = cache.Handler(promhttp.HandlerFor(cache, handlerOpts))
+	} else {
+		metricsHandler = promhttp.HandlerFor(filter, handlerOpts)
+	}
+	mux.Handle(*metricsPath, metricsHandler)
+	mux.Handle("GET /api/v1/inventory", inventoryHandler(logger, exporter))
+
+	adminMux := mux
+	if *adminAddress != "" {
+		adminMux = http.NewServeMux()
+	}
+	if *enablePprof {
+		adminMux.HandleFunc("/debug/pprof/", pprof.Index)
+		adminMux.HandleFunc("/debug/pprof/cmdline", pprof.Cmdline)
+		adminMux.HandleFunc("/debug/pprof/profile", pprof.Profile)
+		adminMux.HandleFunc("/debug/pprof/symbol", pprof.Symbol)
+		adminMux.HandleFunc("/debug/pprof/trace", pprof.Trace)
+		adminMux.Handle("/debug/state", stateHandler(exporter))
+	}
+	if *adminToken != "" {
+		token, err := readAdminToken(*adminToken)
+		if err != nil {
+			logger.Error("failed to read admin token", "err", err)
+			return 1
+		}
+		adminMux.Handle("/-/loglevel", requireToken(token, logLevelHandler(&level, logger)))
+		adminMux.Handle("GET /-/collectors", requireToken(token, collectorsHandler(exporter)))
+		adminMux.Handle("PUT /-/collectors/{name}", requireToken(token, collectorHandler(exporter)))
+	}
+	quit := make(chan struct{})
+	if *lifecycle {
+		adminMux.Handle("/-/reload", reloadHandler(reloader))
+		adminMux.Handle("/-/quit", quitHandler(quit))
+	}
+	adminMux.HandleFunc("/healthz", func(w http.ResponseWriter, r *http.Request) {
+		if !exporter.Healthy() {
+			http.Error(w, "NVML failed to be initialized", http.StatusServiceUnavailable)
+			return
+		}
+		fmt.Fprintln(w, "ok")
+	})
+	adminMux.HandleFunc("/readyz", func(w http.ResponseWriter, r *http.Request) {
+		if !exporter.Healthy() {
+			http.Error(w, "NVML failed to be initialized", http.StatusServiceUnavailable)
+			return
+		}
+		if !exporter.NVMLAvailable() {
+			fmt.Fprintln(w, "ready, NVML is unavailable")
+			return
+		}
+		if !exporter.Ready() {
+			http.Error(w, "no successful NVML collection yet", http.StatusServiceUnavailable)
+			return
+		}
+		fmt.Fprintln(w, "ready")
+	})
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, `<html>
+<head><title>NVIDIA GPU Exporter</title></head>
+<body>
+<h1>NVIDIA GPU Exporter</h1>
+<p><a href="%s">Metrics</a></p>
+</body>
+</html>
+`, *metricsPath)
+	})
+
+	if *warmup > 0 {
+		select {
+		case <-ready:
+			logger.Info("completed first collection", "duration", time.Since(start))
+		case <-time.After(*warmup):
+			logger.Warn("first collection did not complete in time, serving anyway", "timeout", *warmup)
+		}
+	}
+	// Without NVML there are no GPUs to wait for, as for /readyz, unless its
+	// initialization is being retried.
+	if *readyGrace > 0 && (exporter.NVMLAvailable() || !exporter.Healthy()) {
+		select {
+		case <-exporter.Succeeded():
+		case <-time.After(time.Until(start.Add(*readyGrace))):
+			logger.Error("no successful NVML collection within the readiness grace period", "grace", *readyGrace)
+			return 1
+		}
+	}
+
+	servers := []*http.Server{newServer(*listenAddress, mux)}
+	if *adminAddress != "" {
+		servers = append(servers, newServer(*adminAddress, adminMux))
+	}
+	serveErr := make(chan error, len(servers))
+	for _, server := range servers {
+		go func() {
+			logger.Info("listening", "address", server.Addr)
+			serveErr <- server.ListenAndServe()
+		}()
+	}
+
+	term := make(chan os.Signal, 1)
+	signal.Notify(term, os.Interrupt, syscall.SIGTERM)
+	select {
+	case err := <-serveErr:
+		logger.Error("failed to serve", "err", err)
+		return 1
+	case sig := <-term:
+		logger.Info("received signal, shutting down", "signal", sig)
+	case <-quit:
+		logger.Info("received quit request, shutting down")
+	case <-shutdown:
+		logger.Info("service stopped, shutting down")
+	}
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	code := 0
+	for _, server := range servers {
+		if err := server.Shutdown(shutdownCtx); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			logger.Error("failed to shut down HTTP server", "address", server.Addr, "err", err)
+			code = 1
+		}
+	}
+	return code
+}
+
+// newServer returns an HTTP server for handler with the timeouts set by the
+// flags.
+func newServer(addr string, handler http.Handler) *http.Server {
+	return &http.Server{
+		Addr:              addr,
+		Handler:           handler,
+		ReadHeaderTimeout: *readHeaderTimeout,
+		ReadTimeout:       *readTimeout,
+		WriteTimeout:      *writeTimeout,
+		IdleTimeout:       *idleTimeout,
+	}
+}
@@ -0,0 +1,109 @@
+package main
+
+import (
+	"log/slog"
+	"math"
+
+	"github.com/NVIDIA/go-nvml/pkg/nvml"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+func init() {
+	registerCollector("memory", defaultEnabled, newMemoryCollector)
+}
+
+// memoryCollector exports framebuffer memory usage per device and for the
+// whole node.
+type memoryCollector struct {
+	logger *slog.Logger
+
+	used         *prometheus.Desc
+	total        *prometheus.Desc
+	unattributed *prometheus.Desc
+	nodeUsed     *prometheus.Desc
+	nodeTotal    *prometheus.Desc
+}
+
+func newMemoryCollector(logger *slog.Logger) collector {
+	return &memoryCollector{
+		logger: logger,
+		used: deviceDesc("memory", "used_bytes",
+			"Memory used by the GPU device in bytes."),
+		total: deviceDesc("memory", "total_bytes",
+			"Total memory of the GPU device in bytes."),
+		unattributed: deviceDesc("memory", "unattributed_bytes",
+			"Memory used on the GPU device in bytes that isn't attributed to any running process, e.g. held by orphaned contexts of crashed jobs."),
+		nodeUsed: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, "node", "memory_used_bytes"),
+			"Memory used by all GPU devices of the node in bytes.",
+			nil, nil,
+		),
+		nodeTotal: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, "node", "memory_total_bytes"),
+			"Total memory of all GPU devices of the node in bytes.",
+			nil, nil,
+		),
+	}
+}
+
+func (c *memoryCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.used
+	ch <- c.total
+	ch <- c.unattributed
+	ch <- c.nodeUsed
+	ch <- c.nodeTotal
+}
+
+func (c *memoryCollector) Collect(ch chan<- prometheus.Metric, devices []*device) {
+	var nodeUsed, nodeTotal float64
+	reported := 0
+	for _, d := range devices {
+		memory, ret := d.GetMemoryInfo()
+		if ret != nvml.SUCCESS {
+			c.logger.Debug("failed to get memory info", "uuid", d.uuid, "err", ret)
+			continue
+		}
+		ch <- prometheus.MustNewConstMetric(c.used, prometheus.GaugeValue, float64(memory.Used), d.labels...)
+		ch <- prometheus.MustNewConstMetric(c.total, prometheus.GaugeValue, float64(memory.Total), d.labels...)
+		nodeUsed += float64(memory.Used)
+		nodeTotal += float64(memory.Total)
+		reported++
+
+		if unattributed, ok := c.unattributedMemory(d); ok {
+			ch <- prometheus.MustNewConstMetric(c.unattributed, prometheus.GaugeValue, float64(unattributed), d.labels...)
+		}
+	}
+	if reported > 0 {
+		ch <- prometheus.MustNewConstMetric(c.nodeUsed, prometheus.GaugeValue, nodeUsed)
+		ch <- prometheus.MustNewConstMetric(c.nodeTotal, prometheus.GaugeValue, nodeTotal)
+	}
+}
+
+// unattributedMemory returns the memory used on d minus the memory used by
+// its running processes. It uses the v2 memory info, which excludes the
+// memory reserved by the driver, and reports false if that or the memory of
+// any process is unavailable, e.g. in MIG mode or under WDDM.
+func (c *memoryCollector) unattributedMemory(d *device) (uint64, bool) {
+	memory, ret := d.GetMemoryInfo_v2()
+	if ret != nvml.SUCCESS {
+		c.logger.Debug("failed to get v2 memory info", "uuid", d.uuid, "err", ret)
+		return 0, false
+	}
+
+	processes, err := d.runningProcesses()
+	if err != nil {
+		c.logger.Debug("failed to get running processes", "uuid", d.uuid, "err", err)
+		return 0, false
+	}
+	var attributed uint64
+	for _, used := range processes {
+		if used == math.MaxUint64 {
+			return 0, false
+		}
+		attributed += used
+	}
+	if attributed >= memory.Used {
+		return 0, true
+	}
+	return memory.Used - attributed, true
+}
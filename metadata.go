@@ -0,0 +1,97 @@
+package main
+
+import (
+	"cmp"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"google.golang.org/protobuf/proto"
+)
+
+// DeviceMetadata is operator-provided information about a GPU, used to
+// locate it physically and route alerts.
+type DeviceMetadata struct {
+	FriendlyName string `yaml:"friendly_name"`
+	Rack         string `yaml:"rack"`
+	Slot         string `yaml:"slot"`
+	Owner        string `yaml:"owner"`
+}
+
+// labelPairs returns the metadata as label pairs. Unset fields are exported
+// as empty labels so every series of the metric has the same label names.
+func (m DeviceMetadata) labelPairs() []*dto.LabelPair {
+	return []*dto.LabelPair{
+		{Name: proto.String("friendly_name"), Value: proto.String(m.FriendlyName)},
+		{Name: proto.String("owner"), Value: proto.String(m.Owner)},
+		{Name: proto.String("rack"), Value: proto.String(m.Rack)},
+		{Name: proto.String("slot"), Value: proto.String(m.Slot)},
+	}
+}
+
+var metadataMetricName = prometheus.BuildFQName(namespace, "", "device_metadata")
+
+// metadataGatherer adds nvidia_gpu_device_metadata for every device that is
+// present in the metric families gathered from the wrapped Gatherer and has
+// metadata configured for its uuid.
+type metadataGatherer struct {
+	prometheus.Gatherer
+
+	mu       sync.RWMutex
+	metadata map[string]DeviceMetadata
+}
+
+func newMetadataGatherer(g prometheus.Gatherer) *metadataGatherer {
+	return &metadataGatherer{Gatherer: g}
+}
+
+// SetMetadata replaces the device metadata, keyed by lowercase uuid.
+func (g *metadataGatherer) SetMetadata(metadata map[string]DeviceMetadata) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.metadata = metadata
+}
+
+// Gather implements prometheus.Gatherer.
+func (g *metadataGatherer) Gather() ([]*dto.MetricFamily, error) {
+	families, err := g.Gatherer.Gather()
+
+	g.mu.RLock()
+	metadata := g.metadata
+	g.mu.RUnlock()
+	if len(metadata) == 0 {
+		return families, err
+	}
+
+	mf := &dto.MetricFamily{
+		Name: proto.String(metadataMetricName),
+		Help: proto.String("Operator-provided metadata about the GPU device from the configuration file. Always 1."),
+		Type: dto.MetricType_GAUGE.Enum(),
+	}
+	seen := make(map[string]bool)
+	for _, family := range families {
+		for _, m := range family.GetMetric() {
+			uuid, pairs := deviceLabelPairs(m)
+			if uuid == "" || seen[uuid] {
+				continue
+			}
+			seen[uuid] = true
+			md, ok := metadata[strings.ToLower(uuid)]
+			if !ok {
+				continue
+			}
+			labels := append(pairs[:len(pairs):len(pairs)], md.labelPairs()...)
+			slices.SortFunc(labels, func(a, b *dto.LabelPair) int { return cmp.Compare(a.GetName(), b.GetName()) })
+			mf.Metric = append(mf.Metric, &dto.Metric{
+				Label: labels,
+				Gauge: &dto.Gauge{Value: proto.Float64(1)},
+			})
+		}
+	}
+	if len(mf.Metric) > 0 {
+		families = append(families, mf)
+	}
+	return families, err
+}
@@ -0,0 +1,177 @@
+package main
+
+import (
+	"log/slog"
+	"strconv"
+	"strings"
+
+	"github.com/NVIDIA/go-nvml/pkg/nvml"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+func init() {
+	registerCollector("mig", defaultEnabled, newMigCollector)
+}
+
+// migLabels are the labels of the per-MIG device metrics, after the labels
+// of the parent device.
+var migLabels = []string{"mig_uuid", "gpu_instance_id", "compute_instance_id", "mig_profile"}
+
+// migCollector exports the MIG devices of each device in MIG mode, with
+// their memory and utilization, and the placement of its GPU instances, i.e.
+// which memory slices of the parent GPU each one occupies, so the physical
+// layout of the MIG partitions can be reconstructed.
+type migCollector struct {
+	logger *slog.Logger
+
+	gpm *gpmSampler
+
+	info            *prometheus.Desc
+	memoryUsed      *prometheus.Desc
+	memoryTotal     *prometheus.Desc
+	smUtil          *prometheus.Desc
+	memoryBandwidth *prometheus.Desc
+	placementStart  *prometheus.Desc
+	placementSlices *prometheus.Desc
+}
+
+func newMigCollector(logger *slog.Logger) collector {
+	return &migCollector{
+		logger: logger,
+		gpm:    newGPMSampler(logger),
+		info: deviceDesc("mig", "info",
+			"Always 1. Labeled with the uuid, GPU and compute instance ids and profile of a MIG device of the GPU device.",
+			migLabels...),
+		memoryUsed: deviceDesc("mig", "memory_used_bytes",
+			"Memory used by the MIG device in bytes.",
+			migLabels...),
+		memoryTotal: deviceDesc("mig", "memory_total_bytes",
+			"Total memory of the MIG device in bytes.",
+			migLabels...),
+		smUtil: deviceDesc("mig", "sm_utilization_percent",
+			"Percent of the streaming multiprocessors of the GPU instance of the MIG device that were busy since the previous collection. Requires GPM support (Hopper or newer).",
+			migLabels...),
+		memoryBandwidth: deviceDesc("mig", "memory_bandwidth_utilization_percent",
+			"Achieved DRAM bandwidth of the GPU instance of the MIG device as a percent of the theoretical peak since the previous collection. Requires GPM support (Hopper or newer).",
+			migLabels...),
+		placementStart: deviceDesc("mig", "gpu_instance_placement_start",
+			"Index of the first slice of the GPU device occupied by the GPU instance.",
+			"gpu_instance_id", "profile_id"),
+		placementSlices: deviceDesc("mig", "gpu_instance_placement_slices",
+			"Number of slices of the GPU device occupied by the GPU instance.",
+			"gpu_instance_id", "profile_id"),
+	}
+}
+
+func (c *migCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.info
+	ch <- c.memoryUsed
+	ch <- c.memoryTotal
+	ch <- c.smUtil
+	ch <- c.memoryBandwidth
+	ch <- c.placementStart
+	ch <- c.placementSlices
+}
+
+func (c *migCollector) Collect(ch chan<- prometheus.Metric, devices []*device) {
+	defer c.gpm.sweep()
+	for _, d := range devices {
+		mode, _, ret := d.GetMigMode()
+		if ret != nvml.SUCCESS {
+			c.logger.Debug("failed to get MIG mode", "uuid", d.uuid, "err", ret)
+			continue
+		}
+		if mode != nvml.DEVICE_MIG_ENABLE {
+			continue
+		}
+
+		c.collectDevices(ch, d)
+		c.collectPlacements(ch, d)
+	}
+}
+
+// collectDevices sends the metrics of the MIG devices of d.
+func (c *migCollector) collectDevices(ch chan<- prometheus.Metric, d *device) {
+	count, ret := d.GetMaxMigDeviceCount()
+	if ret != nvml.SUCCESS {
+		c.logger.Debug("failed to get max MIG device count", "uuid", d.uuid, "err", ret)
+		return
+	}
+	for i := range count {
+		mig, ret := d.GetMigDeviceHandleByIndex(i)
+		if ret == nvml.ERROR_NOT_FOUND {
+			// The slot holds no MIG device.
+			continue
+		}
+		if ret != nvml.SUCCESS {
+			c.logger.Debug("failed to get MIG device handle", "uuid", d.uuid, "index", i, "err", ret)
+			continue
+		}
+		uuid, ret := mig.GetUUID()
+		if ret != nvml.SUCCESS {
+			c.logger.Debug("failed to get MIG device uuid", "uuid", d.uuid, "index", i, "err", ret)
+			continue
+		}
+		gpuInstanceID, ret := mig.GetGpuInstanceId()
+		if ret != nvml.SUCCESS {
+			c.logger.Debug("failed to get MIG device GPU instance id", "uuid", uuid, "err", ret)
+			continue
+		}
+		computeInstanceID, ret := mig.GetComputeInstanceId()
+		if ret != nvml.SUCCESS {
+			c.logger.Debug("failed to get MIG device compute instance id", "uuid", uuid, "err", ret)
+			continue
+		}
+		// MIG device names end with the profile, e.g.
+		// "NVIDIA A100-SXM4-40GB MIG 1g.5gb".
+		var profile string
+		if name, ret := mig.GetName(); ret == nvml.SUCCESS {
+			_, profile, _ = strings.Cut(name, " MIG ")
+		}
+
+		labels := d.labelsWith(uuid, strconv.Itoa(gpuInstanceID), strconv.Itoa(computeInstanceID), profile)
+		ch <- prometheus.MustNewConstMetric(c.info, prometheus.GaugeValue, 1, labels...)
+		if memory, ret := mig.GetMemoryInfo(); ret == nvml.SUCCESS {
+			ch <- prometheus.MustNewConstMetric(c.memoryUsed, prometheus.GaugeValue, float64(memory.Used), labels...)
+			ch <- prometheus.MustNewConstMetric(c.memoryTotal, prometheus.GaugeValue, float64(memory.Total), labels...)
+		} else {
+			c.logger.Debug("failed to get MIG device memory info", "uuid", uuid, "err", ret)
+		}
+		values := c.gpm.migMetrics(d, gpuInstanceID, uuid, nvml.GPM_METRIC_SM_UTIL, nvml.GPM_METRIC_DRAM_BW_UTIL)
+		if value, ok := values[nvml.GPM_METRIC_SM_UTIL]; ok {
+			ch <- prometheus.MustNewConstMetric(c.smUtil, prometheus.GaugeValue, value, labels...)
+		}
+		if value, ok := values[nvml.GPM_METRIC_DRAM_BW_UTIL]; ok {
+			ch <- prometheus.MustNewConstMetric(c.memoryBandwidth, prometheus.GaugeValue, value, labels...)
+		}
+	}
+}
+
+// collectPlacements sends the placement of the GPU instances of d.
+func (c *migCollector) collectPlacements(ch chan<- prometheus.Metric, d *device) {
+	for profile := range nvml.GPU_INSTANCE_PROFILE_COUNT {
+		info, ret := d.GetGpuInstanceProfileInfo(profile)
+		if ret != nvml.SUCCESS {
+			// Not every profile is supported by every GPU.
+			continue
+		}
+		if info.InstanceCount == 0 {
+			continue
+		}
+		instances, ret := d.GetGpuInstances(&info)
+		if ret != nvml.SUCCESS {
+			c.logger.Debug("failed to get GPU instances", "uuid", d.uuid, "profile_id", info.Id, "err", ret)
+			continue
+		}
+		for _, instance := range instances {
+			instanceInfo, ret := instance.GetInfo()
+			if ret != nvml.SUCCESS {
+				c.logger.Debug("failed to get GPU instance info", "uuid", d.uuid, "profile_id", info.Id, "err", ret)
+				continue
+			}
+			labels := d.labelsWith(strconv.FormatUint(uint64(instanceInfo.Id), 10), strconv.FormatUint(uint64(info.Id), 10))
+			ch <- prometheus.MustNewConstMetric(c.placementStart, prometheus.GaugeValue, float64(instanceInfo.Placement.Start), labels...)
+			ch <- prometheus.MustNewConstMetric(c.placementSlices, prometheus.GaugeValue, float64(instanceInfo.Placement.Size), labels...)
+		}
+	}
+}
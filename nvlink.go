@@ -0,0 +1,94 @@
+package main
+
+import (
+	"log/slog"
+	"strconv"
+
+	"github.com/NVIDIA/go-nvml/pkg/nvml"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+func init() {
+	registerCollector("nvlink", defaultEnabled, newNvlinkCollector)
+}
+
+// nvlinkThresholdUnits maps the NVLink low-power threshold units reported by
+// NVML to seconds.
+var nvlinkThresholdUnits = map[float64]float64{
+	nvml.NVLINK_LOW_POWER_THRESHOLD_UNIT_100US: 100e-6,
+	nvml.NVLINK_LOW_POWER_THRESHOLD_UNIT_50US:  50e-6,
+}
+
+// nvlinkCollector exports the power state of the NVLink links of each device
+// and the idle time after which they enter the low-power state, on devices
+// supporting NVLink power management.
+type nvlinkCollector struct {
+	logger *slog.Logger
+
+	lowPower          *prometheus.Desc
+	lowPowerThreshold *prometheus.Desc
+}
+
+func newNvlinkCollector(logger *slog.Logger) collector {
+	return &nvlinkCollector{
+		logger: logger,
+		lowPower: deviceDesc("nvlink", "low_power",
+			"Whether the NVLink link of the GPU device is in the low-power state (1) or in the high-speed state (0).",
+			"link"),
+		lowPowerThreshold: deviceDesc("nvlink", "low_power_threshold_seconds",
+			"Idle time after which the NVLink links of the GPU device enter the low-power state in seconds."),
+	}
+}
+
+func (c *nvlinkCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.lowPower
+	ch <- c.lowPowerThreshold
+}
+
+func (c *nvlinkCollector) Collect(ch chan<- prometheus.Metric, devices []*device) {
+	for _, d := range devices {
+		fields, ret := fieldValues(d, []nvml.FieldValue{
+			{FieldId: nvml.FI_DEV_NVLINK_GET_POWER_THRESHOLD_SUPPORTED},
+			{FieldId: nvml.FI_DEV_NVLINK_LINK_COUNT},
+			{FieldId: nvml.FI_DEV_NVLINK_GET_POWER_THRESHOLD},
+			{FieldId: nvml.FI_DEV_NVLINK_GET_POWER_THRESHOLD_UNITS},
+		})
+		if ret != nvml.SUCCESS {
+			c.logger.Debug("failed to get NVLink power fields", "uuid", d.uuid, "err", ret)
+			continue
+		}
+		if fields[0] == nil || *fields[0] != 1 {
+			continue
+		}
+
+		if fields[2] != nil && fields[3] != nil {
+			if unit, ok := nvlinkThresholdUnits[*fields[3]]; ok {
+				ch <- prometheus.MustNewConstMetric(c.lowPowerThreshold, prometheus.GaugeValue, *fields[2]*unit, d.labels...)
+			}
+		}
+
+		if fields[1] == nil || *fields[1] == 0 {
+			continue
+		}
+		states := make([]nvml.FieldValue, int(*fields[1]))
+		for link := range states {
+			states[link] = nvml.FieldValue{FieldId: nvml.FI_DEV_NVLINK_GET_POWER_STATE, ScopeId: uint32(link)}
+		}
+		values, ret := fieldValues(d, states)
+		if ret != nvml.SUCCESS {
+			c.logger.Debug("failed to get NVLink power states", "uuid", d.uuid, "err", ret)
+			continue
+		}
+		for link, state := range values {
+			// Inactive links have no power state.
+			if state == nil {
+				continue
+			}
+			lowPower := 0.0
+			if *state == nvml.NVLINK_POWER_STATE_LOW {
+				lowPower = 1
+			}
+			ch <- prometheus.MustNewConstMetric(c.lowPower, prometheus.GaugeValue, lowPower, d.labelsWith(strconv.Itoa(link))...)
+		}
+	}
+}
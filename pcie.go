@@ -0,0 +1,100 @@
+package main
+
+import (
+	"log/slog"
+
+	"github.com/NVIDIA/go-nvml/pkg/nvml"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+func init() {
+	registerCollector("pcie", defaultEnabled, newPcieCollector)
+}
+
+// pcieCollector exports the PCIe throughput of each device, its current and
+// maximum link generation and width, and its PCIe replay count, which grows
+// with signal integrity problems on the link.
+type pcieCollector struct {
+	logger *slog.Logger
+
+	txBytes      *prometheus.Desc
+	rxBytes      *prometheus.Desc
+	linkGen      *prometheus.Desc
+	maxLinkGen   *prometheus.Desc
+	linkWidth    *prometheus.Desc
+	maxLinkWidth *prometheus.Desc
+	replaysTotal *prometheus.Desc
+}
+
+func newPcieCollector(logger *slog.Logger) collector {
+	return &pcieCollector{
+		logger: logger,
+		txBytes: deviceDesc("pcie", "tx_bytes_per_second",
+			"PCIe throughput transmitted by the GPU device over the past 20ms in bytes per second."),
+		rxBytes: deviceDesc("pcie", "rx_bytes_per_second",
+			"PCIe throughput received by the GPU device over the past 20ms in bytes per second."),
+		linkGen: deviceDesc("pcie", "link_generation",
+			"Current PCIe link generation of the GPU device."),
+		maxLinkGen: deviceDesc("pcie", "link_max_generation",
+			"Maximum PCIe link generation supported by the GPU device and the system."),
+		linkWidth: deviceDesc("pcie", "link_width",
+			"Current PCIe link width of the GPU device in lanes."),
+		maxLinkWidth: deviceDesc("pcie", "link_max_width",
+			"Maximum PCIe link width supported by the GPU device and the system in lanes."),
+		replaysTotal: deviceDesc("pcie", "replays_total",
+			"Number of PCIe replays of the GPU device since the driver was loaded."),
+	}
+}
+
+func (c *pcieCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.txBytes
+	ch <- c.rxBytes
+	ch <- c.linkGen
+	ch <- c.maxLinkGen
+	ch <- c.linkWidth
+	ch <- c.maxLinkWidth
+	ch <- c.replaysTotal
+}
+
+func (c *pcieCollector) Collect(ch chan<- prometheus.Metric, devices []*device) {
+	for _, d := range devices {
+		// NVML reports the throughput in KiB/s.
+		if tx, ret := d.GetPcieThroughput(nvml.PCIE_UTIL_TX_BYTES); ret == nvml.SUCCESS {
+			ch <- prometheus.MustNewConstMetric(c.txBytes, prometheus.GaugeValue, float64(tx)*1024, d.labels...)
+		} else {
+			c.logger.Debug("failed to get PCIe TX throughput", "uuid", d.uuid, "err", ret)
+		}
+		if rx, ret := d.GetPcieThroughput(nvml.PCIE_UTIL_RX_BYTES); ret == nvml.SUCCESS {
+			ch <- prometheus.MustNewConstMetric(c.rxBytes, prometheus.GaugeValue, float64(rx)*1024, d.labels...)
+		} else {
+			c.logger.Debug("failed to get PCIe RX throughput", "uuid", d.uuid, "err", ret)
+		}
+
+		if gen, ret := d.GetCurrPcieLinkGeneration(); ret == nvml.SUCCESS {
+			ch <- prometheus.MustNewConstMetric(c.linkGen, prometheus.GaugeValue, float64(gen), d.labels...)
+		} else {
+			c.logger.Debug("failed to get PCIe link generation", "uuid", d.uuid, "err", ret)
+		}
+		if gen, ret := d.GetMaxPcieLinkGeneration(); ret == nvml.SUCCESS {
+			ch <- prometheus.MustNewConstMetric(c.maxLinkGen, prometheus.GaugeValue, float64(gen), d.labels...)
+		} else {
+			c.logger.Debug("failed to get max PCIe link generation", "uuid", d.uuid, "err", ret)
+		}
+		if width, ret := d.GetCurrPcieLinkWidth(); ret == nvml.SUCCESS {
+			ch <- prometheus.MustNewConstMetric(c.linkWidth, prometheus.GaugeValue, float64(width), d.labels...)
+		} else {
+			c.logger.Debug("failed to get PCIe link width", "uuid", d.uuid, "err", ret)
+		}
+		if width, ret := d.GetMaxPcieLinkWidth(); ret == nvml.SUCCESS {
+			ch <- prometheus.MustNewConstMetric(c.maxLinkWidth, prometheus.GaugeValue, float64(width), d.labels...)
+		} else {
+			c.logger.Debug("failed to get max PCIe link width", "uuid", d.uuid, "err", ret)
+		}
+
+		if replays, ret := d.GetPcieReplayCounter(); ret == nvml.SUCCESS {
+			ch <- prometheus.MustNewConstMetric(c.replaysTotal, prometheus.CounterValue, float64(replays), d.labels...)
+		} else {
+			c.logger.Debug("failed to get PCIe replay counter", "uuid", d.uuid, "err", ret)
+		}
+	}
+}
@@ -0,0 +1,157 @@
+package main
+
+import (
+	"log/slog"
+
+	"github.com/NVIDIA/go-nvml/pkg/nvml"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+func init() {
+	registerCollector("power", defaultEnabled, newPowerCollector)
+}
+
+// powerCollector exports power usage per device and for the whole node, the
+// minimum, maximum and average of the power samples NVML took since the
+// previous collection, which catch spikes shorter than the scrape interval,
+// the enforced power limit and the workload power profiles of each device.
+type powerCollector struct {
+	logger *slog.Logger
+
+	// lastSeen holds the timestamp of the newest power sample of each device
+	// by uuid. Devices that are no longer enumerated are dropped.
+	lastSeen map[string]uint64
+
+	usage     *prometheus.Desc
+	usageMin  *prometheus.Desc
+	usageMax  *prometheus.Desc
+	usageAvg  *prometheus.Desc
+	limit     *prometheus.Desc
+	nodeUsage *prometheus.Desc
+
+	profilesSupported *prometheus.Desc
+	profilesRequested *prometheus.Desc
+	profilesEnforced  *prometheus.Desc
+}
+
+func newPowerCollector(logger *slog.Logger) collector {
+	return &powerCollector{
+		logger:   logger,
+		lastSeen: make(map[string]uint64),
+		usage: deviceDesc("", "power_usage_milliwatts",
+			"Power usage of the GPU device in milliwatts."),
+		usageMin: deviceDesc("", "power_usage_min_milliwatts",
+			"Minimum power usage of the GPU device sampled by NVML since the previous collection in milliwatts."),
+		usageMax: deviceDesc("", "power_usage_max_milliwatts",
+			"Maximum power usage of the GPU device sampled by NVML since the previous collection in milliwatts."),
+		usageAvg: deviceDesc("", "power_usage_average_milliwatts",
+			"Average power usage of the GPU device sampled by NVML since the previous collection in milliwatts."),
+		limit: deviceDesc("", "power_enforced_limit_milliwatts",
+			"Power limit enforced on the GPU device in milliwatts, the lowest of the limits set by the user, the driver and the board."),
+		nodeUsage: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, "node", "power_usage_milliwatts"),
+			"Power usage of all GPU devices of the node in milliwatts.",
+			nil, nil,
+		),
+		profilesSupported: deviceDesc("power_profiles", "supported_mask",
+			"Mask of the workload power profiles supported by the GPU device, with bit n set for profile id n."),
+		profilesRequested: deviceDesc("power_profiles", "requested_mask",
+			"Mask of the workload power profiles requested on the GPU device, with bit n set for profile id n."),
+		profilesEnforced: deviceDesc("power_profiles", "enforced_mask",
+			"Mask of the workload power profiles enforced on the GPU device after resolving conflicts, with bit n set for profile id n."),
+	}
+}
+
+func (c *powerCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.usage
+	ch <- c.usageMin
+	ch <- c.usageMax
+	ch <- c.usageAvg
+	ch <- c.limit
+	ch <- c.nodeUsage
+	ch <- c.profilesSupported
+	ch <- c.profilesRequested
+	ch <- c.profilesEnforced
+}
+
+func (c *powerCollector) Collect(ch chan<- prometheus.Metric, devices []*device) {
+	var nodeUsage float64
+	reported := 0
+	present := make(map[string]bool, len(devices))
+	for _, d := range devices {
+		present[d.uuid] = true
+		c.collectSamples(ch, d)
+
+		// Profile ids are below 32, so the first word of each mask holds
+		// them all.
+		if profiles, ret := d.WorkloadPowerProfileGetCurrentProfiles(); ret == nvml.SUCCESS {
+			ch <- prometheus.MustNewConstMetric(c.profilesSupported, prometheus.GaugeValue, float64(profiles.PerfProfilesMask.Mask[0]), d.labels...)
+			ch <- prometheus.MustNewConstMetric(c.profilesRequested, prometheus.GaugeValue, float64(profiles.RequestedProfilesMask.Mask[0]), d.labels...)
+			ch <- prometheus.MustNewConstMetric(c.profilesEnforced, prometheus.GaugeValue, float64(profiles.EnforcedProfilesMask.Mask[0]), d.labels...)
+		} else {
+			c.logger.Debug("failed to get workload power profiles", "uuid", d.uuid, "err", ret)
+		}
+
+		if limit, ret := d.GetEnforcedPowerLimit(); ret == nvml.SUCCESS {
+			ch <- prometheus.MustNewConstMetric(c.limit, prometheus.GaugeValue, float64(limit), d.labels...)
+		} else {
+			c.logger.Debug("failed to get enforced power limit", "uuid", d.uuid, "err", ret)
+		}
+
+		power, ret := d.GetPowerUsage()
+		if ret != nvml.SUCCESS {
+			c.logger.Debug("failed to get power usage", "uuid", d.uuid, "err", ret)
+			continue
+		}
+		ch <- prometheus.MustNewConstMetric(c.usage, prometheus.GaugeValue, float64(power), d.labels...)
+		nodeUsage += float64(power)
+		reported++
+	}
+	if reported > 0 {
+		ch <- prometheus.MustNewConstMetric(c.nodeUsage, prometheus.GaugeValue, nodeUsage)
+	}
+	for uuid := range c.lastSeen {
+		if !present[uuid] {
+			delete(c.lastSeen, uuid)
+		}
+	}
+}
+
+// collectSamples exports the statistics of the power samples taken since the
+// previous collection of the device. The first collection covers the whole
+// sample buffer of NVML.
+func (c *powerCollector) collectSamples(ch chan<- prometheus.Metric, d *device) {
+	valueType, samples, ret := d.GetSamples(nvml.TOTAL_POWER_SAMPLES, c.lastSeen[d.uuid])
+	if ret == nvml.ERROR_NOT_FOUND {
+		// No sample was taken since the previous collection.
+		return
+	}
+	if ret != nvml.SUCCESS {
+		c.logger.Debug("failed to get power samples", "uuid", d.uuid, "err", ret)
+		return
+	}
+
+	var minPower, maxPower, sum float64
+	count := 0
+	for _, sample := range samples {
+		c.lastSeen[d.uuid] = max(c.lastSeen[d.uuid], sample.TimeStamp)
+		value, ok := nvmlValue(valueType, sample.SampleValue)
+		if !ok {
+			continue
+		}
+		if count == 0 || value < minPower {
+			minPower = value
+		}
+		if count == 0 || value > maxPower {
+			maxPower = value
+		}
+		sum += value
+		count++
+	}
+	if count == 0 {
+		return
+	}
+	ch <- prometheus.MustNewConstMetric(c.usageMin, prometheus.GaugeValue, minPower, d.labels...)
+	ch <- prometheus.MustNewConstMetric(c.usageMax, prometheus.GaugeValue, maxPower, d.labels...)
+	ch <- prometheus.MustNewConstMetric(c.usageAvg, prometheus.GaugeValue, sum/float64(count), d.labels...)
+}
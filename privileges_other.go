@@ -0,0 +1,10 @@
+//go:build !unix
+
+package main
+
+import "errors"
+
+// dropPrivileges is only supported on Unix.
+func dropPrivileges(name string) error {
+	return errors.New("--security.run-as-user is only supported on Unix")
+}
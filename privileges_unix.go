@@ -0,0 +1,54 @@
+//go:build unix
+
+package main
+
+import (
+	"fmt"
+	"os/user"
+	"strconv"
+	"syscall"
+)
+
+// dropPrivileges switches the process to the user given by name or uid, with
+// its primary and supplementary groups. Go applies the change to every thread
+// of the process.
+func dropPrivileges(name string) error {
+	u, err := user.Lookup(name)
+	if err != nil {
+		var uidErr error
+		if u, uidErr = user.LookupId(name); uidErr != nil {
+			return err
+		}
+	}
+	uid, err := strconv.Atoi(u.Uid)
+	if err != nil {
+		return fmt.Errorf("invalid uid %q of user %s: %w", u.Uid, u.Username, err)
+	}
+	gid, err := strconv.Atoi(u.Gid)
+	if err != nil {
+		return fmt.Errorf("invalid gid %q of user %s: %w", u.Gid, u.Username, err)
+	}
+
+	groupIDs, err := u.GroupIds()
+	if err != nil {
+		return fmt.Errorf("failed to get groups of user %s: %w", u.Username, err)
+	}
+	groups := make([]int, 0, len(groupIDs))
+	for _, id := range groupIDs {
+		if g, err := strconv.Atoi(id); err == nil {
+			groups = append(groups, g)
+		}
+	}
+
+	// The groups must be changed while still privileged.
+	if err := syscall.Setgroups(groups); err != nil {
+		return fmt.Errorf("failed to set groups: %w", err)
+	}
+	if err := syscall.Setgid(gid); err != nil {
+		return fmt.Errorf("failed to set gid: %w", err)
+	}
+	if err := syscall.Setuid(uid); err != nil {
+		return fmt.Errorf("failed to set uid: %w", err)
+	}
+	return nil
+}
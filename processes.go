@@ -0,0 +1,367 @@
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"log/slog"
+	"math"
+	"os"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/NVIDIA/go-nvml/pkg/nvml"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/procfs"
+)
+
+var (
+	processEnv = flag.String("collector.processes.env", "",
+		"Comma-separated names of environment variables of GPU processes to export as labels of the per-process metrics, e.g. JOB_ID,USER.")
+	processKubernetes = flag.Bool("collector.processes.kubernetes", false,
+		"Label the per-process metrics with the namespace, pod and container of the process, resolved from its cgroup.")
+)
+
+// cgroupContainerID matches the container id at the end of a cgroup path,
+// e.g. "cri-containerd-<id>.scope" or "crio-<id>.scope" under systemd and
+// "<id>" under cgroupfs.
+var cgroupContainerID = regexp.MustCompile(`([0-9a-f]{64})(?:\.scope)?$`)
+
+func init() {
+	registerCollector("processes", defaultDisabled, newProcessesCollector)
+}
+
+// processesCollector exports the memory used by, the utilization of and
+// the start time of each process running on each device, labeled with its
+// name, optionally its Kubernetes container and selected environment
+// variables for workload attribution, and counts the processes starting and
+// exiting on each device between collections.
+type processesCollector struct {
+	logger *slog.Logger
+
+	// envVars are the names of the environment variables exported as labels.
+	envVars []string
+	// kubernetes adds the namespace, pod and container labels.
+	kubernetes bool
+	// containers caches the containers of the processes by container id.
+	// Containers that no longer run a GPU process are dropped.
+	containers map[string]kubernetesContainer
+
+	// lastSeen holds the timestamp of the newest process utilization sample
+	// of each device by uuid. Devices that are no longer enumerated are
+	// dropped.
+	lastSeen map[string]uint64
+
+	// running holds the processes seen on each device by uuid at the previous
+	// collection, mapped to their start time or 0 if unknown. A pid with a
+	// different start time is a new process reusing the pid.
+	running map[string]map[uint32]float64
+	// started and exited count the process churn on each device by uuid.
+	started map[string]int
+	exited  map[string]int
+
+	memoryUsed   *prometheus.Desc
+	smUtil       *prometheus.Desc
+	memoryUtil   *prometheus.Desc
+	encoderUtil  *prometheus.Desc
+	decoderUtil  *prometheus.Desc
+	startTime    *prometheus.Desc
+	count        *prometheus.Desc
+	startedTotal *prometheus.Desc
+	exitedTotal  *prometheus.Desc
+}
+
+func newProcessesCollector(logger *slog.Logger) collector {
+	c := &processesCollector{
+		logger:     logger,
+		kubernetes: *processKubernetes,
+		containers: make(map[string]kubernetesContainer),
+		lastSeen:   make(map[string]uint64),
+		running:    make(map[string]map[uint32]float64),
+		started:    make(map[string]int),
+		exited:     make(map[string]int),
+	}
+
+	labels := []string{"pid", "process_name"}
+	if c.kubernetes {
+		labels = append(labels, "namespace", "pod", "container")
+	}
+	for _, name := range strings.Split(*processEnv, ",") {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			continue
+		}
+		label := envLabelName(name)
+		if strings.HasPrefix(label, "__") {
+			logger.Error("environment variable label is reserved, skipping", "env", name, "label", label)
+			continue
+		}
+		if slices.Contains(deviceLabels, label) || slices.Contains(labels, label) {
+			logger.Error("environment variable label clashes with another label, skipping", "env", name, "label", label)
+			continue
+		}
+		c.envVars = append(c.envVars, name)
+		labels = append(labels, label)
+	}
+
+	c.memoryUsed = deviceDesc("process", "memory_used_bytes",
+		"Memory used on the GPU device by the process in bytes.",
+		labels...)
+	c.smUtil = deviceDesc("process", "sm_utilization",
+		"Percent of time over the past sample period during which the process was executing kernels on the streaming multiprocessors of the GPU device.",
+		labels...)
+	c.memoryUtil = deviceDesc("process", "memory_utilization",
+		"Percent of time over the past sample period during which the process was reading or writing memory of the GPU device.",
+		labels...)
+	c.encoderUtil = deviceDesc("process", "encoder_utilization",
+		"Percent of time over the past sample period during which the process was using the video encoders of the GPU device.",
+		labels...)
+	c.decoderUtil = deviceDesc("process", "decoder_utilization",
+		"Percent of time over the past sample period during which the process was using the video decoders of the GPU device.",
+		labels...)
+	c.startTime = deviceDesc("process", "start_time_seconds",
+		"Start time of the process using the GPU device since unix epoch in seconds.",
+		labels...)
+	c.count = deviceDesc("", "processes",
+		"Number of processes running on the GPU device.")
+	c.startedTotal = deviceDesc("", "processes_started_total",
+		"Number of processes observed to start using the GPU device between collections.")
+	c.exitedTotal = deviceDesc("", "processes_exited_total",
+		"Number of processes observed to stop using the GPU device between collections.")
+	return c
+}
+
+// envLabelName returns the label name for an environment variable, e.g.
+// "job_id" for JOB_ID. Names starting with "__" map to reserved label names,
+// which the caller must reject.
+func envLabelName(name string) string {
+	label := strings.Map(func(r rune) rune {
+		if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') || r == '_' {
+			return r
+		}
+		if r >= 'A' && r <= 'Z' {
+			return r - 'A' + 'a'
+		}
+		return '_'
+	}, name)
+	if label != "" && label[0] >= '0' && label[0] <= '9' {
+		label = "_" + label
+	}
+	return label
+}
+
+func (c *processesCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.memoryUsed
+	ch <- c.smUtil
+	ch <- c.memoryUtil
+	ch <- c.encoderUtil
+	ch <- c.decoderUtil
+	ch <- c.startTime
+	ch <- c.count
+	ch <- c.startedTotal
+	ch <- c.exitedTotal
+}
+
+func (c *processesCollector) Collect(ch chan<- prometheus.Metric, devices []*device) {
+	seen := make(map[string]bool)
+	present := make(map[string]bool, len(devices))
+	for _, d := range devices {
+		present[d.uuid] = true
+		processes, err := d.runningProcesses()
+		if err != nil {
+			c.logger.Debug("failed to get running processes", "uuid", d.uuid, "err", err)
+			continue
+		}
+		utilization, utilOK := c.utilization(d)
+
+		running := make(map[uint32]float64, len(processes))
+		for pid, used := range processes {
+			name, start := c.procInfo(pid)
+			labels := d.labelsWith(strconv.FormatUint(uint64(pid), 10), name)
+			if c.kubernetes {
+				id := c.containerID(pid)
+				seen[id] = true
+				container := c.container(id)
+				labels = append(labels, container.namespace, container.pod, container.container)
+			}
+			labels = append(labels, c.environ(pid)...)
+			if used != math.MaxUint64 {
+				ch <- prometheus.MustNewConstMetric(c.memoryUsed, prometheus.GaugeValue, float64(used), labels...)
+			}
+			if utilOK {
+				// Processes without a sample were idle.
+				sample := utilization[pid]
+				ch <- prometheus.MustNewConstMetric(c.smUtil, prometheus.GaugeValue, float64(sample.SmUtil), labels...)
+				ch <- prometheus.MustNewConstMetric(c.memoryUtil, prometheus.GaugeValue, float64(sample.MemUtil), labels...)
+				ch <- prometheus.MustNewConstMetric(c.encoderUtil, prometheus.GaugeValue, float64(sample.EncUtil), labels...)
+				ch <- prometheus.MustNewConstMetric(c.decoderUtil, prometheus.GaugeValue, float64(sample.DecUtil), labels...)
+			}
+			if start > 0 {
+				ch <- prometheus.MustNewConstMetric(c.startTime, prometheus.GaugeValue, start, labels...)
+			}
+			running[pid] = start
+		}
+		c.updateChurn(d.uuid, running)
+
+		ch <- prometheus.MustNewConstMetric(c.count, prometheus.GaugeValue, float64(len(processes)), d.labels...)
+		ch <- prometheus.MustNewConstMetric(c.startedTotal, prometheus.CounterValue, float64(c.started[d.uuid]), d.labels...)
+		ch <- prometheus.MustNewConstMetric(c.exitedTotal, prometheus.CounterValue, float64(c.exited[d.uuid]), d.labels...)
+	}
+	for id := range c.containers {
+		if !seen[id] {
+			delete(c.containers, id)
+		}
+	}
+	for uuid := range c.lastSeen {
+		if !present[uuid] {
+			delete(c.lastSeen, uuid)
+		}
+	}
+}
+
+// utilization returns the newest utilization sample of each process taken
+// on the device since the previous collection, by pid. It returns false if
+// NVML can't report process utilization.
+func (c *processesCollector) utilization(d *device) (map[uint32]nvml.ProcessUtilizationSample, bool) {
+	samples, ret := d.GetProcessUtilization(c.lastSeen[d.uuid])
+	if ret != nvml.SUCCESS && ret != nvml.ERROR_NOT_FOUND {
+		c.logger.Debug("failed to get process utilization", "uuid", d.uuid, "err", ret)
+		return nil, false
+	}
+	newest := make(map[uint32]nvml.ProcessUtilizationSample, len(samples))
+	for _, sample := range samples {
+		c.lastSeen[d.uuid] = max(c.lastSeen[d.uuid], sample.TimeStamp)
+		if prev, ok := newest[sample.Pid]; !ok || sample.TimeStamp > prev.TimeStamp {
+			newest[sample.Pid] = sample
+		}
+	}
+	return newest, true
+}
+
+// updateChurn counts the processes that started and exited on the device
+// with the given uuid since the previous collection. The processes running
+// at the first collection of a device are not counted as started.
+func (c *processesCollector) updateChurn(uuid string, running map[uint32]float64) {
+	previous, ok := c.running[uuid]
+	c.running[uuid] = running
+	if !ok {
+		return
+	}
+	for pid, start := range running {
+		if prev, ok := previous[pid]; !ok || prev != start {
+			c.started[uuid]++
+		}
+	}
+	for pid, start := range previous {
+		if cur, ok := running[pid]; !ok || cur != start {
+			c.exited[uuid]++
+		}
+	}
+}
+
+// procInfo returns the name of the process with the given pid and its start
+// time since unix epoch in seconds. Either is empty if it can't be read.
+func (c *processesCollector) procInfo(pid uint32) (string, float64) {
+	proc, err := procfs.NewProc(int(pid))
+	if err != nil {
+		c.logger.Debug("failed to find process", "pid", pid, "err", err)
+		return "", 0
+	}
+	stat, err := proc.Stat()
+	if err != nil {
+		c.logger.Debug("failed to read process stat", "pid", pid, "err", err)
+		return "", 0
+	}
+	start, err := stat.StartTime()
+	if err != nil {
+		c.logger.Debug("failed to get process start time", "pid", pid, "err", err)
+		return stat.Comm, 0
+	}
+	return stat.Comm, start
+}
+
+// containerID returns the id of the container of the process with the given
+// pid from its cgroup, or "" if it doesn't run in a container.
+func (c *processesCollector) containerID(pid uint32) string {
+	content, err := os.ReadFile(fmt.Sprintf("/proc/%d/cgroup", pid))
+	if err != nil {
+		c.logger.Debug("failed to read process cgroup", "pid", pid, "err", err)
+		return ""
+	}
+	return parseCgroupContainerID(string(content))
+}
+
+// parseCgroupContainerID returns the container id in the content of a
+// /proc/<pid>/cgroup file, or "" if there is none.
+func parseCgroupContainerID(content string) string {
+	// Lines are "<hierarchy>:<controllers>:<path>", a single "0::<path>" on
+	// cgroup v2.
+	for _, line := range strings.Split(content, "\n") {
+		parts := strings.SplitN(line, ":", 3)
+		if len(parts) != 3 {
+			continue
+		}
+		if match := cgroupContainerID.FindStringSubmatch(parts[2]); match != nil {
+			return match[1]
+		}
+	}
+	return ""
+}
+
+// container returns the Kubernetes container with the given id, from the
+// pod annotations of its OCI bundle. It is empty for containers that aren't
+// managed by the kubelet or whose bundle can't be found.
+func (c *processesCollector) container(id string) kubernetesContainer {
+	if id == "" {
+		return kubernetesContainer{}
+	}
+	if container, ok := c.containers[id]; ok {
+		return container
+	}
+	container, err := readBundleContainer(id)
+	if os.IsNotExist(err) {
+		c.logger.Debug("failed to find container bundle", "id", id)
+		return container
+	}
+	if err != nil {
+		c.logger.Debug("failed to read container bundle", "id", id, "err", err)
+	}
+	c.containers[id] = container
+	return container
+}
+
+// environ returns the values of the exported environment variables of the
+// process with the given pid. Variables that are unset, or can't be read
+// because the process runs in another PID namespace or as another user, are
+// empty.
+func (c *processesCollector) environ(pid uint32) []string {
+	values := make([]string, len(c.envVars))
+	if len(c.envVars) == 0 {
+		return values
+	}
+	content, err := os.ReadFile(fmt.Sprintf("/proc/%d/environ", pid))
+	if err != nil {
+		c.logger.Debug("failed to read process environment", "pid", pid, "err", err)
+		return values
+	}
+	return parseEnviron(content, c.envVars)
+}
+
+// parseEnviron returns the values of the environment variables with the given
+// names in the NUL-separated environ content, or "" for those that aren't
+// set.
+func parseEnviron(content []byte, names []string) []string {
+	values := make([]string, len(names))
+	for _, entry := range bytes.Split(content, []byte{0}) {
+		name, value, ok := bytes.Cut(entry, []byte{'='})
+		if !ok {
+			continue
+		}
+		if i := slices.Index(names, string(name)); i >= 0 {
+			values[i] = string(value)
+		}
+	}
+	return values
+}
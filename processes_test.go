@@ -0,0 +1,122 @@
+package main
+
+import (
+	"flag"
+	"log/slog"
+	"slices"
+	"testing"
+)
+
+func TestEnvLabelName(t *testing.T) {
+	for _, tc := range []struct {
+		env, want string
+	}{
+		{"JOB_ID", "job_id"},
+		{"user", "user"},
+		{"MLFLOW-RUN.ID", "mlflow_run_id"},
+		{"1SLOT", "_1slot"},
+		{"_FOO", "_foo"},
+		{"__FOO", "__foo"},
+		{"ÄB", "_b"},
+	} {
+		if got := envLabelName(tc.env); got != tc.want {
+			t.Errorf("envLabelName(%q) = %q, want %q", tc.env, got, tc.want)
+		}
+	}
+}
+
+func TestParseEnviron(t *testing.T) {
+	names := []string{"JOB_ID", "USER", "MLFLOW_RUN_ID"}
+	for _, tc := range []struct {
+		name    string
+		content string
+		want    []string
+	}{
+		{"empty", "", []string{"", "", ""}},
+		{"all set", "JOB_ID=42\x00USER=alice\x00MLFLOW_RUN_ID=abc\x00", []string{"42", "alice", "abc"}},
+		{"some set", "HOME=/root\x00USER=alice\x00", []string{"", "alice", ""}},
+		{"empty value", "JOB_ID=\x00", []string{"", "", ""}},
+		{"value with equals", "JOB_ID=a=b\x00", []string{"a=b", "", ""}},
+		{"no trailing nul", "USER=alice", []string{"", "alice", ""}},
+		{"entry without equals", "JOB_ID\x00USER=alice\x00", []string{"", "alice", ""}},
+		{"prefix of name", "JOB=1\x00JOB_ID_2=2\x00", []string{"", "", ""}},
+		{"case sensitive", "user=alice\x00", []string{"", "", ""}},
+		{"last wins", "USER=alice\x00USER=bob\x00", []string{"", "bob", ""}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := parseEnviron([]byte(tc.content), names); !slices.Equal(got, tc.want) {
+				t.Errorf("parseEnviron(%q) = %q, want %q", tc.content, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestProcessesCollectorEnvLabels(t *testing.T) {
+	defer func(value string) { flag.Set("collector.processes.env", value) }(*processEnv)
+	flag.Set("collector.processes.env", " JOB_ID, __META,job-id,pid,,UUID,1X")
+
+	c := newProcessesCollector(slog.New(slog.DiscardHandler)).(*processesCollector)
+	// __META is reserved, job-id duplicates JOB_ID, pid and uuid clash with
+	// the process and device labels.
+	if want := []string{"JOB_ID", "1X"}; !slices.Equal(c.envVars, want) {
+		t.Errorf("envVars = %q, want %q", c.envVars, want)
+	}
+}
+
+func TestParseCgroupContainerID(t *testing.T) {
+	const id = "4c0f1e2d3b4a59687766554433221100ffeeddccbbaa99887766554433221100"
+	for _, tc := range []struct {
+		name, cgroup, want string
+	}{
+		{"containerd systemd", "0::/kubepods.slice/kubepods-burstable.slice/kubepods-burstable-pod1b2c.slice/cri-containerd-" + id + ".scope\n", id},
+		{"cri-o systemd", "0::/kubepods.slice/kubepods-pod1b2c.slice/crio-" + id + ".scope\n", id},
+		{"cgroupfs v1", "12:memory:/kubepods/besteffort/pod1b2c/" + id + "\n11:cpu,cpuacct:/kubepods/besteffort/pod1b2c/" + id + "\n", id},
+		{"docker", "0::/system.slice/docker-" + id + ".scope\n", id},
+		{"host process", "0::/user.slice/user-1000.slice/session-3.scope\n", ""},
+		{"pod sandbox only", "0::/kubepods.slice/kubepods-pod1b2c.slice\n", ""},
+		{"empty", "", ""},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := parseCgroupContainerID(tc.cgroup); got != tc.want {
+				t.Errorf("parseCgroupContainerID() = %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestUpdateChurn(t *testing.T) {
+	for _, tc := range []struct {
+		name    string
+		running []map[uint32]float64
+		started int
+		exited  int
+	}{
+		{"first collection", []map[uint32]float64{{1: 100, 2: 200}}, 0, 0},
+		{"unchanged", []map[uint32]float64{{1: 100}, {1: 100}}, 0, 0},
+		{"started", []map[uint32]float64{{1: 100}, {1: 100, 2: 200}}, 1, 0},
+		{"exited", []map[uint32]float64{{1: 100, 2: 200}, {1: 100}}, 0, 1},
+		{"all exited", []map[uint32]float64{{1: 100, 2: 200}, {}}, 0, 2},
+		{"started on idle device", []map[uint32]float64{{}, {1: 100}}, 1, 0},
+		// A reused pid with another start time is a new process.
+		{"pid reused", []map[uint32]float64{{1: 100}, {1: 300}}, 1, 1},
+		{"churn", []map[uint32]float64{{1: 100}, {2: 200}, {2: 200, 3: 300}, {3: 300}}, 2, 2},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			c := &processesCollector{
+				running: make(map[string]map[uint32]float64),
+				started: make(map[string]int),
+				exited:  make(map[string]int),
+			}
+			for _, running := range tc.running {
+				c.updateChurn("GPU-0", running)
+				c.updateChurn("GPU-1", map[uint32]float64{9: 900})
+			}
+			if c.started["GPU-0"] != tc.started || c.exited["GPU-0"] != tc.exited {
+				t.Errorf("started, exited = %d, %d, want %d, %d", c.started["GPU-0"], c.exited["GPU-0"], tc.started, tc.exited)
+			}
+			if c.started["GPU-1"] != 0 || c.exited["GPU-1"] != 0 {
+				t.Errorf("churn of GPU-0 was counted for GPU-1")
+			}
+		})
+	}
+}
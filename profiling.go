@@ -0,0 +1,77 @@
+package main
+
+import (
+	"log/slog"
+
+	"github.com/NVIDIA/go-nvml/pkg/nvml"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+func init() {
+	registerCollector("profiling", defaultDisabled, newProfilingCollector)
+}
+
+// profilingMetrics maps the GPM metrics exported by the profiling collector
+// to the value of the unit label of nvidia_gpu_profiling_utilization_percent.
+var profilingMetrics = map[nvml.GpmMetricId]string{
+	nvml.GPM_METRIC_GRAPHICS_UTIL:   "graphics",
+	nvml.GPM_METRIC_SM_UTIL:         "sm",
+	nvml.GPM_METRIC_INTEGER_UTIL:    "integer",
+	nvml.GPM_METRIC_ANY_TENSOR_UTIL: "tensor",
+	nvml.GPM_METRIC_FP64_UTIL:       "fp64",
+	nvml.GPM_METRIC_FP32_UTIL:       "fp32",
+	nvml.GPM_METRIC_FP16_UTIL:       "fp16",
+}
+
+// profilingCollector exports the hardware profiling counters of GPU
+// Performance Monitoring (GPM), which resolve how busy the streaming
+// multiprocessors and their pipelines are, unlike the coarse duty cycle. It
+// is experimental and disabled by default because sampling the counters
+// costs more than the other collectors and they are only available on
+// Hopper or newer.
+type profilingCollector struct {
+	logger *slog.Logger
+
+	gpm *gpmSampler
+	ids []nvml.GpmMetricId
+
+	occupancy   *prometheus.Desc
+	utilization *prometheus.Desc
+}
+
+func newProfilingCollector(logger *slog.Logger) collector {
+	ids := []nvml.GpmMetricId{nvml.GPM_METRIC_SM_OCCUPANCY}
+	for id := range profilingMetrics {
+		ids = append(ids, id)
+	}
+	return &profilingCollector{
+		logger: logger,
+		gpm:    newGPMSampler(logger),
+		ids:    ids,
+		occupancy: deviceDesc("profiling", "sm_occupancy_percent",
+			"Achieved warp occupancy of the streaming multiprocessors as a percent of the maximum since the previous collection. Requires GPM support (Hopper or newer)."),
+		utilization: deviceDesc("profiling", "utilization_percent",
+			"Percent of time since the previous collection the unit was active. Requires GPM support (Hopper or newer).",
+			"unit"),
+	}
+}
+
+func (c *profilingCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.occupancy
+	ch <- c.utilization
+}
+
+func (c *profilingCollector) Collect(ch chan<- prometheus.Metric, devices []*device) {
+	defer c.gpm.sweep()
+	for _, d := range devices {
+		values := c.gpm.metrics(d, c.ids...)
+		if value, ok := values[nvml.GPM_METRIC_SM_OCCUPANCY]; ok {
+			ch <- prometheus.MustNewConstMetric(c.occupancy, prometheus.GaugeValue, value, d.labels...)
+		}
+		for id, unit := range profilingMetrics {
+			if value, ok := values[id]; ok {
+				ch <- prometheus.MustNewConstMetric(c.utilization, prometheus.GaugeValue, value, d.labelsWith(unit)...)
+			}
+		}
+	}
+}
@@ -0,0 +1,279 @@
+package main
+
+import (
+	"bytes"
+	"cmp"
+	"context"
+	"encoding/xml"
+	"fmt"
+	"log/slog"
+	"os/exec"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+func init() {
+	registerCollector("remote", defaultDisabled, newRemoteCollector)
+	// SSH round trips are too slow for the scrape path.
+	collectorIntervals["remote"] = 30 * time.Second
+}
+
+// RemoteHostConfig is a host whose GPUs are collected over SSH by running
+// nvidia-smi on it.
+type RemoteHostConfig struct {
+	// Name is the value of the host label. It defaults to Destination.
+	Name string `yaml:"name"`
+	// Destination is passed to ssh, e.g. "admin@10.0.0.5" or a host alias
+	// from ~/.ssh/config.
+	Destination string `yaml:"destination"`
+	// Timeout bounds the SSH command. It defaults to 10s.
+	Timeout time.Duration `yaml:"timeout"`
+}
+
+const defaultRemoteTimeout = 10 * time.Second
+
+// validateRemoteHosts checks the remote hosts and fills in their defaults.
+func validateRemoteHosts(hosts []RemoteHostConfig) error {
+	seen := make(map[string]bool, len(hosts))
+	for i := range hosts {
+		h := &hosts[i]
+		if h.Destination == "" {
+			return fmt.Errorf("remote host %d: missing destination", i)
+		}
+		if strings.HasPrefix(h.Destination, "-") {
+			return fmt.Errorf("remote host %d: invalid destination %q", i, h.Destination)
+		}
+		if h.Name == "" {
+			h.Name = h.Destination
+		}
+		if seen[h.Name] {
+			return fmt.Errorf("duplicate remote host %q", h.Name)
+		}
+		seen[h.Name] = true
+		if h.Timeout <= 0 {
+			h.Timeout = defaultRemoteTimeout
+		}
+	}
+	return nil
+}
+
+// remoteHosts holds the remote hosts of the configuration file.
+var remoteHosts atomic.Pointer[[]RemoteHostConfig]
+
+// setRemoteHosts replaces the remote hosts.
+func setRemoteHosts(hosts []RemoteHostConfig) {
+	remoteHosts.Store(&hosts)
+}
+
+// smiLog is the part of the XML output of nvidia-smi -q -x the remote
+// collector uses.
+type smiLog struct {
+	GPUs []smiGPU `xml:"gpu"`
+}
+
+type smiGPU struct {
+	ProductName string `xml:"product_name"`
+	Serial      string `xml:"serial"`
+	UUID        string `xml:"uuid"`
+	MinorNumber string `xml:"minor_number"`
+	PCIBusID    string `xml:"pci>pci_bus_id"`
+	FanSpeed    string `xml:"fan_speed"`
+	MemoryTotal string `xml:"fb_memory_usage>total"`
+	MemoryUsed  string `xml:"fb_memory_usage>used"`
+	GPUUtil     string `xml:"utilization>gpu_util"`
+	MemoryUtil  string `xml:"utilization>memory_util"`
+	Temperature string `xml:"temperature>gpu_temp"`
+	PowerDraw   string `xml:"power_readings>power_draw"`
+	// Drivers since R535 report the power draw under gpu_power_readings.
+	GPUPowerDraw string `xml:"gpu_power_readings>power_draw"`
+}
+
+// parseSMI parses the output of nvidia-smi -q -x.
+func parseSMI(out []byte) ([]smiGPU, error) {
+	var log smiLog
+	if err := xml.NewDecoder(bytes.NewReader(out)).Decode(&log); err != nil {
+		return nil, fmt.Errorf("failed to parse nvidia-smi output: %w", err)
+	}
+	return log.GPUs, nil
+}
+
+// smiValue parses a value reported by nvidia-smi with its unit, e.g.
+// "1024 MiB" or "35 %". Unsupported values are reported as "N/A" or
+// "[Not Supported]".
+func smiValue(s string) (float64, bool) {
+	fields := strings.Fields(s)
+	if len(fields) == 0 {
+		return 0, false
+	}
+	value, err := strconv.ParseFloat(fields[0], 64)
+	return value, err == nil
+}
+
+// labels returns the values of deviceLabels for g on host.
+func (g smiGPU) labels(host string) []string {
+	values := make([]string, len(deviceLabels))
+	for i, label := range deviceLabels {
+		switch label {
+		case "minor_number":
+			values[i] = g.MinorNumber
+		case "uuid":
+			values[i] = g.UUID
+		case "name":
+			values[i] = g.ProductName
+		case "model":
+			values[i] = modelName(g.ProductName)
+		case "serial":
+			values[i] = g.Serial
+		case "pci_bus_id":
+			values[i] = g.PCIBusID
+		case "host":
+			values[i] = host
+		}
+	}
+	return values
+}
+
+// remoteField is a value of nvidia-smi exported with the descriptor of the
+// corresponding local metric.
+type remoteField struct {
+	value func(smiGPU) string
+	// scale converts the value reported by nvidia-smi to the unit of the
+	// metric.
+	scale float64
+	desc  *prometheus.Desc
+}
+
+// remoteCollector collects the GPUs of the remote hosts of the
+// configuration file over SSH, as the local metrics with the host label set.
+// Each host is queried with a single nvidia-smi invocation per collection,
+// in parallel.
+type remoteCollector struct {
+	logger *slog.Logger
+
+	fields []remoteField
+	// warned is set once the collector warned that the host label is
+	// missing.
+	warned bool
+
+	up       *prometheus.Desc
+	duration *prometheus.Desc
+}
+
+func newRemoteCollector(logger *slog.Logger) collector {
+	// The descriptors are taken from the local collectors, so both export
+	// the same metrics.
+	memory := newMemoryCollector(logger).(*memoryCollector)
+	utilization := newUtilizationCollector(logger).(*utilizationCollector)
+	power := newPowerCollector(logger).(*powerCollector)
+	temperature := newTemperatureCollector(logger).(*temperatureCollector)
+	fan := newFanCollector(logger).(*fanCollector)
+	return &remoteCollector{
+		logger: logger,
+		fields: []remoteField{
+			{func(g smiGPU) string { return g.MemoryUsed }, 1 << 20, memory.used},
+			{func(g smiGPU) string { return g.MemoryTotal }, 1 << 20, memory.total},
+			{func(g smiGPU) string { return g.GPUUtil }, 1, utilization.dutyCycle},
+			{func(g smiGPU) string { return g.MemoryUtil }, 1, utilization.memoryDutyCycle},
+			{func(g smiGPU) string { return cmp.Or(g.GPUPowerDraw, g.PowerDraw) }, 1000, power.usage},
+			{func(g smiGPU) string { return g.Temperature }, 1, temperature.temperature},
+			{func(g smiGPU) string { return g.FanSpeed }, 1, fan.speed},
+		},
+		up: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, "remote", "up"),
+			"Whether the last collection from the remote host succeeded.",
+			[]string{"host"}, nil,
+		),
+		duration: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, "remote", "collection_duration_seconds"),
+			"Duration of the last collection from the remote host in seconds.",
+			[]string{"host"}, nil,
+		),
+	}
+}
+
+func (c *remoteCollector) nvmlIndependent() {}
+
+func (c *remoteCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.up
+	ch <- c.duration
+	for _, f := range c.fields {
+		ch <- f.desc
+	}
+}
+
+func (c *remoteCollector) Collect(ch chan<- prometheus.Metric, _ []*device) {
+	hosts := remoteHosts.Load()
+	if hosts == nil || len(*hosts) == 0 {
+		return
+	}
+	// The host label is only added to the device labels when the collector
+	// is enabled at startup.
+	if !slices.Contains(deviceLabels, "host") {
+		if !c.warned {
+			c.logger.Warn("the remote collector must be enabled at startup, restart with --collector.remote")
+			c.warned = true
+		}
+		return
+	}
+
+	var wg sync.WaitGroup
+	for _, h := range *hosts {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			start := time.Now()
+			err := c.collectHost(ch, h)
+			if err != nil {
+				c.logger.Error("failed to collect from remote host", "host", h.Name, "err", err)
+			}
+			up := 0.0
+			if err == nil {
+				up = 1
+			}
+			ch <- prometheus.MustNewConstMetric(c.duration, prometheus.GaugeValue, time.Since(start).Seconds(), h.Name)
+			ch <- prometheus.MustNewConstMetric(c.up, prometheus.GaugeValue, up, h.Name)
+		}()
+	}
+	wg.Wait()
+}
+
+// collectHost runs nvidia-smi on h and sends the metrics of its devices.
+func (c *remoteCollector) collectHost(ch chan<- prometheus.Metric, h RemoteHostConfig) error {
+	ctx, cancel := context.WithTimeout(context.Background(), h.Timeout)
+	defer cancel()
+
+	cmd := exec.CommandContext(ctx, "ssh",
+		"-o", "BatchMode=yes",
+		"-o", fmt.Sprintf("ConnectTimeout=%d", max(1, int(h.Timeout.Seconds()))),
+		"--", h.Destination,
+		"nvidia-smi", "-q", "-x")
+	out, err := cmd.Output()
+	if err != nil {
+		if exitErr, ok := err.(*exec.ExitError); ok && len(exitErr.Stderr) > 0 {
+			return fmt.Errorf("%w: %s", err, strings.TrimSpace(string(exitErr.Stderr)))
+		}
+		return err
+	}
+
+	gpus, err := parseSMI(out)
+	if err != nil {
+		return err
+	}
+	for _, g := range gpus {
+		labels := g.labels(h.Name)
+		for _, f := range c.fields {
+			value, ok := smiValue(f.value(g))
+			if !ok {
+				continue
+			}
+			ch <- prometheus.MustNewConstMetric(f.desc, prometheus.GaugeValue, value*f.scale, labels...)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,149 @@
+package main
+
+import (
+	"slices"
+	"testing"
+)
+
+const smiSample = `<?xml version="1.0" ?>
+<!DOCTYPE nvidia_smi_log SYSTEM "nvsmi_device_v12.dtd">
+<nvidia_smi_log>
+	<driver_version>550.54.15</driver_version>
+	<attached_gpus>2</attached_gpus>
+	<gpu id="00000000:17:00.0">
+		<product_name>NVIDIA A100-SXM4-80GB</product_name>
+		<serial>1564720004631</serial>
+		<uuid>GPU-aaaaaaaa-0000-0000-0000-000000000000</uuid>
+		<minor_number>0</minor_number>
+		<pci>
+			<pci_bus_id>00000000:17:00.0</pci_bus_id>
+		</pci>
+		<fan_speed>N/A</fan_speed>
+		<fb_memory_usage>
+			<total>81920 MiB</total>
+			<used>1024 MiB</used>
+		</fb_memory_usage>
+		<utilization>
+			<gpu_util>35 %</gpu_util>
+			<memory_util>10 %</memory_util>
+		</utilization>
+		<temperature>
+			<gpu_temp>34 C</gpu_temp>
+		</temperature>
+		<gpu_power_readings>
+			<power_draw>61.25 W</power_draw>
+		</gpu_power_readings>
+	</gpu>
+	<gpu id="00000000:65:00.0">
+		<product_name>Tesla T4</product_name>
+		<uuid>GPU-bbbbbbbb-0000-0000-0000-000000000000</uuid>
+		<minor_number>1</minor_number>
+		<fan_speed>[Not Supported]</fan_speed>
+		<power_readings>
+			<power_draw>27.50 W</power_draw>
+		</power_readings>
+	</gpu>
+</nvidia_smi_log>
+`
+
+func TestParseSMI(t *testing.T) {
+	gpus, err := parseSMI([]byte(smiSample))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(gpus) != 2 {
+		t.Fatalf("got %d GPUs, want 2", len(gpus))
+	}
+
+	a100 := gpus[0]
+	for _, tc := range []struct {
+		field, got, want string
+	}{
+		{"product_name", a100.ProductName, "NVIDIA A100-SXM4-80GB"},
+		{"serial", a100.Serial, "1564720004631"},
+		{"uuid", a100.UUID, "GPU-aaaaaaaa-0000-0000-0000-000000000000"},
+		{"minor_number", a100.MinorNumber, "0"},
+		{"pci_bus_id", a100.PCIBusID, "00000000:17:00.0"},
+		{"memory total", a100.MemoryTotal, "81920 MiB"},
+		{"memory used", a100.MemoryUsed, "1024 MiB"},
+		{"gpu_util", a100.GPUUtil, "35 %"},
+		{"memory_util", a100.MemoryUtil, "10 %"},
+		{"gpu_temp", a100.Temperature, "34 C"},
+		{"gpu_power_readings", a100.GPUPowerDraw, "61.25 W"},
+		{"power_readings", gpus[1].PowerDraw, "27.50 W"},
+	} {
+		if tc.got != tc.want {
+			t.Errorf("%s = %q, want %q", tc.field, tc.got, tc.want)
+		}
+	}
+
+	if _, err := parseSMI([]byte("Failed to initialize NVML: Driver/library version mismatch")); err == nil {
+		t.Error("parsing non-XML output succeeded")
+	}
+}
+
+func TestSMIValue(t *testing.T) {
+	for _, tc := range []struct {
+		in    string
+		want  float64
+		valid bool
+	}{
+		{"1024 MiB", 1024, true},
+		{"61.25 W", 61.25, true},
+		{"35 %", 35, true},
+		{"0", 0, true},
+		{"N/A", 0, false},
+		{"[Not Supported]", 0, false},
+		{"", 0, false},
+	} {
+		got, valid := smiValue(tc.in)
+		if got != tc.want || valid != tc.valid {
+			t.Errorf("smiValue(%q) = %v, %v, want %v, %v", tc.in, got, valid, tc.want, tc.valid)
+		}
+	}
+}
+
+func TestSMIGPULabels(t *testing.T) {
+	defer func(labels []string) { deviceLabels = labels }(deviceLabels)
+	deviceLabels = []string{"minor_number", "uuid", "name", "model", "serial", "pci_bus_id", "host"}
+
+	g := smiGPU{
+		ProductName: "NVIDIA A100-SXM4-80GB",
+		Serial:      "1564720004631",
+		UUID:        "GPU-aaaaaaaa",
+		MinorNumber: "0",
+		PCIBusID:    "00000000:17:00.0",
+	}
+	want := []string{"0", "GPU-aaaaaaaa", "NVIDIA A100-SXM4-80GB", modelName(g.ProductName), "1564720004631", "00000000:17:00.0", "gpu-1"}
+	if got := g.labels("gpu-1"); !slices.Equal(got, want) {
+		t.Errorf("labels = %q, want %q", got, want)
+	}
+}
+
+func TestValidateRemoteHosts(t *testing.T) {
+	for _, tc := range []struct {
+		name  string
+		hosts []RemoteHostConfig
+		valid bool
+	}{
+		{"valid", []RemoteHostConfig{{Destination: "admin@gpu-1"}, {Name: "b", Destination: "gpu-2"}}, true},
+		{"missing destination", []RemoteHostConfig{{Name: "a"}}, false},
+		{"option destination", []RemoteHostConfig{{Destination: "-oProxyCommand=id"}}, false},
+		{"duplicate name", []RemoteHostConfig{{Destination: "gpu-1"}, {Name: "gpu-1", Destination: "gpu-2"}}, false},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			err := validateRemoteHosts(tc.hosts)
+			if valid := err == nil; valid != tc.valid {
+				t.Fatalf("validateRemoteHosts() = %v, want valid %v", err, tc.valid)
+			}
+			if !tc.valid {
+				return
+			}
+			for _, h := range tc.hosts {
+				if h.Name == "" || h.Timeout != defaultRemoteTimeout {
+					t.Errorf("host %+v: defaults not filled in", h)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,25 @@
+//go:build !windows
+
+package main
+
+import (
+	"fmt"
+	"os"
+)
+
+// isWindowsService reports whether the process was started by the Windows
+// service control manager, which is never the case on other platforms.
+func isWindowsService() bool {
+	return false
+}
+
+// runServiceCommand reports that services are only supported on Windows.
+func runServiceCommand(args []string) int {
+	fmt.Fprintln(os.Stderr, "the service command is only supported on Windows")
+	return 2
+}
+
+// runService is never called on platforms other than Windows.
+func runService(opts ExporterOpts) int {
+	panic("not a Windows service")
+}
@@ -0,0 +1,212 @@
+//go:build windows
+
+package main
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"golang.org/x/sys/windows/svc"
+	"golang.org/x/sys/windows/svc/eventlog"
+	"golang.org/x/sys/windows/svc/mgr"
+)
+
+const (
+	serviceName        = "nvidia_gpu_exporter"
+	serviceDisplayName = "NVIDIA GPU Exporter"
+	serviceDescription = "Exports NVIDIA GPU metrics for Prometheus."
+)
+
+// isWindowsService reports whether the process was started by the service
+// control manager.
+func isWindowsService() bool {
+	ok, err := svc.IsWindowsService()
+	return err == nil && ok
+}
+
+// runServiceCommand runs the service subcommand, which installs or
+// uninstalls the exporter as a Windows service, and returns the exit code.
+// The arguments after "install" are passed to the service on every start.
+func runServiceCommand(args []string) int {
+	if len(args) == 0 {
+		fmt.Fprintf(os.Stderr, "Usage: %s service install [flags] | uninstall\n", os.Args[0])
+		return 2
+	}
+	var err error
+	switch args[0] {
+	case "install":
+		err = installService(args[1:])
+	case "uninstall":
+		err = uninstallService()
+	default:
+		fmt.Fprintf(os.Stderr, "unknown service command %q\n", args[0])
+		return 2
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "failed to %s service: %v\n", args[0], err)
+		return 1
+	}
+	return 0
+}
+
+func installService(args []string) error {
+	exe, err := os.Executable()
+	if err != nil {
+		return err
+	}
+	exe, err = filepath.Abs(exe)
+	if err != nil {
+		return err
+	}
+
+	m, err := mgr.Connect()
+	if err != nil {
+		return err
+	}
+	defer m.Disconnect()
+
+	s, err := m.CreateService(serviceName, exe, mgr.Config{
+		DisplayName: serviceDisplayName,
+		Description: serviceDescription,
+		StartType:   mgr.StartAutomatic,
+	}, args...)
+	if err != nil {
+		return err
+	}
+	defer s.Close()
+
+	if err := eventlog.InstallAsEventCreate(serviceName, eventlog.Error|eventlog.Warning|eventlog.Info); err != nil {
+		s.Delete()
+		return fmt.Errorf("failed to register event log source: %w", err)
+	}
+	return nil
+}
+
+func uninstallService() error {
+	m, err := mgr.Connect()
+	if err != nil {
+		return err
+	}
+	defer m.Disconnect()
+
+	s, err := m.OpenService(serviceName)
+	if err != nil {
+		return err
+	}
+	defer s.Close()
+	if err := s.Delete(); err != nil {
+		return err
+	}
+	return eventlog.Remove(serviceName)
+}
+
+// runService runs the exporter under the service control manager, logging to
+// the Windows event log, and returns the exit code.
+func runService(opts ExporterOpts) int {
+	elog, err := eventlog.Open(serviceName)
+	if err != nil {
+		return 1
+	}
+	defer elog.Close()
+
+	handler := &serviceHandler{opts: opts, elog: elog}
+	if err := svc.Run(serviceName, handler); err != nil {
+		elog.Error(1, fmt.Sprintf("failed to run service: %v", err))
+		return 1
+	}
+	return int(handler.code)
+}
+
+// serviceHandler implements svc.Handler by running the exporter until the
+// service is stopped.
+type serviceHandler struct {
+	opts ExporterOpts
+	elog *eventlog.Log
+	code uint32
+}
+
+func (h *serviceHandler) Execute(args []string, requests <-chan svc.ChangeRequest, status chan<- svc.Status) (bool, uint32) {
+	status <- svc.Status{State: svc.StartPending}
+
+	shutdown := make(chan struct{})
+	done := make(chan int, 1)
+	go func() {
+		done <- run(h.opts, func(opts *slog.HandlerOptions) slog.Handler {
+			return newEventLogHandler(h.elog, opts)
+		}, shutdown)
+	}()
+
+	const accepts = svc.AcceptStop | svc.AcceptShutdown
+	status <- svc.Status{State: svc.Running, Accepts: accepts}
+	for {
+		select {
+		case code := <-done:
+			h.code = uint32(code)
+			return code != 0, h.code
+		case r := <-requests:
+			switch r.Cmd {
+			case svc.Interrogate:
+				status <- r.CurrentStatus
+			case svc.Stop, svc.Shutdown:
+				status <- svc.Status{State: svc.StopPending}
+				close(shutdown)
+				h.code = uint32(<-done)
+				return h.code != 0, h.code
+			}
+		}
+	}
+}
+
+// eventLogHandler writes log records to the Windows event log, formatted by
+// a text handler, with the event type matching the record level.
+type eventLogHandler struct {
+	slog.Handler
+	elog *eventlog.Log
+
+	// mu guards buf, which the text handler writes each record to. It is
+	// shared by the handlers returned by WithAttrs and WithGroup.
+	mu  *sync.Mutex
+	buf *bytes.Buffer
+}
+
+func newEventLogHandler(elog *eventlog.Log, opts *slog.HandlerOptions) *eventLogHandler {
+	buf := &bytes.Buffer{}
+	return &eventLogHandler{
+		Handler: slog.NewTextHandler(buf, opts),
+		elog:    elog,
+		mu:      &sync.Mutex{},
+		buf:     buf,
+	}
+}
+
+func (h *eventLogHandler) Handle(ctx context.Context, r slog.Record) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.buf.Reset()
+	if err := h.Handler.Handle(ctx, r); err != nil {
+		return err
+	}
+	msg := h.buf.String()
+	switch {
+	case r.Level >= slog.LevelError:
+		return h.elog.Error(1, msg)
+	case r.Level >= slog.LevelWarn:
+		return h.elog.Warning(1, msg)
+	default:
+		return h.elog.Info(1, msg)
+	}
+}
+
+func (h *eventLogHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return &eventLogHandler{Handler: h.Handler.WithAttrs(attrs), elog: h.elog, mu: h.mu, buf: h.buf}
+}
+
+func (h *eventLogHandler) WithGroup(name string) slog.Handler {
+	return &eventLogHandler{Handler: h.Handler.WithGroup(name), elog: h.elog, mu: h.mu, buf: h.buf}
+}
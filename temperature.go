@@ -0,0 +1,136 @@
+package main
+
+import (
+	"log/slog"
+	"time"
+
+	"github.com/NVIDIA/go-nvml/pkg/nvml"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+func init() {
+	registerCollector("temperature", defaultEnabled, newTemperatureCollector)
+	// Scrape intervals are too coarse to catch short threshold breaches.
+	collectorIntervals["temperature"] = 5 * time.Second
+}
+
+// temperatureCollector exports the temperature and slowdown threshold of
+// each device and the temperature of the hottest device of the node. It also
+// counts how often and for how long each device was at or above its
+// slowdown threshold across collections.
+type temperatureCollector struct {
+	logger *slog.Logger
+
+	// breaches holds the slowdown threshold breach state per device uuid.
+	// Devices that are no longer enumerated are dropped.
+	breaches map[string]*thresholdBreaches
+
+	temperature   *prometheus.Desc
+	slowdown      *prometheus.Desc
+	breachesTotal *prometheus.Desc
+	breachSeconds *prometheus.Desc
+	nodeMax       *prometheus.Desc
+}
+
+// thresholdBreaches counts the breaches of a temperature threshold observed
+// by consecutive samples.
+type thresholdBreaches struct {
+	// above is whether the previous sample was at or above the threshold,
+	// taken at sampled.
+	above   bool
+	sampled time.Time
+
+	count   int
+	seconds float64
+}
+
+// observe records a sample taken at now. The time since the previous sample
+// is counted as time above the threshold if both samples are above it.
+func (b *thresholdBreaches) observe(now time.Time, above bool) {
+	if above {
+		if !b.above {
+			b.count++
+		} else {
+			b.seconds += now.Sub(b.sampled).Seconds()
+		}
+	}
+	b.above = above
+	b.sampled = now
+}
+
+func newTemperatureCollector(logger *slog.Logger) collector {
+	return &temperatureCollector{
+		logger:   logger,
+		breaches: make(map[string]*thresholdBreaches),
+		temperature: deviceDesc("", "temperature_celsius",
+			"Temperature of the GPU device in celsius."),
+		slowdown: deviceDesc("", "temperature_slowdown_threshold_celsius",
+			"Temperature at which the GPU device starts to slow down its clocks in celsius."),
+		breachesTotal: deviceDesc("", "temperature_slowdown_breaches_total",
+			"Number of times the temperature of the GPU device was observed to reach its slowdown threshold."),
+		breachSeconds: deviceDesc("", "temperature_slowdown_breach_seconds_total",
+			"Time the temperature of the GPU device was observed at or above its slowdown threshold in seconds, at the resolution of the collection interval."),
+		nodeMax: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, "node", "temperature_max_celsius"),
+			"Temperature of the hottest GPU device of the node in celsius.",
+			nil, nil,
+		),
+	}
+}
+
+func (c *temperatureCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.temperature
+	ch <- c.slowdown
+	ch <- c.breachesTotal
+	ch <- c.breachSeconds
+	ch <- c.nodeMax
+}
+
+func (c *temperatureCollector) Collect(ch chan<- prometheus.Metric, devices []*device) {
+	var nodeMax float64
+	reported := 0
+	present := make(map[string]bool, len(devices))
+	for _, d := range devices {
+		present[d.uuid] = true
+		threshold, thresholdRet := d.GetTemperatureThreshold(nvml.TEMPERATURE_THRESHOLD_SLOWDOWN)
+		if thresholdRet == nvml.SUCCESS {
+			ch <- prometheus.MustNewConstMetric(c.slowdown, prometheus.GaugeValue, float64(threshold), d.labels...)
+		} else {
+			c.logger.Debug("failed to get slowdown temperature threshold", "uuid", d.uuid, "err", thresholdRet)
+		}
+
+		temperature, ret := d.GetTemperature(nvml.TEMPERATURE_GPU)
+		if ret != nvml.SUCCESS {
+			c.logger.Debug("failed to get temperature", "uuid", d.uuid, "err", ret)
+			continue
+		}
+		ch <- prometheus.MustNewConstMetric(c.temperature, prometheus.GaugeValue, float64(temperature), d.labels...)
+
+		if thresholdRet == nvml.SUCCESS {
+			b := c.breaches[d.uuid]
+			if b == nil {
+				b = &thresholdBreaches{}
+				c.breaches[d.uuid] = b
+			}
+			b.observe(time.Now(), temperature >= threshold)
+			ch <- prometheus.MustNewConstMetric(c.breachesTotal, prometheus.CounterValue, float64(b.count), d.labels...)
+			ch <- prometheus.MustNewConstMetric(c.breachSeconds, prometheus.CounterValue, b.seconds, d.labels...)
+		}
+		if reported == 0 || float64(temperature) > nodeMax {
+			nodeMax = float64(temperature)
+		}
+		reported++
+	}
+	if reported > 0 {
+		ch <- prometheus.MustNewConstMetric(c.nodeMax, prometheus.GaugeValue, nodeMax)
+	}
+	if devices == nil {
+		// Enumeration failed, so the devices may still be there.
+		return
+	}
+	for uuid := range c.breaches {
+		if !present[uuid] {
+			delete(c.breaches, uuid)
+		}
+	}
+}
@@ -0,0 +1,84 @@
+package main
+
+import (
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/NVIDIA/go-nvml/pkg/nvml"
+	"github.com/NVIDIA/go-nvml/pkg/nvml/mock"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+func TestThresholdBreachesObserve(t *testing.T) {
+	for _, tc := range []struct {
+		name    string
+		above   []bool
+		count   int
+		seconds float64
+	}{
+		{"below", []bool{false, false, false}, 0, 0},
+		// The first sample has no previous one to measure the time
+		// above the threshold from.
+		{"above from the start", []bool{true}, 1, 0},
+		{"stays above", []bool{true, true, true}, 1, 20},
+		// Only the time between two samples above the threshold is
+		// known to be above it.
+		{"reaches threshold", []bool{false, true}, 1, 0},
+		{"drops below", []bool{false, true, true, false}, 1, 10},
+		{"single sample above", []bool{false, true, false}, 1, 0},
+		{"breaches twice", []bool{false, true, false, false, true, true}, 2, 10},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			b := &thresholdBreaches{}
+			now := time.Unix(1_700_000_000, 0)
+			for _, above := range tc.above {
+				b.observe(now, above)
+				now = now.Add(10 * time.Second)
+			}
+			if b.count != tc.count || b.seconds != tc.seconds {
+				t.Errorf("count, seconds = %d, %v, want %d, %v", b.count, b.seconds, tc.count, tc.seconds)
+			}
+		})
+	}
+}
+
+func TestTemperatureCollectorPrunesBreaches(t *testing.T) {
+	defer func(labels []string) { deviceLabels = labels }(deviceLabels)
+	deviceLabels = []string{"uuid"}
+
+	c := newTemperatureCollector(slog.New(slog.DiscardHandler)).(*temperatureCollector)
+	newDevice := func(uuid string) *device {
+		return &device{
+			uuid:   uuid,
+			labels: []string{uuid},
+			Device: &mock.Device{
+				GetTemperatureThresholdFunc: func(nvml.TemperatureThresholds) (uint32, nvml.Return) {
+					return 90, nvml.SUCCESS
+				},
+				GetTemperatureFunc: func(nvml.TemperatureSensors) (uint32, nvml.Return) {
+					return 95, nvml.SUCCESS
+				},
+			},
+		}
+	}
+	collect := func(devices []*device) {
+		ch := make(chan prometheus.Metric)
+		go func() {
+			c.Collect(ch, devices)
+			close(ch)
+		}()
+		for range ch {
+		}
+	}
+
+	collect([]*device{newDevice("GPU-0"), newDevice("GPU-1")})
+	collect(nil)
+	if len(c.breaches) != 2 {
+		t.Errorf("failed enumeration dropped breaches, %d left", len(c.breaches))
+	}
+	collect([]*device{newDevice("GPU-0")})
+	if _, ok := c.breaches["GPU-1"]; ok || len(c.breaches) != 1 {
+		t.Errorf("breaches of a device that is no longer enumerated were kept")
+	}
+}
@@ -0,0 +1,115 @@
+package main
+
+import (
+	"log/slog"
+
+	"github.com/NVIDIA/go-nvml/pkg/nvml"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+func init() {
+	registerCollector("utilization", defaultEnabled, newUtilizationCollector)
+}
+
+// encoderTypeNames maps NVML encoder types to the values of the codec label.
+var encoderTypeNames = map[nvml.EncoderType]string{
+	nvml.ENCODER_QUERY_H264: "h264",
+	nvml.ENCODER_QUERY_HEVC: "hevc",
+}
+
+// utilizationCollector exports how busy the compute engines, the video
+// encoders and decoders and the memory of each device are, the remaining
+// capacity of its video encoders, and the average duty cycle of the node.
+type utilizationCollector struct {
+	logger *slog.Logger
+
+	gpm *gpmSampler
+
+	dutyCycle           *prometheus.Desc
+	memoryDutyCycle     *prometheus.Desc
+	memoryBandwidthUtil *prometheus.Desc
+	encoderCapacity     *prometheus.Desc
+	encoderUtil         *prometheus.Desc
+	decoderUtil         *prometheus.Desc
+	nodeDutyCycle       *prometheus.Desc
+}
+
+func newUtilizationCollector(logger *slog.Logger) collector {
+	return &utilizationCollector{
+		logger: logger,
+		gpm:    newGPMSampler(logger),
+		dutyCycle: deviceDesc("", "duty_cycle",
+			"Percent of time over the past sample period during which one or more kernels were executing on the GPU device."),
+		memoryDutyCycle: deviceDesc("memory", "duty_cycle",
+			"Percent of time over the past sample period during which device memory was being read or written."),
+		memoryBandwidthUtil: deviceDesc("memory", "bandwidth_utilization_percent",
+			"Achieved DRAM bandwidth as a percent of the theoretical peak since the previous collection. Requires GPM support (Hopper or newer)."),
+		encoderCapacity: deviceDesc("encoder", "capacity_percent",
+			"Remaining capacity of the video encoders of the GPU device for the codec as a percent of their full capacity.",
+			"codec"),
+		encoderUtil: deviceDesc("encoder", "utilization_percent",
+			"Percent of time over the past sample period during which the video encoders of the GPU device were busy."),
+		decoderUtil: deviceDesc("decoder", "utilization_percent",
+			"Percent of time over the past sample period during which the video decoders of the GPU device were busy."),
+		nodeDutyCycle: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, "node", "duty_cycle_average"),
+			"Average duty cycle of the GPU devices of the node.",
+			nil, nil,
+		),
+	}
+}
+
+func (c *utilizationCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.dutyCycle
+	ch <- c.memoryDutyCycle
+	ch <- c.memoryBandwidthUtil
+	ch <- c.encoderCapacity
+	ch <- c.encoderUtil
+	ch <- c.decoderUtil
+	ch <- c.nodeDutyCycle
+}
+
+func (c *utilizationCollector) Collect(ch chan<- prometheus.Metric, devices []*device) {
+	defer c.gpm.sweep()
+	var nodeDutyCycle float64
+	reported := 0
+	for _, d := range devices {
+		if value, ok := c.gpm.metrics(d, nvml.GPM_METRIC_DRAM_BW_UTIL)[nvml.GPM_METRIC_DRAM_BW_UTIL]; ok {
+			ch <- prometheus.MustNewConstMetric(c.memoryBandwidthUtil, prometheus.GaugeValue, value, d.labels...)
+		}
+
+		for encoderType, codec := range encoderTypeNames {
+			capacity, ret := d.GetEncoderCapacity(encoderType)
+			if ret != nvml.SUCCESS {
+				c.logger.Debug("failed to get encoder capacity", "uuid", d.uuid, "codec", codec, "err", ret)
+				continue
+			}
+			ch <- prometheus.MustNewConstMetric(c.encoderCapacity, prometheus.GaugeValue, float64(capacity), d.labelsWith(codec)...)
+		}
+
+		// The second value is the sampling period, which isn't exported.
+		if utilization, _, ret := d.GetEncoderUtilization(); ret == nvml.SUCCESS {
+			ch <- prometheus.MustNewConstMetric(c.encoderUtil, prometheus.GaugeValue, float64(utilization), d.labels...)
+		} else {
+			c.logger.Debug("failed to get encoder utilization", "uuid", d.uuid, "err", ret)
+		}
+		if utilization, _, ret := d.GetDecoderUtilization(); ret == nvml.SUCCESS {
+			ch <- prometheus.MustNewConstMetric(c.decoderUtil, prometheus.GaugeValue, float64(utilization), d.labels...)
+		} else {
+			c.logger.Debug("failed to get decoder utilization", "uuid", d.uuid, "err", ret)
+		}
+
+		utilization, ret := d.GetUtilizationRates()
+		if ret != nvml.SUCCESS {
+			c.logger.Debug("failed to get utilization rates", "uuid", d.uuid, "err", ret)
+			continue
+		}
+		ch <- prometheus.MustNewConstMetric(c.dutyCycle, prometheus.GaugeValue, float64(utilization.Gpu), d.labels...)
+		ch <- prometheus.MustNewConstMetric(c.memoryDutyCycle, prometheus.GaugeValue, float64(utilization.Memory), d.labels...)
+		nodeDutyCycle += float64(utilization.Gpu)
+		reported++
+	}
+	if reported > 0 {
+		ch <- prometheus.MustNewConstMetric(c.nodeDutyCycle, prometheus.GaugeValue, nodeDutyCycle/float64(reported))
+	}
+}
@@ -0,0 +1,97 @@
+package main
+
+import (
+	"log/slog"
+	"time"
+
+	"github.com/NVIDIA/go-nvml/pkg/nvml"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+func init() {
+	registerCollector("vgpu", defaultEnabled, newVgpuCollector)
+}
+
+// gridFeatureNames maps GRID license feature codes to the values of the
+// feature label.
+var gridFeatureNames = map[uint32]string{
+	uint32(nvml.GRID_LICENSE_FEATURE_CODE_UNKNOWN):    "unknown",
+	uint32(nvml.GRID_LICENSE_FEATURE_CODE_VGPU):       "vgpu",
+	uint32(nvml.GRID_LICENSE_FEATURE_CODE_NVIDIA_RTX): "nvidia_rtx",
+	uint32(nvml.GRID_LICENSE_FEATURE_CODE_GAMING):     "gaming",
+	uint32(nvml.GRID_LICENSE_FEATURE_CODE_COMPUTE):    "compute",
+	uint32(nvml.GRID_LICENSE_FEATURE_CODE_VGAMEDEV):   "vgamedev",
+}
+
+// vgpuCollector exports the license state of each licensable feature on
+// vGPU guests, where an unlicensed device runs with restricted performance.
+type vgpuCollector struct {
+	logger *slog.Logger
+
+	licensed      *prometheus.Desc
+	licenseExpiry *prometheus.Desc
+}
+
+func newVgpuCollector(logger *slog.Logger) collector {
+	return &vgpuCollector{
+		logger: logger,
+		licensed: deviceDesc("vgpu", "license_licensed",
+			"Whether the licensable feature is licensed on the vGPU guest (1) or not (0).",
+			"feature"),
+		licenseExpiry: deviceDesc("vgpu", "license_expiry_timestamp_seconds",
+			"Expiry time of the license for the licensable feature in seconds since the Unix epoch.",
+			"feature"),
+	}
+}
+
+func (c *vgpuCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.licensed
+	ch <- c.licenseExpiry
+}
+
+func (c *vgpuCollector) Collect(ch chan<- prometheus.Metric, devices []*device) {
+	for _, d := range devices {
+		c.collectLicense(ch, d)
+	}
+}
+
+func (c *vgpuCollector) collectLicense(ch chan<- prometheus.Metric, d *device) {
+	mode, ret := d.GetVirtualizationMode()
+	if ret != nvml.SUCCESS || mode != nvml.GPU_VIRTUALIZATION_MODE_VGPU {
+		return
+	}
+
+	features, ret := d.GetGridLicensableFeatures()
+	if ret != nvml.SUCCESS {
+		c.logger.Debug("failed to get licensable features", "uuid", d.uuid, "err", ret)
+		return
+	}
+	if features.IsGridLicenseSupported == 0 {
+		return
+	}
+
+	count := int(features.LicensableFeaturesCount)
+	if count > len(features.GridLicensableFeatures) {
+		count = len(features.GridLicensableFeatures)
+	}
+	for _, feature := range features.GridLicensableFeatures[:count] {
+		name, ok := gridFeatureNames[feature.FeatureCode]
+		if !ok {
+			name = gridFeatureNames[uint32(nvml.GRID_LICENSE_FEATURE_CODE_UNKNOWN)]
+		}
+		featureLabels := d.labelsWith(name)
+
+		licensed := 0.0
+		if feature.FeatureState == nvml.GRID_LICENSE_STATE_LICENSED {
+			licensed = 1
+		}
+		ch <- prometheus.MustNewConstMetric(c.licensed, prometheus.GaugeValue, licensed, featureLabels...)
+
+		expiry := feature.LicenseExpiry
+		if expiry.Status == nvml.GRID_LICENSE_EXPIRY_VALID {
+			t := time.Date(int(expiry.Year), time.Month(expiry.Month), int(expiry.Day),
+				int(expiry.Hour), int(expiry.Min), int(expiry.Sec), 0, time.UTC)
+			ch <- prometheus.MustNewConstMetric(c.licenseExpiry, prometheus.GaugeValue, float64(t.Unix()), featureLabels...)
+		}
+	}
+}
@@ -0,0 +1,227 @@
+package main
+
+import (
+	"log/slog"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/NVIDIA/go-nvml/pkg/nvml"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+func init() {
+	registerCollector("xid", defaultEnabled, newXidCollector)
+}
+
+const (
+	// xidWaitTimeout bounds each wait for an event, so the watcher notices
+	// failures of the event set and being stopped in time.
+	xidWaitTimeout = time.Second
+	// xidRetryBackoff is the minimum time between the failure of an event
+	// set and the creation of the next one.
+	xidRetryBackoff = time.Minute
+)
+
+// xidCollector counts the XID errors of each device. Collections register
+// the devices with an NVML event set, and a watcher goroutine waits on it and
+// counts every XID error as it occurs, so errors that clear before the next
+// scrape are still exported.
+type xidCollector struct {
+	logger *slog.Logger
+
+	errors    *prometheus.Desc
+	lastError *prometheus.Desc
+
+	// mu guards the fields below, which are shared with the watcher.
+	mu sync.Mutex
+	// set is the event set the devices are registered with. It is nil
+	// until the first collection, after the watcher gave up on it and once
+	// the collector is stopped.
+	set nvml.EventSet
+	// done is closed to stop the watcher of set, and stopped is closed by
+	// the watcher when it returns.
+	done, stopped chan struct{}
+	// session is the NVML session set was created in.
+	session uint64
+	// failed is when creating an event set or waiting on it last failed.
+	failed time.Time
+	// registered holds the devices registered with set by uuid.
+	registered map[string]*device
+	// counts holds the XID errors observed per device uuid.
+	counts map[string]*xidErrors
+}
+
+// xidErrors holds the XID errors observed on a device.
+type xidErrors struct {
+	// device is the most recent enumeration of the device, for its labels.
+	device *device
+	byXid  map[uint64]int
+	last   time.Time
+}
+
+func newXidCollector(logger *slog.Logger) collector {
+	return &xidCollector{
+		logger: logger,
+		errors: deviceDesc("", "xid_errors_total",
+			"Number of XID errors of the GPU device by XID since the exporter started.",
+			"xid"),
+		lastError: deviceDesc("", "last_xid_error_timestamp_seconds",
+			"Time of the most recent XID error of the GPU device since unix epoch in seconds."),
+		session:    nvmlSession.Load(),
+		registered: make(map[string]*device),
+		counts:     make(map[string]*xidErrors),
+	}
+}
+
+// stop stops the watcher and waits for it to return. The next collection
+// starts a new one.
+func (c *xidCollector) stop() {
+	c.mu.Lock()
+	stopped := c.stopWatcher()
+	c.mu.Unlock()
+	if stopped != nil {
+		<-stopped
+	}
+}
+
+// stopWatcher tells the watcher of set to stop, and returns the channel that
+// is closed once it did, or nil if there is none. It must be called with mu
+// held.
+func (c *xidCollector) stopWatcher() chan struct{} {
+	if c.set == nil {
+		return nil
+	}
+	close(c.done)
+	c.set = nil
+	return c.stopped
+}
+
+func (c *xidCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.errors
+	ch <- c.lastError
+}
+
+func (c *xidCollector) Collect(ch chan<- prometheus.Metric, devices []*device) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.register(devices)
+	for _, e := range c.counts {
+		for xid, count := range e.byXid {
+			ch <- prometheus.MustNewConstMetric(c.errors, prometheus.CounterValue, float64(count), e.device.labelsWith(strconv.FormatUint(xid, 10))...)
+		}
+		ch <- prometheus.MustNewConstMetric(c.lastError, prometheus.GaugeValue, float64(e.last.UnixNano())/1e9, e.device.labels...)
+	}
+}
+
+// register registers the devices that aren't yet with the event set,
+// creating it and starting the watcher if needed. It must be called with mu
+// held.
+func (c *xidCollector) register(devices []*device) {
+	if session := nvmlSession.Load(); session != c.session {
+		// Event sets don't survive a reinitialization of NVML, so a new one
+		// is created right away.
+		c.stopWatcher()
+		c.failed = time.Time{}
+		c.session = session
+	}
+	if c.set == nil {
+		if time.Since(c.failed) < xidRetryBackoff {
+			return
+		}
+		set, ret := nvml.EventSetCreate()
+		if ret != nvml.SUCCESS {
+			c.logger.Debug("failed to create event set", "err", ret)
+			c.failed = time.Now()
+			return
+		}
+		c.set = set
+		c.done = make(chan struct{})
+		c.stopped = make(chan struct{})
+		clear(c.registered)
+		go c.watch(set, c.done, c.stopped)
+	}
+
+	for _, d := range devices {
+		if _, ok := c.registered[d.uuid]; ok {
+			continue
+		}
+		supported, ret := d.GetSupportedEventTypes()
+		if ret != nvml.SUCCESS {
+			c.logger.Debug("failed to get supported event types", "uuid", d.uuid, "err", ret)
+			continue
+		}
+		if supported&nvml.EventTypeXidCriticalError == 0 {
+			c.logger.Debug("XID events are not supported", "uuid", d.uuid)
+			c.registered[d.uuid] = d
+			continue
+		}
+		if ret := d.RegisterEvents(nvml.EventTypeXidCriticalError, c.set); ret != nvml.SUCCESS {
+			c.logger.Debug("failed to register for XID events", "uuid", d.uuid, "err", ret)
+			continue
+		}
+		c.registered[d.uuid] = d
+		if e, ok := c.counts[d.uuid]; ok {
+			e.device = d
+		}
+	}
+}
+
+// watch counts the XID errors delivered to set until done is closed or
+// waiting on set fails, e.g. because NVML was reinitialized, then frees set
+// and closes stopped. After a failure, the next collection past
+// xidRetryBackoff creates a new event set.
+func (c *xidCollector) watch(set nvml.EventSet, done, stopped chan struct{}) {
+	defer close(stopped)
+	defer set.Free()
+	for {
+		select {
+		case <-done:
+			return
+		default:
+		}
+		data, ret := set.Wait(uint32(xidWaitTimeout.Milliseconds()))
+		if ret == nvml.ERROR_TIMEOUT {
+			continue
+		}
+		if ret != nvml.SUCCESS {
+			c.mu.Lock()
+			defer c.mu.Unlock()
+			if c.set == set {
+				c.logger.Warn("failed to wait for XID events", "err", ret, "retry_in", xidRetryBackoff)
+				c.set = nil
+				c.failed = time.Now()
+			}
+			return
+		}
+		if data.EventType != nvml.EventTypeXidCriticalError {
+			continue
+		}
+		c.record(data)
+	}
+}
+
+// record counts the XID error of an event.
+func (c *xidCollector) record(data nvml.EventData) {
+	uuid, ret := data.Device.GetUUID()
+	if ret != nvml.SUCCESS {
+		c.logger.Debug("failed to get uuid of XID event device", "xid", data.EventData, "err", ret)
+		return
+	}
+	c.logger.Warn("observed XID error", "uuid", uuid, "xid", data.EventData)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	e, ok := c.counts[uuid]
+	if !ok {
+		d, ok := c.registered[uuid]
+		if !ok {
+			return
+		}
+		e = &xidErrors{device: d, byXid: make(map[uint64]int)}
+		c.counts[uuid] = e
+	}
+	e.byXid[data.EventData]++
+	e.last = time.Now()
+}